@@ -0,0 +1,8 @@
+data "matrix_public_rooms" "gaming" {
+  generic_search_term = "gaming"
+  max_rooms           = 200
+}
+
+output "gaming_room_ids" {
+  value = data.matrix_public_rooms.gaming.rooms[*].room_id
+}
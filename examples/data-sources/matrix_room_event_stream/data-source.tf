@@ -0,0 +1,12 @@
+data "matrix_room_event_stream" "alerts" {
+  room_id = "!room:example.com"
+  limit   = 20
+  filter = jsonencode({
+    types   = ["m.room.message"]
+    senders = ["@alertbot:example.com"]
+  })
+}
+
+output "latest_alert" {
+  value = try(jsondecode(data.matrix_room_event_stream.alerts.chunk[0].content_json).body, null)
+}
@@ -0,0 +1,12 @@
+# Everyone who reacted to a poll
+data "matrix_room_relations" "poll_votes" {
+  room_id    = "!room:example.com"
+  event_id   = "$poll"
+  rel_type   = "m.annotation"
+  event_type = "m.reaction"
+  limit      = 1000
+}
+
+output "voters" {
+  value = distinct([for event in data.matrix_room_relations.poll_votes.events : event.sender])
+}
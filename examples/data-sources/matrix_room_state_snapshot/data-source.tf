@@ -0,0 +1,13 @@
+data "matrix_room_state_snapshot" "lobby" {
+  room_id = "!room:example.com"
+}
+
+# Only the settings relevant for an audit
+data "matrix_room_state_snapshot" "lobby_access" {
+  room_id     = "!room:example.com"
+  type_filter = ["m.room.join_rules", "m.room.history_visibility", "m.room.power_levels"]
+}
+
+output "lobby_state_hash" {
+  value = data.matrix_room_state_snapshot.lobby.state_hash
+}
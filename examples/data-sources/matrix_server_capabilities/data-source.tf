@@ -0,0 +1,6 @@
+data "matrix_server_capabilities" "homeserver" {}
+
+# Pin new rooms to the version the homeserver currently recommends
+resource "matrix_room" "lobby" {
+  room_version = data.matrix_server_capabilities.homeserver.default_room_version
+}
@@ -0,0 +1,5 @@
+data "matrix_synapse_background_update_status" "current" {}
+
+output "background_updates_done" {
+  value = data.matrix_synapse_background_update_status.current.all_complete
+}
@@ -0,0 +1,7 @@
+data "matrix_synapse_forward_extremities" "lobby" {
+  room_id = "!someroom:example.com"
+}
+
+output "lobby_forward_extremities" {
+  value = data.matrix_synapse_forward_extremities.lobby.extremity_count
+}
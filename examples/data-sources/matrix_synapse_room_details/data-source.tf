@@ -0,0 +1,14 @@
+data "matrix_synapse_room_details" "support" {
+  room_id = "!room:example.com"
+
+  lifecycle {
+    postcondition {
+      condition     = self.joined_local_members == 0
+      error_message = "Local users are still joined to the room."
+    }
+  }
+}
+
+output "banned_users" {
+  value = [for member in data.matrix_synapse_room_details.support.members : member.user_id if member.membership == "ban"]
+}
@@ -0,0 +1,9 @@
+data "matrix_synapse_room_event_context" "suspicious" {
+  room_id  = "!room:example.com"
+  event_id = "$event"
+  limit    = 5
+}
+
+output "suspicious_sender" {
+  value = jsondecode(data.matrix_synapse_room_event_context.suspicious.event).sender
+}
@@ -0,0 +1,9 @@
+data "matrix_synapse_room_report" "lobby" {
+  room_id = "!lobby:example.com"
+  dir     = "f"
+  limit   = 50
+}
+
+output "lobby_reported_events" {
+  value = [for report in data.matrix_synapse_room_report.lobby.event_reports : report.event_id]
+}
@@ -0,0 +1,13 @@
+resource "time_static" "now" {}
+
+# The last event sent more than 30 days ago.
+data "matrix_synapse_room_timestamp_to_event" "retention" {
+  room_id = "!room:example.com"
+  ts      = (time_static.now.unix - 30 * 24 * 60 * 60) * 1000
+  dir     = "b"
+}
+
+resource "matrix_synapse_purge_history" "retention" {
+  room_id              = data.matrix_synapse_room_timestamp_to_event.retention.room_id
+  purge_up_to_event_id = data.matrix_synapse_room_timestamp_to_event.retention.event_id
+}
@@ -0,0 +1,13 @@
+data "matrix_synapse_stats_room" "largest" {
+  order_by = "joined_members"
+  dir      = "b"
+  limit    = 10
+}
+
+output "largest_rooms" {
+  value = { for room in data.matrix_synapse_stats_room.largest.rooms : room.room_id => room.joined_members }
+}
+
+output "unencrypted_rooms" {
+  value = [for room in data.matrix_synapse_stats_room.largest.rooms : room.room_id if room.encryption == null]
+}
@@ -0,0 +1,12 @@
+data "matrix_synapse_stats_user" "all" {
+  guests    = false
+  max_users = 5000
+}
+
+output "heaviest_uploaders" {
+  value = [for user in data.matrix_synapse_stats_user.all.users : user.name if user.media_count > 1000]
+}
+
+output "admins" {
+  value = [for user in data.matrix_synapse_stats_user.all.users : user.name if user.admin]
+}
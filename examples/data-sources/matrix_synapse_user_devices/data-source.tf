@@ -0,0 +1,7 @@
+data "matrix_synapse_user_devices" "alice" {
+  user_id = "@alice:example.com"
+}
+
+output "alice_device_ips" {
+  value = [for device in data.matrix_synapse_user_devices.alice.devices : device.last_seen_ip]
+}
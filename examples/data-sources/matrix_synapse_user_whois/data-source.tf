@@ -0,0 +1,20 @@
+data "matrix_synapse_user_whois" "deploy_bot" {
+  user_id = "@deploy-bot:example.com"
+}
+
+locals {
+  deploy_bot_ips = distinct(flatten([
+    for device in data.matrix_synapse_user_whois.deploy_bot.devices : [
+      for session in device.sessions : [
+        for connection in session.connections : connection.ip
+      ]
+    ]
+  ]))
+}
+
+check "deploy_bot_ip_range" {
+  assert {
+    condition     = alltrue([for ip in local.deploy_bot_ips : startswith(ip, "10.")])
+    error_message = "The deploy bot connected from outside 10.0.0.0/8: ${join(", ", local.deploy_bot_ips)}"
+  }
+}
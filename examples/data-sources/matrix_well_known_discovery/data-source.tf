@@ -0,0 +1,7 @@
+data "matrix_well_known_discovery" "example" {
+  server_name = "example.com"
+}
+
+output "homeserver_url" {
+  value = data.matrix_well_known_discovery.example.homeserver_url
+}
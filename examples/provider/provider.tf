@@ -13,4 +13,36 @@ provider "matrix" {
   # Does not apply for provisioning users.
   # Environment variable: MATRIX_DEFAULT_USERID
   default_user_id = "@meow:matrix.org"
-}
\ No newline at end of file
+
+  # Refuse to destroy any matrix_room, e.g. for production homeservers.
+  prevent_destroy_rooms = true
+}
+# Resolve the homeserver URL from the server name of the user
+provider "matrix" {
+  alias = "discovered"
+
+  discover_well_known  = true
+  default_access_token = "MDAxYetAnotherRandomString"
+  default_user_id      = "@admin:example.com"
+}
+
+# One aliased provider block per homeserver
+provider "matrix" {
+  alias = "internal"
+
+  client_server_url    = "https://matrix.internal.example.com"
+  default_access_token = "MDAxSomeOtherRandomString"
+  default_user_id      = "@admin:internal.example.com"
+}
+
+resource "matrix_room" "internal_lobby" {
+  provider = matrix.internal
+}
+
+module "rooms" {
+  source = "./rooms"
+
+  providers = {
+    matrix = matrix.internal
+  }
+}
@@ -0,0 +1 @@
+terraform import matrix_account_data.bot_config "@bot:example.com/org.example.bot.config"
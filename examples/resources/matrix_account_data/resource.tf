@@ -0,0 +1,8 @@
+# Configuration a bot reads from its own account data
+resource "matrix_account_data" "bot_config" {
+  type = "org.example.bot.config"
+  data = jsonencode({
+    admins  = ["@alice:example.com"]
+    command = "!bot"
+  })
+}
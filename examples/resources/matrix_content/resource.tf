@@ -0,0 +1,14 @@
+resource "matrix_content" "logo" {
+  source = "${path.module}/logo.png"
+}
+
+resource "matrix_space" "company" {
+  name       = "Company"
+  avatar_url = matrix_content.logo.content_uri
+}
+
+# Large file, the upload is retried by the next apply if it fails
+resource "matrix_content" "installer" {
+  source       = "${path.module}/dist/installer.msi"
+  async_upload = true
+}
@@ -0,0 +1 @@
+terraform import matrix_dm.oncall "!abcdefghijklmnop:example.com"
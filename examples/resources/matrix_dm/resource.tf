@@ -0,0 +1,8 @@
+# Notification channel from CI to the on-call engineer
+resource "matrix_dm" "oncall" {
+  user_id = "@oncall:example.com"
+}
+
+output "oncall_room_id" {
+  value = matrix_dm.oncall.room_id
+}
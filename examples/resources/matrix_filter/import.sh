@@ -0,0 +1 @@
+terraform import matrix_filter.bot "@bot:example.com/0"
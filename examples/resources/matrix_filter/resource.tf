@@ -0,0 +1,14 @@
+# Only sync messages for the bot
+resource "matrix_filter" "bot" {
+  filter_json = jsonencode({
+    presence     = { types = [] }
+    account_data = { types = [] }
+    room = {
+      timeline = { types = ["m.room.message"] }
+    }
+  })
+}
+
+output "bot_filter_id" {
+  value = matrix_filter.bot.filter_id
+}
@@ -0,0 +1 @@
+terraform import matrix_ignored_users.moderation "@moderation:example.com"
@@ -0,0 +1,7 @@
+# Ignore known spammers with the moderation bot
+resource "matrix_ignored_users" "moderation" {
+  ignored_user_ids = [
+    "@spam:example.com",
+    "@troll:example.org",
+  ]
+}
@@ -0,0 +1 @@
+terraform import matrix_policy_room.spam "!abcdefghijklmnop:example.com"
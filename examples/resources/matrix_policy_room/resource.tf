@@ -0,0 +1,12 @@
+resource "matrix_policy_room" "spam" {
+  name      = "Spam ban list"
+  topic     = "Users and servers banned for spam"
+  shortcode = "spam"
+}
+
+# Let the trust & safety team edit the list
+resource "matrix_room_power_level_user" "moderator" {
+  room_id = matrix_policy_room.spam.room_id
+  user_id = "@alice:example.com"
+  level   = 50
+}
@@ -0,0 +1 @@
+terraform import matrix_policy_rule.spammer "!abcdefghijklmnop:example.com/user/rule:@spam:example.com"
@@ -0,0 +1,18 @@
+resource "matrix_policy_room" "spam" {
+  name      = "Spam ban list"
+  shortcode = "spam"
+}
+
+resource "matrix_policy_rule" "spammer" {
+  room_id     = matrix_policy_room.spam.room_id
+  entity_type = "user"
+  entity      = "@spam:example.com"
+  reason      = "spam"
+}
+
+resource "matrix_policy_rule" "evil_servers" {
+  room_id     = matrix_policy_room.spam.room_id
+  entity_type = "server"
+  entity      = "*.evil.example"
+  reason      = "spam and abuse"
+}
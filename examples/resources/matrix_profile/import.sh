@@ -0,0 +1 @@
+terraform import matrix_profile.bot "@bot:example.com"
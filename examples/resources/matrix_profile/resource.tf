@@ -0,0 +1,4 @@
+resource "matrix_profile" "bot" {
+  displayname   = "Build Bot"
+  avatar_source = "${path.module}/bot.png"
+}
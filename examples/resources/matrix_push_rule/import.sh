@@ -0,0 +1 @@
+terraform import matrix_push_rule.deploy_failed "override/deploy-failed"
@@ -0,0 +1,16 @@
+# Highlight failed deployments for the bot account
+resource "matrix_push_rule" "deploy_failed" {
+  kind    = "override"
+  rule_id = "deploy-failed"
+  conditions_json = jsonencode([
+    { kind = "event_match", key = "content.body", pattern = "*deploy failed*" },
+  ])
+  actions_json = jsonencode(["notify", { set_tweak = "highlight" }])
+}
+
+# Never notify for messages of a noisy bridge
+resource "matrix_push_rule" "bridge" {
+  kind         = "sender"
+  rule_id      = "@bridge:example.com"
+  actions_json = jsonencode([])
+}
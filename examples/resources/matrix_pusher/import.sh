@@ -0,0 +1 @@
+terraform import matrix_pusher.monitoring "com.example.alerts/monitoring"
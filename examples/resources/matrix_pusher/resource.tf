@@ -0,0 +1,9 @@
+# Forward the notifications of the monitoring account to Sygnal
+resource "matrix_pusher" "monitoring" {
+  app_id              = "com.example.alerts"
+  pushkey             = "monitoring"
+  app_display_name    = "Alerts"
+  device_display_name = "Monitoring"
+  url                 = "https://sygnal.example.com/_matrix/push/v1/notify"
+  format              = "event_id_only"
+}
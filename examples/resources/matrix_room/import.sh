@@ -0,0 +1 @@
+terraform import matrix_room.lobby "!room:example.com"
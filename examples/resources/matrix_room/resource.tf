@@ -0,0 +1,38 @@
+resource "matrix_room" "lobby" {
+  name         = "Lobby"
+  topic        = "Say hi!"
+  visibility   = "public"
+  preset       = "public_chat"
+  invite       = ["@alice:example.com"]
+  room_version = "10"
+}
+
+# Encrypted, invite-only room without a window in which it is neither
+resource "matrix_room" "board" {
+  initial_state = [
+    {
+      type         = "m.room.encryption"
+      content_json = jsonencode({ algorithm = "m.megolm.v1.aes-sha2" })
+    },
+    {
+      type         = "m.room.join_rules"
+      content_json = jsonencode({ join_rule = "invite" })
+    },
+  ]
+}
+
+# A space to group rooms
+resource "matrix_room" "space" {
+  creation_content_json = jsonencode({ type = "m.space" })
+}
+
+# Internal room that users of other homeservers can never join
+resource "matrix_room" "internal" {
+  federate = false
+}
+
+# Temporary room that is purged from the homeserver when destroyed
+resource "matrix_room" "incident" {
+  name       = "Incident 42"
+  on_destroy = "delete"
+}
@@ -0,0 +1 @@
+terraform import matrix_room_account_data.bot_state "@bot:example.com/!room:example.com/org.example.bot.state"
@@ -0,0 +1,8 @@
+# Remember the last event a bot has processed in a room
+resource "matrix_room_account_data" "bot_state" {
+  room_id = "!room:example.com"
+  type    = "org.example.bot.state"
+  data = jsonencode({
+    last_processed_event = "$event"
+  })
+}
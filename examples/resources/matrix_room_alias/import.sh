@@ -0,0 +1 @@
+terraform import matrix_room_alias.lobby "#lobby:example.com"
@@ -0,0 +1,8 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+resource "matrix_room_alias" "lobby" {
+  alias   = "#lobby:example.com"
+  room_id = matrix_room.lobby.room_id
+}
@@ -0,0 +1 @@
+terraform import matrix_room_avatar.lobby "!room:example.com"
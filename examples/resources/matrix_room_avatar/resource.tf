@@ -0,0 +1,19 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+# Uploaded again whenever the image changes
+resource "matrix_room_avatar" "lobby" {
+  room_id = matrix_room.lobby.room_id
+  source  = "${path.module}/lobby.png"
+}
+
+# Media that is already in the content repository
+resource "matrix_room" "board" {
+  name = "Board"
+}
+
+resource "matrix_room_avatar" "board" {
+  room_id = matrix_room.board.room_id
+  url     = "mxc://example.com/board-avatar"
+}
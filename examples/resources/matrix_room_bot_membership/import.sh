@@ -0,0 +1,2 @@
+# The rooms are not imported, the next apply invites the bot to the configured rooms again.
+terraform import matrix_room_bot_membership.alerts "@alerts:example.com"
@@ -0,0 +1,17 @@
+variable "bot_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "matrix_room_bot_membership" "alerts" {
+  user_id  = "@alerts:example.com"
+  password = var.bot_password
+  # Bump to set the password to the current value of var.bot_password again.
+  password_wo_version = 1
+  display_name        = "Alerts"
+  room_ids = [
+    "!ops:example.com",
+    "!oncall:example.com",
+  ]
+}
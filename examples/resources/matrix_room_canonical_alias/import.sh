@@ -0,0 +1 @@
+terraform import matrix_room_canonical_alias.lobby "!room:example.com"
@@ -0,0 +1,20 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+resource "matrix_room_alias" "lobby" {
+  alias   = "#lobby:example.org"
+  room_id = matrix_room.lobby.room_id
+}
+
+resource "matrix_room_alias" "lobby_old" {
+  alias   = "#lobby:example.com"
+  room_id = matrix_room.lobby.room_id
+}
+
+# Advertise the alias on the new domain, keep the old one as alternative
+resource "matrix_room_canonical_alias" "lobby" {
+  room_id     = matrix_room.lobby.room_id
+  alias       = matrix_room_alias.lobby.alias
+  alt_aliases = [matrix_room_alias.lobby_old.alias]
+}
@@ -0,0 +1 @@
+terraform import matrix_room_directory_listing.lobby "!room:example.com"
@@ -0,0 +1,6 @@
+resource "matrix_room" "lobby" {}
+
+resource "matrix_room_directory_listing" "lobby" {
+  room_id    = matrix_room.lobby.room_id
+  visibility = "public"
+}
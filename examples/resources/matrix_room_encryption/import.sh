@@ -0,0 +1 @@
+terraform import matrix_room_encryption.lobby "!room:example.com"
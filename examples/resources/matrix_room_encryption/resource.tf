@@ -0,0 +1,19 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+resource "matrix_room_encryption" "lobby" {
+  room_id              = matrix_room.lobby.room_id
+  rotation_period_msgs = 50
+}
+
+# Rooms which must never contain unencrypted messages enable encryption when
+# they are created instead
+resource "matrix_room" "board" {
+  name = "Board"
+
+  initial_state = [{
+    type         = "m.room.encryption"
+    content_json = jsonencode({ algorithm = "m.megolm.v1.aes-sha2" })
+  }]
+}
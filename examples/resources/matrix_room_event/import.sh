@@ -0,0 +1 @@
+terraform import matrix_room_event.config '!room:example.com/$event'
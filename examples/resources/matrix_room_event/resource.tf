@@ -0,0 +1,19 @@
+# A custom state event
+resource "matrix_room_event" "config" {
+  room_id    = "!room:example.com"
+  event_type = "org.example.bot.config"
+  state_key  = ""
+  content = jsonencode({
+    prefix = "!"
+  })
+}
+
+# A message event
+resource "matrix_room_event" "welcome" {
+  room_id    = "!room:example.com"
+  event_type = "m.room.message"
+  content = jsonencode({
+    msgtype = "m.notice"
+    body    = "Welcome to the room!"
+  })
+}
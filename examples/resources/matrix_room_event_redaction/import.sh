@@ -0,0 +1 @@
+terraform import matrix_room_event_redaction.spam '!room:example.com/$event'
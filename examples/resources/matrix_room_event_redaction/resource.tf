@@ -0,0 +1,5 @@
+resource "matrix_room_event_redaction" "spam" {
+  room_id  = "!room:example.com"
+  event_id = "$event"
+  reason   = "Spam"
+}
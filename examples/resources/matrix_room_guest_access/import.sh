@@ -0,0 +1 @@
+terraform import matrix_room_guest_access.lobby "!room:example.com"
@@ -0,0 +1,8 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+resource "matrix_room_guest_access" "lobby" {
+  room_id      = matrix_room.lobby.room_id
+  guest_access = "forbidden"
+}
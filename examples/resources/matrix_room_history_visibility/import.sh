@@ -0,0 +1 @@
+terraform import matrix_room_history_visibility.lobby "!room:example.com"
@@ -0,0 +1,9 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+# Members only see messages sent after they were invited
+resource "matrix_room_history_visibility" "lobby" {
+  room_id            = matrix_room.lobby.room_id
+  history_visibility = "invited"
+}
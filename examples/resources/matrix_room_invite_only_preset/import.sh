@@ -0,0 +1 @@
+terraform import matrix_room_invite_only_preset.platform_team "!platform:example.com"
@@ -0,0 +1,14 @@
+resource "matrix_room_invite_only_preset" "platform_team" {
+  name  = "Platform Team"
+  topic = "Private room of the platform team"
+
+  admins = [
+    "@alice:example.com",
+  ]
+
+  initial_members = [
+    "@alice:example.com",
+    "@bob:example.com",
+    "@carol:example.com",
+  ]
+}
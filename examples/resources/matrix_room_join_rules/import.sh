@@ -0,0 +1 @@
+terraform import matrix_room_join_rules.lobby "!room:example.com"
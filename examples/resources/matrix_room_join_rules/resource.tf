@@ -0,0 +1,14 @@
+resource "matrix_space" "community" {
+  name = "Community"
+}
+
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+# Members of the space can join the room without an invite
+resource "matrix_room_join_rules" "lobby" {
+  room_id   = matrix_room.lobby.room_id
+  join_rule = "restricted"
+  allow     = [matrix_space.community.room_id]
+}
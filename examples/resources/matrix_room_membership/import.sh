@@ -0,0 +1 @@
+terraform import matrix_room_membership.alice "!room:example.com/@alice:example.com"
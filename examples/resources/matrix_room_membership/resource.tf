@@ -0,0 +1,16 @@
+resource "matrix_room" "internal" {
+  name = "Internal"
+}
+
+resource "matrix_room_membership" "alice" {
+  room_id    = matrix_room.internal.room_id
+  user_id    = "@alice:example.com"
+  membership = "invite"
+}
+
+resource "matrix_room_membership" "mallory" {
+  room_id    = matrix_room.internal.room_id
+  user_id    = "@mallory:example.com"
+  membership = "ban"
+  reason     = "Spam"
+}
@@ -0,0 +1 @@
+terraform import matrix_room_message.oncall '!abcdefghijklmnop:example.com/$abcdefghijklmnopqrstuvwxyz'
@@ -0,0 +1,8 @@
+# Keep the on-call rota pinned in the ops room
+resource "matrix_room_message" "oncall" {
+  room_id        = "!abcdefghijklmnop:example.com"
+  msgtype        = "m.notice"
+  body           = "On call this week: Alice, backup: Bob"
+  formatted_body = "On call this week: <b>Alice</b>, backup: Bob"
+  pinned         = true
+}
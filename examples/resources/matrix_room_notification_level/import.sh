@@ -0,0 +1 @@
+terraform import matrix_room_notification_level.announcements "!room:example.com"
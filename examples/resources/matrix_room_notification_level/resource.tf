@@ -0,0 +1,5 @@
+# Never notify the bot about messages in a busy room
+resource "matrix_room_notification_level" "announcements" {
+  room_id = "!room:example.com"
+  actions = ["dont_notify"]
+}
@@ -0,0 +1 @@
+terraform import matrix_room_pinned_events.lobby "!room:example.com"
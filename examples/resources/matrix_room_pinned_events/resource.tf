@@ -0,0 +1,17 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+resource "matrix_room_event" "rules" {
+  room_id    = matrix_room.lobby.room_id
+  event_type = "m.room.message"
+  content = jsonencode({
+    msgtype = "m.text"
+    body    = "Please be nice to each other."
+  })
+}
+
+resource "matrix_room_pinned_events" "lobby" {
+  room_id   = matrix_room.lobby.room_id
+  event_ids = [matrix_room_event.rules.event_id]
+}
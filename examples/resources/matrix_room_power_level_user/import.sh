@@ -0,0 +1 @@
+terraform import matrix_room_power_level_user.alice "!room:example.com/@alice:example.com"
@@ -0,0 +1,10 @@
+resource "matrix_room" "ops" {
+  name = "Operations"
+}
+
+# Bots and other admins may still change the levels of everyone else
+resource "matrix_room_power_level_user" "alice" {
+  room_id = matrix_room.ops.room_id
+  user_id = "@alice:example.com"
+  level   = 50
+}
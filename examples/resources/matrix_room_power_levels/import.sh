@@ -0,0 +1 @@
+terraform import matrix_room_power_levels.ops "!room:example.com"
@@ -0,0 +1,23 @@
+resource "matrix_room" "ops" {
+  name = "Operations"
+}
+
+resource "matrix_room_power_levels" "ops" {
+  room_id = matrix_room.ops.room_id
+
+  # The provider user has to stay able to change the power levels
+  users = {
+    "@terraform:example.com" = 100
+    "@alice:example.com"     = 50
+  }
+
+  events = {
+    "m.room.name"         = 50
+    "m.room.power_levels" = 100
+    "m.room.tombstone"    = 100
+  }
+
+  kick   = 50
+  ban    = 50
+  invite = 50
+}
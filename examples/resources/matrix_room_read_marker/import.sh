@@ -0,0 +1 @@
+terraform import matrix_room_read_marker.bot "!room:example.com"
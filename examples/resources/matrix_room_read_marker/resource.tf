@@ -0,0 +1,6 @@
+# Reset the position of a bot before a test run
+resource "matrix_room_read_marker" "bot" {
+  room_id    = "!room:example.com"
+  fully_read = "$event"
+  read       = "$event"
+}
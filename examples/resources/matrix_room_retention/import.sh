@@ -0,0 +1 @@
+terraform import matrix_room_retention.lobby "!room:example.com"
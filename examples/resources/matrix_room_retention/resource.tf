@@ -0,0 +1,13 @@
+resource "matrix_room" "community" {
+  for_each = toset(["lobby", "off-topic", "support"])
+
+  name = each.key
+}
+
+# Keep messages in every community room for 90 days
+resource "matrix_room_retention" "community" {
+  for_each = matrix_room.community
+
+  room_id      = each.value.room_id
+  max_lifetime = 90 * 24 * 60 * 60 * 1000
+}
@@ -0,0 +1 @@
+terraform import matrix_room_server_acl.lobby "!room:example.com"
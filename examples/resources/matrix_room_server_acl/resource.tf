@@ -0,0 +1,13 @@
+locals {
+  federation_blocklist = ["*.evil.example", "spam.example"]
+}
+
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+resource "matrix_room_server_acl" "lobby" {
+  room_id           = matrix_room.lobby.room_id
+  deny              = local.federation_blocklist
+  allow_ip_literals = false
+}
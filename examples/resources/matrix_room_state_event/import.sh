@@ -0,0 +1,4 @@
+terraform import matrix_room_state_event.widget "!room:example.com/im.vector.modular.widgets/grafana"
+
+# State events with an empty state key end with a slash
+terraform import matrix_room_state_event.settings "!room:example.com/org.example.settings/"
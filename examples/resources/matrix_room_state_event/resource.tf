@@ -0,0 +1,15 @@
+resource "matrix_room" "lobby" {
+  name = "Lobby"
+}
+
+# A state event of an MSC without a dedicated resource
+resource "matrix_room_state_event" "widget" {
+  room_id   = matrix_room.lobby.room_id
+  type      = "im.vector.modular.widgets"
+  state_key = "grafana"
+  content = jsonencode({
+    type = "m.custom"
+    url  = "https://grafana.example.com"
+    name = "Grafana"
+  })
+}
@@ -0,0 +1 @@
+terraform import matrix_room_tag.alerts "@bot:example.com/!room:example.com/m.favourite"
@@ -0,0 +1,19 @@
+resource "matrix_room" "alerts" {
+  name = "Alerts"
+}
+
+# Keep the alerts room at the top of the bot's room list
+resource "matrix_room_tag" "alerts" {
+  room_id = matrix_room.alerts.room_id
+  tag     = "m.favourite"
+  order   = 0.1
+}
+
+resource "matrix_room" "archive" {
+  name = "Archive"
+}
+
+resource "matrix_room_tag" "archive" {
+  room_id = matrix_room.archive.room_id
+  tag     = "m.lowpriority"
+}
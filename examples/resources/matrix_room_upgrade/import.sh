@@ -0,0 +1 @@
+terraform import matrix_room_upgrade.lobby "!lobby:example.com"
@@ -0,0 +1,14 @@
+data "matrix_server_capabilities" "server" {}
+
+resource "matrix_room_upgrade" "lobby" {
+  room_id     = "!lobby:example.com"
+  new_version = data.matrix_server_capabilities.server.default_room_version
+
+  # Point these to the replacement room as well
+  aliases   = ["#lobby:example.org"]
+  space_ids = ["!community:example.com"]
+}
+
+output "new_lobby_room_id" {
+  value = matrix_room_upgrade.lobby.replacement_room_id
+}
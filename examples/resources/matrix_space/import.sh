@@ -0,0 +1 @@
+terraform import matrix_space.engineering "!space:example.com"
@@ -0,0 +1,5 @@
+resource "matrix_space" "engineering" {
+  name       = "Engineering"
+  topic      = "All rooms of the engineering teams"
+  avatar_url = "mxc://example.com/SEsfnsuifSDFSSEF"
+}
@@ -0,0 +1 @@
+terraform import matrix_space_child.backend "!space:example.com/!room:example.com"
@@ -0,0 +1,17 @@
+resource "matrix_space" "engineering" {
+  name = "Engineering"
+}
+
+resource "matrix_room" "backend" {
+  name = "Backend"
+}
+
+resource "matrix_space_child" "backend" {
+  space_id  = matrix_space.engineering.room_id
+  room_id   = matrix_room.backend.room_id
+  via       = ["example.com"]
+  order     = "10"
+  suggested = true
+  parent    = true
+  canonical = true
+}
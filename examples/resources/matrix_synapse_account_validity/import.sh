@@ -0,0 +1 @@
+terraform import matrix_synapse_account_validity.contractor "@contractor:example.com"
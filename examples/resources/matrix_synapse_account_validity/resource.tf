@@ -0,0 +1,7 @@
+# The contractor account expires at the end of the contract.
+resource "matrix_synapse_account_validity" "contractor" {
+  user_id = "@contractor:example.com"
+
+  # 2027-12-31T23:59:59Z
+  expiration_ts = 1830297599000
+}
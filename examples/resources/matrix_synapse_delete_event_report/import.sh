@@ -0,0 +1 @@
+terraform import matrix_synapse_delete_event_report.example 42
@@ -0,0 +1,19 @@
+data "matrix_synapse_room_report" "all" {
+  limit = 100
+}
+
+locals {
+  # Reports older than 30 days that were not rated as very offensive.
+  stale_reports = [
+    for report in data.matrix_synapse_room_report.all.event_reports : report.id
+    if report.received_ts < (time_static.now.unix - 30 * 24 * 60 * 60) * 1000 && coalesce(report.score, 0) > -50
+  ]
+}
+
+resource "time_static" "now" {}
+
+resource "matrix_synapse_delete_event_report" "stale" {
+  for_each = toset([for id in local.stale_reports : tostring(id)])
+
+  report_id = each.value
+}
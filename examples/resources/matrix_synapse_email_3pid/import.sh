@@ -0,0 +1 @@
+terraform import matrix_synapse_email_3pid.alice "@alice:example.com/alice@example.com"
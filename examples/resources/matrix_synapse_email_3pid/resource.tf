@@ -0,0 +1,10 @@
+resource "matrix_synapse_email_3pid" "alice" {
+  user_id = "@alice:example.com"
+  address = "alice@example.com"
+}
+
+resource "matrix_synapse_email_3pid" "alice_phone" {
+  user_id = "@alice:example.com"
+  medium  = "msisdn"
+  address = "441234567890"
+}
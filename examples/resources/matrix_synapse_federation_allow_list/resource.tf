@@ -0,0 +1,13 @@
+resource "matrix_synapse_federation_allow_list" "partners" {
+  servers = [
+    "example.com",
+    "partner.example.org",
+  ]
+}
+
+# Synapse reads every file in its configuration directory, restart it to
+# apply changes.
+resource "local_file" "federation_allow_list" {
+  filename = "/etc/matrix-synapse/conf.d/federation_allow_list.yaml"
+  content  = matrix_synapse_federation_allow_list.partners.config_fragment
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_forward_extremities_cleanup.lobby "!room:example.com"
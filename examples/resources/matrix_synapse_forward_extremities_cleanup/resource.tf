@@ -0,0 +1,9 @@
+data "matrix_synapse_forward_extremities" "lobby" {
+  room_id = "!someroom:example.com"
+}
+
+# Only clean up once the room has become degraded
+resource "matrix_synapse_forward_extremities_cleanup" "lobby" {
+  count   = data.matrix_synapse_forward_extremities.lobby.extremity_count > 10 ? 1 : 0
+  room_id = data.matrix_synapse_forward_extremities.lobby.room_id
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_media_quarantine.spam "example.com/abcdefghijklmnopqrstuvwx"
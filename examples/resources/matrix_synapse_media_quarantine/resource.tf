@@ -0,0 +1,5 @@
+# Quarantine mxc://example.com/SomeGeneratedId
+resource "matrix_synapse_media_quarantine" "spam" {
+  server_name = "example.com"
+  media_id    = "SomeGeneratedId"
+}
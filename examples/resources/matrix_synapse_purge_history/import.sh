@@ -0,0 +1,2 @@
+# The purge options are not reported by Synapse, so they are not imported.
+terraform import matrix_synapse_purge_history.before_2024 "!room:example.com/purge_id"
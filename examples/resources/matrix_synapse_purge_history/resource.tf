@@ -0,0 +1,13 @@
+# Purge everything before 2024, including messages of local users
+resource "matrix_synapse_purge_history" "before_2024" {
+  room_id             = "!room:example.com"
+  purge_up_to_ts      = 1704067200000
+  delete_local_events = true
+  timeout_minutes     = 30
+}
+
+# Purge everything before an event, keeping messages of local users
+resource "matrix_synapse_purge_history" "before_event" {
+  room_id              = "!room:example.com"
+  purge_up_to_event_id = "$event"
+}
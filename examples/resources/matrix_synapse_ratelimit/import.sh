@@ -0,0 +1 @@
+terraform import matrix_synapse_ratelimit.bridge "@bot:example.com"
@@ -0,0 +1,6 @@
+# Allow a busy bridge bot to send more messages than regular users
+resource "matrix_synapse_ratelimit" "bridge" {
+  user_id             = "@bridge:example.com"
+  messages_per_second = 50
+  burst_count         = 200
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_registration_token.onboarding "abcd1234"
@@ -0,0 +1,10 @@
+# A token for the next onboarding batch, valid for ten sign-ups until the
+# end of the year.
+resource "matrix_synapse_registration_token" "onboarding" {
+  uses_allowed = 10
+  expiry_time  = 1798761600000 # 2027-01-01T00:00:00Z
+}
+
+output "onboarding_token" {
+  value = matrix_synapse_registration_token.onboarding.token
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_room_block.abuse "!room:example.com"
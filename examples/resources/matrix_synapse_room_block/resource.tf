@@ -0,0 +1,3 @@
+resource "matrix_synapse_room_block" "abuse" {
+  room_id = "!abusiveRoom:example.org"
+}
@@ -0,0 +1,2 @@
+# The deletion options are not reported by Synapse, so they are not imported.
+terraform import matrix_synapse_room_delete.abuse "!abuse:example.com/delete_id"
@@ -0,0 +1,9 @@
+# Delete an abusive room, keep it blocked and move the local members to a
+# new room explaining what happened.
+resource "matrix_synapse_room_delete" "abuse" {
+  room_id          = "!abuse:example.com"
+  block            = true
+  new_room_user_id = "@moderation:example.com"
+  room_name        = "Content violation notification"
+  message          = "The room you were in has been removed for violating the terms of service."
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_room_make_admin.recovery "!abandoned:example.com/@moderator:example.com"
@@ -0,0 +1,5 @@
+# Recover a room whose admins have all left
+resource "matrix_synapse_room_make_admin" "recovery" {
+  room_id = "!abandoned:example.com"
+  user_id = "@moderator:example.com"
+}
@@ -0,0 +1,2 @@
+# Only media of the room that is currently quarantined is imported.
+terraform import matrix_synapse_room_media_quarantine.abuse "!abuse:example.com"
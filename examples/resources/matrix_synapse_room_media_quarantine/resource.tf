@@ -0,0 +1,3 @@
+resource "matrix_synapse_room_media_quarantine" "abuse" {
+  room_id = "!abuse:example.com"
+}
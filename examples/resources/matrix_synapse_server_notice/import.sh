@@ -0,0 +1,2 @@
+# The identifier is user_id/room_id/event_id of the notice in the server notices room
+terraform import matrix_synapse_server_notice.maintenance '@alice:example.com/!notices:example.com/$event'
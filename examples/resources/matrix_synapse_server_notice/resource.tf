@@ -0,0 +1,4 @@
+resource "matrix_synapse_server_notice" "maintenance" {
+  user_id      = "@alice:example.com"
+  content_body = "The homeserver will be down for maintenance on Saturday from 10:00 to 12:00 UTC."
+}
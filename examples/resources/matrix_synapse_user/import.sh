@@ -0,0 +1 @@
+terraform import matrix_synapse_user.alice "@alice:example.com"
@@ -0,0 +1,21 @@
+variable "deploy_bot_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "matrix_synapse_user" "deploy_bot" {
+  user_id     = "@deploy:example.com"
+  password    = var.deploy_bot_password
+  displayname = "Deploy Bot"
+  user_type   = "bot"
+}
+
+# Employees log in with SSO, so they have no password
+resource "matrix_synapse_user" "alice" {
+  user_id     = "@alice:example.com"
+  displayname = "Alice"
+  admin       = true
+
+  # Honour GDPR erasure when the employee leaves
+  on_destroy = "erase"
+}
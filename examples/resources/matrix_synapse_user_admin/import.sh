@@ -0,0 +1 @@
+terraform import matrix_synapse_user_admin.alice "@alice:example.com"
@@ -0,0 +1,3 @@
+resource "matrix_synapse_user_admin" "alice" {
+  user_id = "@alice:example.com"
+}
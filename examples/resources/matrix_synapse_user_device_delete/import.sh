@@ -0,0 +1 @@
+terraform import matrix_synapse_user_device_delete.stolen_laptop "@alice:example.com/QBUAZIFURK"
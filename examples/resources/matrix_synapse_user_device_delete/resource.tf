@@ -0,0 +1,5 @@
+# Revoke a compromised device
+resource "matrix_synapse_user_device_delete" "stolen_laptop" {
+  user_id   = "@alice:example.com"
+  device_id = "QBUAZIFURK"
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_user_external_id.alice "@alice:example.com/oidc-keycloak/f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
@@ -0,0 +1,6 @@
+# Bind the account to its Keycloak subject before the first SSO login.
+resource "matrix_synapse_user_external_id" "alice" {
+  user_id       = "@alice:example.com"
+  auth_provider = "oidc-keycloak"
+  external_id   = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+}
@@ -0,0 +1,13 @@
+resource "matrix_synapse_user_login" "bot" {
+  user_id        = "@bot:example.com"
+  valid_until_ms = 1830297599000
+}
+
+# Act as the bot user
+provider "matrix" {
+  alias = "bot"
+
+  client_server_url    = "https://matrix.example.com"
+  default_user_id      = matrix_synapse_user_login.bot.user_id
+  default_access_token = matrix_synapse_user_login.bot.access_token
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_user_password.alice "@alice:example.com"
@@ -0,0 +1,13 @@
+variable "alice_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "matrix_synapse_user_password" "alice" {
+  user_id  = "@alice:example.com"
+  password = var.alice_password
+  # Bump to reset the password to the current value of var.alice_password.
+  password_wo_version = 1
+  logout_devices      = true
+}
@@ -0,0 +1 @@
+terraform import matrix_synapse_user_shadow_ban.spammer "@spammer:example.com"
@@ -0,0 +1,3 @@
+resource "matrix_synapse_user_shadow_ban" "spammer" {
+  user_id = "@spammer:example.com"
+}
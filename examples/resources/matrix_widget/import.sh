@@ -0,0 +1 @@
+terraform import matrix_widget.grafana "!abcdefghijklmnop:example.com/im.vector.modular.widgets/grafana"
@@ -0,0 +1,10 @@
+# Show the Grafana overview above the timeline of the ops room
+resource "matrix_widget" "grafana" {
+  room_id   = "!abcdefghijklmnop:example.com"
+  widget_id = "grafana"
+  type      = "m.grafana"
+  url       = "https://grafana.example.com/d/overview?kiosk&theme=$theme"
+  name      = "Overview"
+  data_json = jsonencode({ theme = "dark" })
+  container = "top"
+}
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccountDataResource{}
+var _ resource.ResourceWithImportState = &AccountDataResource{}
+
+func NewAccountDataResource() resource.Resource {
+	return &AccountDataResource{}
+}
+
+// AccountDataResource defines the resource implementation.
+type AccountDataResource struct {
+	client *gomatrix.Client
+}
+
+// AccountDataResourceModel describes the resource data model.
+type AccountDataResourceModel struct {
+	UserID types.String         `tfsdk:"user_id"`
+	Type   types.String         `tfsdk:"type"`
+	Data   jsontypes.Normalized `tfsdk:"data"`
+	Id     types.String         `tfsdk:"id"`
+}
+
+// accountDataURL builds the URL of a global account data entry.
+func (r *AccountDataResource) accountDataURL(data AccountDataResourceModel) string {
+	return r.client.BuildURL("user", data.UserID.ValueString(), "account_data", data.Type.ValueString())
+}
+
+func (r *AccountDataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_data"
+}
+
+func (r *AccountDataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Stores global account data of the provider user, e.g. the configuration of a bot. " +
+			"Use `matrix_room_account_data` for account data scoped to a room. " +
+			"Account data cannot be deleted, so destroying the resource replaces it with an empty object.\n\n" +
+			"Some types defined by the specification are managed by the homeserver, e.g. `m.push_rules`, and cannot be set here.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user owning the account data. Users can only access their own account data, " +
+					"so this defaults to and must be the provider user.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the account data, e.g. `org.example.bot.config`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"data": schema.StringAttribute{
+				MarkdownDescription: "The account data as JSON object, e.g. from `jsonencode`. " +
+					"Differences in key order or whitespace are ignored.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/type`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AccountDataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *AccountDataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccountDataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsUnknown() {
+		data.UserID = types.StringValue(r.client.UserID)
+	}
+
+	err := r.client.MakeRequest("PUT", r.accountDataURL(data), json.RawMessage(data.Data.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set account data, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + data.Type.ValueString())
+
+	tflog.Trace(ctx, "set account data", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountDataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccountDataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content json.RawMessage
+	err := r.client.MakeRequest("GET", r.accountDataURL(data), nil, &content)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "account data no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read account data, got error: %s", err))
+		return
+	}
+
+	// Destroying the resource leaves an empty object behind.
+	if jsonEqual(content, []byte("{}")) && !jsonEqual([]byte(data.Data.ValueString()), []byte("{}")) {
+		tflog.Warn(ctx, "account data was cleared, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Semantic equality keeps the configured formatting unless the data
+	// really changed.
+	data.Data = jsontypes.NewNormalizedValue(string(content))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountDataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AccountDataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("PUT", r.accountDataURL(data), json.RawMessage(data.Data.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set account data, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccountDataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccountDataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("PUT", r.accountDataURL(data), map[string]any{}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear account data, got error: %s", err))
+		return
+	}
+}
+
+func (r *AccountDataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "user_id", "type") == nil {
+		return
+	}
+
+	// Read replaces the placeholder with the current data.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), "{}")...)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccountDataResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccAccountDataResourceConfig(`"[]"`),
+				ExpectError: regexp.MustCompile(`JSON object`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccAccountDataResourceConfig(`jsonencode({ admin = "@alice:example.com" })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_account_data.test", "type", "org.example.bot.config"),
+					resource.TestCheckResourceAttrSet("matrix_account_data.test", "user_id"),
+					resource.TestCheckResourceAttr("matrix_account_data.test", "data", `{"admin":"@alice:example.com"}`),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_account_data.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAccountDataResourceConfig(`jsonencode({ admin = "@bob:example.com" })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_account_data.test", "data", `{"admin":"@bob:example.com"}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAccountDataResourceConfig(data string) string {
+	return `
+resource "matrix_account_data" "test" {
+  type = "org.example.bot.config"
+  data = ` + data + `
+}
+`
+}
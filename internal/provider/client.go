@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/matrix-org/gomatrix"
+)
+
+// synapseAdminURL builds a URL for the Synapse admin API of the configured
+// homeserver, e.g. synapseAdminURL(client, "v1", "media", "quarantine").
+func synapseAdminURL(client *gomatrix.Client, urlPath ...string) string {
+	return client.BuildBaseURL(append([]string{"_synapse", "admin"}, urlPath...)...)
+}
+
+// contextTransport attaches a context to every request it sends, so
+// requests are aborted once the context is done.
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// contextAwareClient returns a copy of the client whose requests are
+// cancelled together with ctx, as gomatrix itself does not take contexts.
+//
+// The framework creates a new resource or data source for every RPC and
+// configures it with the context of that RPC, which Terraform cancels on
+// interrupt. Configure methods therefore wrap the provider client with their
+// context, so every request of the following operation can be cancelled.
+func contextAwareClient(ctx context.Context, client *gomatrix.Client) *gomatrix.Client {
+	httpClient := http.Client{}
+	if client.Client != nil {
+		httpClient = *client.Client
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.Transport = contextTransport{ctx: ctx, base: base}
+
+	return &gomatrix.Client{
+		HomeserverURL:    client.HomeserverURL,
+		Prefix:           client.Prefix,
+		UserID:           client.UserID,
+		AccessToken:      client.AccessToken,
+		Client:           &httpClient,
+		Syncer:           client.Syncer,
+		Store:            client.Store,
+		AppServiceUserID: client.AppServiceUserID,
+	}
+}
+
+// clientAsUser returns a copy of the client which authenticates as another
+// user with the given access token. It shares the HTTP client, so requests
+// are still cancelled together with the operation.
+func clientAsUser(client *gomatrix.Client, userID string, accessToken string) *gomatrix.Client {
+	return &gomatrix.Client{
+		HomeserverURL: client.HomeserverURL,
+		Prefix:        client.Prefix,
+		UserID:        userID,
+		AccessToken:   accessToken,
+		Client:        client.Client,
+	}
+}
+
+// clientV1URL builds a URL for the v1 endpoints of the client-server API,
+// which gomatrix cannot build as it always uses the v3 prefix.
+func clientV1URL(client *gomatrix.Client, urlPath ...string) string {
+	return client.BuildBaseURL(append([]string{"_matrix", "client", "v1"}, urlPath...)...)
+}
+
+// uploadContent uploads media to the content repository and returns its
+// mxc:// URI. gomatrix only knows the removed r0 endpoint, so this uses v3.
+// The body is streamed, so size must be known up front.
+func uploadContent(client *gomatrix.Client, body io.Reader, size int64, contentType string, filename string) (string, error) {
+	var upload gomatrix.RespMediaUpload
+	err := sendContent(client, "POST", client.BuildBaseURL("_matrix", "media", "v3", "upload"), body, size, contentType, filename, &upload)
+	if err != nil {
+		return "", err
+	}
+
+	return upload.ContentURI, nil
+}
+
+// createContentURI reserves an mxc:// URI whose content is uploaded later
+// with uploadContentTo. The homeserver forgets the URI if nothing is
+// uploaded before it expires.
+func createContentURI(client *gomatrix.Client) (string, error) {
+	var created struct {
+		ContentURI string `json:"content_uri"`
+	}
+	err := client.MakeRequest("POST", client.BuildBaseURL("_matrix", "media", "v1", "create"), nil, &created)
+	if err != nil {
+		return "", err
+	}
+
+	return created.ContentURI, nil
+}
+
+// uploadContentTo uploads the content of an mxc:// URI reserved with
+// createContentURI.
+func uploadContentTo(client *gomatrix.Client, contentURI string, body io.Reader, size int64, contentType string, filename string) error {
+	mxc, err := url.Parse(contentURI)
+	if err != nil {
+		return err
+	}
+
+	uploadURL := client.BuildBaseURL("_matrix", "media", "v3", "upload", mxc.Host, strings.TrimPrefix(mxc.Path, "/"))
+	return sendContent(client, "PUT", uploadURL, body, size, contentType, filename, nil)
+}
+
+// sendContent sends a raw media upload request, which gomatrix cannot do as
+// it always encodes the request body as JSON.
+func sendContent(client *gomatrix.Client, method string, uploadURL string, body io.Reader, size int64, contentType string, filename string, resBody any) error {
+	if filename != "" {
+		uploadURL += "?filename=" + url.QueryEscape(filename)
+	}
+
+	req, err := http.NewRequest(method, uploadURL, body)
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+client.AccessToken)
+
+	res, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	contents, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	// Mirror the errors of gomatrix, so matrixErrCode works on them.
+	if res.StatusCode/100 != 2 {
+		httpErr := gomatrix.HTTPError{
+			Contents: contents,
+			Code:     res.StatusCode,
+			Message:  "Failed to upload content: " + string(contents),
+		}
+
+		var respErr gomatrix.RespError
+		if json.Unmarshal(contents, &respErr) == nil && respErr.ErrCode != "" {
+			httpErr.WrappedError = respErr
+		}
+
+		return httpErr
+	}
+
+	if resBody == nil {
+		return nil
+	}
+
+	return json.Unmarshal(contents, resBody)
+}
+
+// matrixErrCode returns the Matrix errcode (e.g. M_NOT_FOUND) of an error
+// returned by the homeserver, or an empty string if there is none.
+func matrixErrCode(err error) string {
+	var httpErr gomatrix.HTTPError
+	if !errors.As(err, &httpErr) {
+		return ""
+	}
+
+	var respErr gomatrix.RespError
+	if !errors.As(httpErr.WrappedError, &respErr) {
+		return ""
+	}
+
+	return respErr.ErrCode
+}
+
+// isNotFound reports whether the homeserver answered with a 404.
+func isNotFound(err error) bool {
+	var httpErr gomatrix.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusNotFound
+}
+
+// isConflict reports whether the homeserver answered with a 409, e.g. for a
+// room alias that is already taken.
+func isConflict(err error) bool {
+	var httpErr gomatrix.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusConflict
+}
+
+// isUnrecognized reports whether the homeserver does not know the endpoint
+// at all, which usually means the feature is missing in this version.
+func isUnrecognized(err error) bool {
+	return matrixErrCode(err) == "M_UNRECOGNIZED"
+}
+
+// synapseUser is the subset of the Synapse admin API user object that the
+// provider works with.
+// See https://element-hq.github.io/synapse/latest/admin_api/user_admin_api.html#query-user-account
+type synapseUser struct {
+	Name         string  `json:"name"`
+	Displayname  string  `json:"displayname"`
+	Admin        bool    `json:"admin"`
+	Deactivated  bool    `json:"deactivated"`
+	ShadowBanned bool    `json:"shadow_banned"`
+	UserType     *string `json:"user_type"`
+
+	Threepids   []synapseThreepid   `json:"threepids"`
+	ExternalIDs []synapseExternalID `json:"external_ids"`
+}
+
+// synapseThreepid is a third-party identifier (e.g. an email address) bound
+// to a user account.
+type synapseThreepid struct {
+	Medium  string `json:"medium"`
+	Address string `json:"address"`
+}
+
+// synapseExternalID binds a user account to the subject of a single sign-on
+// provider.
+type synapseExternalID struct {
+	AuthProvider string `json:"auth_provider"`
+	ExternalID   string `json:"external_id"`
+}
+
+// getSynapseUser queries a user account through the Synapse admin API.
+func getSynapseUser(client *gomatrix.Client, userID string) (*synapseUser, error) {
+	var user synapseUser
+	err := client.MakeRequest("GET", synapseAdminURL(client, "v2", "users", userID), nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// synapseJoinedRooms is the response of the Synapse admin API listing the
+// rooms a user is joined to.
+type synapseJoinedRooms struct {
+	JoinedRooms []string `json:"joined_rooms"`
+}
+
+// synapseRoomState is the response of the Synapse admin API listing the
+// current state of a room. It works even if the provider user is not joined.
+type synapseRoomState struct {
+	State []gomatrix.Event `json:"state"`
+}
+
+// getSynapseRoomStateEvent returns the current state event of the given type
+// and state key using the Synapse admin API, or nil if there is none.
+func getSynapseRoomStateEvent(client *gomatrix.Client, roomID string, eventType string, stateKey string) (*gomatrix.Event, error) {
+	var state synapseRoomState
+	err := client.MakeRequest("GET", synapseAdminURL(client, "v1", "rooms", roomID, "state"), nil, &state)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, event := range state.State {
+		if event.Type == eventType && event.StateKey != nil && *event.StateKey == stateKey {
+			return &state.State[i], nil
+		}
+	}
+
+	return nil, nil
+}
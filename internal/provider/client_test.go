@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestContextAwareClient(t *testing.T) {
+	t.Parallel()
+
+	// The server only answers once the request was aborted.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := gomatrix.NewClient(server.URL, "@admin:example.com", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	wrapped := contextAwareClient(ctx, client)
+	if wrapped.UserID != client.UserID || wrapped.AccessToken != client.AccessToken {
+		t.Errorf("expected the credentials of the client to be kept")
+	}
+
+	err = wrapped.MakeRequest("GET", wrapped.BuildURL("account", "whoami"), nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be aborted with the context, got: %v", err)
+	}
+}
+
+func TestWaitForPurgeCancelled(t *testing.T) {
+	t.Parallel()
+
+	polls := make(chan struct{}, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls <- struct{}{}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "active"}`))
+	}))
+	defer server.Close()
+
+	client, err := gomatrix.NewClient(server.URL, "@admin:example.com", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &SynapsePurgeHistoryResource{client: contextAwareClient(ctx, client)}
+
+	go func() {
+		<-polls
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err = r.waitForPurge(ctx, "purge", time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected polling to stop with the context, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > purgeHistoryPollInterval+time.Second {
+		t.Errorf("expected polling to stop right away, took %s", elapsed)
+	}
+}
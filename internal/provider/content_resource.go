@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ContentResource{}
+var _ resource.ResourceWithModifyPlan = &ContentResource{}
+
+func NewContentResource() resource.Resource {
+	return &ContentResource{}
+}
+
+// ContentResource defines the resource implementation.
+type ContentResource struct {
+	client *gomatrix.Client
+}
+
+// ContentResourceModel describes the resource data model.
+type ContentResourceModel struct {
+	Source       types.String `tfsdk:"source"`
+	ContentType  types.String `tfsdk:"content_type"`
+	Filename     types.String `tfsdk:"filename"`
+	AsyncUpload  types.Bool   `tfsdk:"async_upload"`
+	SourceSHA256 types.String `tfsdk:"source_sha256"`
+	ContentURI   types.String `tfsdk:"content_uri"`
+	Uploaded     types.Bool   `tfsdk:"uploaded"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// contentSniffLen is the number of bytes http.DetectContentType looks at.
+const contentSniffLen = 512
+
+// contentFile is a local file to upload. Only its metadata is kept in
+// memory, the content is streamed from disk on upload.
+type contentFile struct {
+	path        string
+	size        int64
+	contentType string
+	sha256      string
+}
+
+// readContentFile hashes a local file and detects its content type, from the
+// file extension if possible and from the content otherwise.
+func readContentFile(source string) (*contentFile, error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, contentSniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	hash := sha256.New()
+	hash.Write(head)
+	rest, err := io.Copy(hash, f)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(source))
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+
+	return &contentFile{
+		path:        source,
+		size:        int64(n) + rest,
+		contentType: contentType,
+		sha256:      hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// upload streams the file to the content repository and returns its mxc://
+// URI.
+func (f *contentFile) upload(client *gomatrix.Client, contentType string, filename string) (string, error) {
+	body, err := os.Open(f.path)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	return uploadContent(client, body, f.size, contentType, filename)
+}
+
+// uploadTo streams the file to an mxc:// URI reserved with
+// createContentURI.
+func (f *contentFile) uploadTo(client *gomatrix.Client, contentURI string, contentType string, filename string) error {
+	body, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return uploadContentTo(client, contentURI, body, f.size, contentType, filename)
+}
+
+func (r *ContentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_content"
+}
+
+func (r *ContentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a local file to the content repository of the homeserver, e.g. an image for an avatar " +
+			"or a widget. Media is immutable, so a changed file is uploaded again and gets a new `content_uri`.\n\n" +
+			"Files are streamed from disk, so large files do not need to fit in memory. With `async_upload`, the `mxc://` URI is " +
+			"reserved before the upload starts, and a failed upload is retried to the same URI by the next apply.\n\n" +
+			"The client-server API cannot delete media, destroying the resource only removes it from the Terraform state.",
+
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The path of the local file to upload, e.g. `\"${path.module}/logo.png\"`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "The MIME type of the file. Detected from the file extension or content if unset.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "The file name clients offer when downloading the media. Defaults to the base name of `source`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"async_upload": schema.BoolAttribute{
+				MarkdownDescription: "Reserve the `mxc://` URI first and upload the file to it afterwards, as defined by " +
+					"[MSC2246](https://github.com/matrix-org/matrix-spec-proposals/pull/2246). If the upload fails, e.g. because " +
+					"a large file hits a timeout, the resource is still created with `uploaded` set to `false`, and the next apply " +
+					"retries the upload without changing `content_uri`. Needs Matrix 1.7 or newer. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"source_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the uploaded file.",
+				Computed:            true,
+			},
+			"content_uri": schema.StringAttribute{
+				MarkdownDescription: "The `mxc://` URI of the uploaded media.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"uploaded": schema.BoolAttribute{
+				MarkdownDescription: "Whether the file has been uploaded. Only `false` after a failed `async_upload`.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The `mxc://` URI of the uploaded media",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ContentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *ContentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to upload on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ContentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.Source.IsUnknown() {
+		return
+	}
+
+	// Hash the file at plan time, so a changed file shows up as a diff.
+	file, err := readContentFile(plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Unable to Read Content", fmt.Sprintf("Unable to read %s, got error: %s", plan.Source.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_sha256"), file.sha256)...)
+
+	if plan.ContentType.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_type"), file.contentType)...)
+	}
+	if plan.Filename.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("filename"), filepath.Base(plan.Source.ValueString()))...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state ContentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.SourceSHA256.ValueString() != file.sha256 {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("source_sha256"))
+		return
+	}
+
+	// Retry a failed asynchronous upload to the reserved URI.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("uploaded"), true)...)
+}
+
+func (r *ContentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file, err := readContentFile(data.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Content", fmt.Sprintf("Unable to read %s, got error: %s", data.Source.ValueString(), err))
+		return
+	}
+
+	// Store the hash of what is actually uploaded, in case the file changed
+	// since the plan.
+	data.SourceSHA256 = types.StringValue(file.sha256)
+
+	if !data.AsyncUpload.ValueBool() {
+		contentURI, err := file.upload(r.client, data.ContentType.ValueString(), data.Filename.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload content, got error: %s", err))
+			return
+		}
+
+		data.ContentURI = types.StringValue(contentURI)
+		data.Uploaded = types.BoolValue(true)
+	} else {
+		contentURI, err := createContentURI(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create content URI, got error: %s", err))
+			return
+		}
+
+		data.ContentURI = types.StringValue(contentURI)
+
+		// An error would taint the resource and throw away the reserved URI,
+		// so keep it and let the next apply retry the upload.
+		err = file.uploadTo(r.client, contentURI, data.ContentType.ValueString(), data.Filename.ValueString())
+		data.Uploaded = types.BoolValue(err == nil)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Upload Incomplete",
+				fmt.Sprintf("Reserved %s, but uploading %s failed: %s. The next apply retries the upload.", contentURI, data.Source.ValueString(), err),
+			)
+		}
+	}
+
+	data.Id = data.ContentURI
+
+	tflog.Trace(ctx, "uploaded content", map[string]any{"source": data.Source.ValueString(), "content_uri": data.ContentURI.ValueString(), "uploaded": data.Uploaded.ValueBool()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Uploaded media cannot change, there is nothing to refresh. Changes to
+	// the local file are detected by ModifyPlan.
+}
+
+func (r *ContentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ContentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changes to the file require replacement, only a failed asynchronous
+	// upload is left to do.
+	if !state.Uploaded.ValueBool() {
+		file, err := readContentFile(data.Source.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Content", fmt.Sprintf("Unable to read %s, got error: %s", data.Source.ValueString(), err))
+			return
+		}
+
+		err = file.uploadTo(r.client, data.ContentURI.ValueString(), data.ContentType.ValueString(), data.Filename.ValueString())
+		// A previous attempt may have succeeded after all.
+		if err != nil && matrixErrCode(err) != "M_CANNOT_OVERWRITE_MEDIA" {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload content to %s, got error: %s. "+
+				"If the reserved URI expired, replace the resource with terraform apply -replace.", data.ContentURI.ValueString(), err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Media cannot be deleted through the client-server API, only forget the
+	// resource.
+}
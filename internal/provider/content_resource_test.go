@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccContentResource(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "logo.png")
+	testAccWriteFile(t, source, "first logo")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContentResourceConfig(source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("matrix_content.test", "content_uri", regexp.MustCompile(`^mxc://`)),
+					resource.TestCheckResourceAttrPair("matrix_content.test", "id", "matrix_content.test", "content_uri"),
+					resource.TestCheckResourceAttr("matrix_content.test", "content_type", "image/png"),
+					resource.TestCheckResourceAttr("matrix_content.test", "filename", "logo.png"),
+					resource.TestCheckResourceAttrSet("matrix_content.test", "source_sha256"),
+					resource.TestCheckResourceAttr("matrix_content.test", "uploaded", "true"),
+				),
+			},
+			// An unchanged file is not uploaded again
+			{
+				Config:   testAccContentResourceConfig(source),
+				PlanOnly: true,
+			},
+			// A changed file is uploaded again
+			{
+				PreConfig: func() { testAccWriteFile(t, source, "second logo") },
+				Config:    testAccContentResourceConfig(source),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_content.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccContentResourceConfig(source string) string {
+	return fmt.Sprintf(`
+resource "matrix_content" "test" {
+  source = %q
+}
+`, source)
+}
+
+func TestAccContentResource_asyncUpload(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "installer.bin")
+	testAccWriteFile(t, source, strings.Repeat("large file ", 1024))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContentResourceConfigAsync(source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("matrix_content.test", "content_uri", regexp.MustCompile(`^mxc://`)),
+					resource.TestCheckResourceAttr("matrix_content.test", "uploaded", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccContentResourceConfigAsync(source string) string {
+	return fmt.Sprintf(`
+resource "matrix_content" "test" {
+  source       = %q
+  async_upload = true
+}
+`, source)
+}
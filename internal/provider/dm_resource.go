@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DMResource{}
+var _ resource.ResourceWithImportState = &DMResource{}
+
+func NewDMResource() resource.Resource {
+	return &DMResource{}
+}
+
+// DMResource defines the resource implementation.
+type DMResource struct {
+	client *gomatrix.Client
+}
+
+// DMResourceModel describes the resource data model.
+type DMResourceModel struct {
+	UserID types.String `tfsdk:"user_id"`
+	RoomID types.String `tfsdk:"room_id"`
+	Id     types.String `tfsdk:"id"`
+}
+
+// directRooms is the content of the m.direct account data, the direct
+// message rooms of the provider user by the ID of the other user.
+type directRooms map[string][]string
+
+// directRoomsURL builds the URL of the m.direct account data of the provider
+// user.
+func directRoomsURL(client *gomatrix.Client) string {
+	return client.BuildURL("user", client.UserID, "account_data", "m.direct")
+}
+
+// getDirectRooms reads the m.direct account data, which is empty for users
+// without direct message rooms.
+func getDirectRooms(client *gomatrix.Client) (directRooms, error) {
+	rooms := directRooms{}
+	err := client.MakeRequest("GET", directRoomsURL(client), nil, &rooms)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+// updateDirectRooms adds or removes a room from the direct message rooms
+// with a user, keeping all other entries of m.direct.
+func updateDirectRooms(client *gomatrix.Client, userID string, roomID string, add bool) error {
+	rooms, err := getDirectRooms(client)
+	if err != nil {
+		return fmt.Errorf("unable to read m.direct account data: %w", err)
+	}
+
+	var userRooms []string
+	for _, id := range rooms[userID] {
+		if id != roomID {
+			userRooms = append(userRooms, id)
+		}
+	}
+	if add {
+		userRooms = append(userRooms, roomID)
+	}
+
+	if len(userRooms) > 0 {
+		rooms[userID] = userRooms
+	} else {
+		delete(rooms, userID)
+	}
+
+	err = client.MakeRequest("PUT", directRoomsURL(client), rooms, nil)
+	if err != nil {
+		return fmt.Errorf("unable to set m.direct account data: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DMResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dm"
+}
+
+func (r *DMResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a direct message room between the provider user and another user, e.g. as notification " +
+			"channel from CI, and records it in the `m.direct` account data of the provider user, so clients show it " +
+			"as direct message. The other user is invited and has to join the room. Destroying the resource removes " +
+			"the room from `m.direct` and makes the provider user leave and forget it, the other user stays in it.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user to send direct messages to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the direct message room.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DMResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *DMResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DMResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	room, err := r.client.CreateRoom(&gomatrix.ReqCreateRoom{
+		Preset:   "trusted_private_chat",
+		Invite:   []string{data.UserID.ValueString()},
+		IsDirect: true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create direct message room, got error: %s", err))
+		return
+	}
+
+	data.RoomID = types.StringValue(room.RoomID)
+	data.Id = data.RoomID
+
+	// Save the room before touching m.direct, so it is not lost if that fails.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	err = updateDirectRooms(r.client, data.UserID.ValueString(), room.RoomID, true)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to mark room as direct message, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created direct message room", map[string]any{"room_id": room.RoomID, "user_id": data.UserID.ValueString()})
+}
+
+func (r *DMResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DMResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.create", "", nil)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room, got error: %s", err))
+		return
+	}
+
+	// Imported rooms only know their ID, find the other user in m.direct.
+	if data.UserID.IsNull() {
+		rooms, err := getDirectRooms(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.direct account data, got error: %s", err))
+			return
+		}
+
+		for userID, roomIDs := range rooms {
+			for _, roomID := range roomIDs {
+				if roomID == data.RoomID.ValueString() {
+					data.UserID = types.StringValue(userID)
+				}
+			}
+		}
+
+		if data.UserID.IsNull() {
+			resp.Diagnostics.AddError(
+				"Not a Direct Message Room",
+				fmt.Sprintf("The room %s is not listed in the m.direct account data of the provider user.", data.RoomID.ValueString()),
+			)
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DMResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DMResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DMResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DMResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateDirectRooms(r.client, data.UserID.ValueString(), data.RoomID.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unmark room as direct message, got error: %s", err))
+		return
+	}
+
+	err = leaveAndForgetRoom(r.client, data.RoomID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to leave direct message room, got error: %s", err))
+		return
+	}
+}
+
+func (r *DMResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
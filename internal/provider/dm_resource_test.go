@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDMResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-dm"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDMResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("matrix_dm.test", "room_id", regexp.MustCompile(`^!`)),
+					resource.TestMatchResourceAttr("matrix_dm.test", "user_id", regexp.MustCompile(`^@tf-acc-dm:`)),
+				),
+			},
+			// ImportState testing finds the user in m.direct
+			{
+				ResourceName:      "matrix_dm.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccDMResourceConfig = `
+variable "user_id" {}
+
+resource "matrix_dm" "test" {
+  user_id = var.user_id
+}
+`
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FilterResource{}
+var _ resource.ResourceWithImportState = &FilterResource{}
+
+func NewFilterResource() resource.Resource {
+	return &FilterResource{}
+}
+
+// FilterResource defines the resource implementation.
+type FilterResource struct {
+	client *gomatrix.Client
+}
+
+// FilterResourceModel describes the resource data model.
+type FilterResourceModel struct {
+	UserID     types.String         `tfsdk:"user_id"`
+	FilterJSON jsontypes.Normalized `tfsdk:"filter_json"`
+	FilterID   types.String         `tfsdk:"filter_id"`
+	Id         types.String         `tfsdk:"id"`
+}
+
+func (r *FilterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_filter"
+}
+
+func (r *FilterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a filter, which clients and bots pass to `/sync` or `/messages` by its ID to limit the " +
+			"events they receive. Filters cannot be changed, so changing the filter creates a new one with a new ID. " +
+			"Filters cannot be deleted either, destroying the resource only removes it from the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user owning the filter. Users can only upload their own filters, " +
+					"so this defaults to and must be the provider user.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"filter_json": schema.StringAttribute{
+				MarkdownDescription: "The filter as JSON object, e.g. from `jsonencode`. " +
+					"Differences in key order or whitespace are ignored.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"filter_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the filter, to be passed as `filter` parameter.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/filter_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FilterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *FilterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FilterResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsUnknown() {
+		data.UserID = types.StringValue(r.client.UserID)
+	}
+
+	var filter gomatrix.RespCreateFilter
+	err := r.client.MakeRequest("POST", r.client.BuildURL("user", data.UserID.ValueString(), "filter"), json.RawMessage(data.FilterJSON.ValueString()), &filter)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create filter, got error: %s", err))
+		return
+	}
+
+	data.FilterID = types.StringValue(filter.FilterID)
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + filter.FilterID)
+
+	tflog.Trace(ctx, "created filter", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FilterResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filter json.RawMessage
+	err := r.client.MakeRequest("GET", r.client.BuildURL("user", data.UserID.ValueString(), "filter", data.FilterID.ValueString()), nil, &filter)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "filter no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read filter, got error: %s", err))
+		return
+	}
+
+	// Filters cannot change, but homeservers may return them with defaults
+	// filled in, so only imported filters take the returned JSON.
+	if data.FilterJSON.IsNull() {
+		data.FilterJSON = jsontypes.NewNormalizedValue(string(filter))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FilterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FilterResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FilterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Filters cannot be deleted, removing the resource from the state is all
+	// there is to do.
+}
+
+func (r *FilterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id", "filter_id")
+}
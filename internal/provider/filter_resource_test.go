@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFilterResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFilterResourceConfig(10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("matrix_filter.test", "filter_id", regexp.MustCompile(`.+`)),
+					resource.TestMatchResourceAttr("matrix_filter.test", "id", regexp.MustCompile(`^@.+/.+$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_filter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The homeserver may return the filter with defaults filled in.
+				ImportStateVerifyIgnore: []string{"filter_json"},
+			},
+			// Replace and Read testing
+			{
+				Config: testAccFilterResourceConfig(20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_filter.test", "filter_json", `{"room":{"timeline":{"limit":20,"types":["m.room.message"]}}}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFilterResourceConfig(limit int) string {
+	return fmt.Sprintf(`
+resource "matrix_filter" "test" {
+  filter_json = jsonencode({
+    room = {
+      timeline = {
+        limit = %d
+        types = ["m.room.message"]
+      }
+    }
+  })
+}
+`, limit)
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IgnoredUsersResource{}
+var _ resource.ResourceWithImportState = &IgnoredUsersResource{}
+
+func NewIgnoredUsersResource() resource.Resource {
+	return &IgnoredUsersResource{}
+}
+
+// IgnoredUsersResource defines the resource implementation.
+type IgnoredUsersResource struct {
+	client *gomatrix.Client
+}
+
+// IgnoredUsersResourceModel describes the resource data model.
+type IgnoredUsersResourceModel struct {
+	UserID         types.String   `tfsdk:"user_id"`
+	IgnoredUserIDs []types.String `tfsdk:"ignored_user_ids"`
+	Id             types.String   `tfsdk:"id"`
+}
+
+// ignoredUserListContent is the content of the m.ignored_user_list account
+// data. The values are empty objects reserved for future use.
+type ignoredUserListContent struct {
+	IgnoredUsers map[string]struct{} `json:"ignored_users"`
+}
+
+// ignoredUserListURL builds the URL of the m.ignored_user_list account data.
+func (r *IgnoredUsersResource) ignoredUserListURL(data IgnoredUsersResourceModel) string {
+	return r.client.BuildURL("user", data.UserID.ValueString(), "account_data", "m.ignored_user_list")
+}
+
+func (r *IgnoredUsersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ignored_users"
+}
+
+func (r *IgnoredUsersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the users ignored by the provider user, stored in the `m.ignored_user_list` account data. " +
+			"The homeserver hides all events and invites of ignored users. Users ignored in a client show up as drift " +
+			"and are unignored by the next apply. Destroying the resource unignores all users.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user ignoring the users. Users can only access their own account data, " +
+					"so this defaults to and must be the provider user.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"ignored_user_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the ignored users.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixUserID()),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user ignoring the users",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IgnoredUsersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// set replaces the ignored users with the ones in data.
+func (r *IgnoredUsersResource) set(data IgnoredUsersResourceModel) error {
+	content := ignoredUserListContent{IgnoredUsers: map[string]struct{}{}}
+	for _, userID := range data.IgnoredUserIDs {
+		content.IgnoredUsers[userID.ValueString()] = struct{}{}
+	}
+
+	return r.client.MakeRequest("PUT", r.ignoredUserListURL(data), content, nil)
+}
+
+func (r *IgnoredUsersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IgnoredUsersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsUnknown() {
+		data.UserID = types.StringValue(r.client.UserID)
+	}
+
+	err := r.set(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set ignored users, got error: %s", err))
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "set ignored users", map[string]any{"user_id": data.UserID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IgnoredUsersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IgnoredUsersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content ignoredUserListContent
+	err := r.client.MakeRequest("GET", r.ignoredUserListURL(data), nil, &content)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ignored users, got error: %s", err))
+		return
+	}
+
+	// A missing list means no user is ignored, which is drift to correct
+	// rather than a reason to recreate the resource.
+	userIDs := make([]string, 0, len(content.IgnoredUsers))
+	for userID := range content.IgnoredUsers {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	data.IgnoredUserIDs = make([]types.String, 0, len(userIDs))
+	for _, userID := range userIDs {
+		data.IgnoredUserIDs = append(data.IgnoredUserIDs, types.StringValue(userID))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IgnoredUsersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IgnoredUsersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.set(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set ignored users, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IgnoredUsersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IgnoredUsersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.IgnoredUserIDs = nil
+	err := r.set(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unignore users, got error: %s", err))
+		return
+	}
+}
+
+func (r *IgnoredUsersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id")
+}
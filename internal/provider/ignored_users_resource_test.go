@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIgnoredUsersResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIgnoredUsersResourceConfig("@spam:example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_ignored_users.test", "ignored_user_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("matrix_ignored_users.test", "ignored_user_ids.*", "@spam:example.com"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_ignored_users.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccIgnoredUsersResourceConfig("@spam:example.com", "@troll:example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_ignored_users.test", "ignored_user_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("matrix_ignored_users.test", "ignored_user_ids.*", "@troll:example.com"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccIgnoredUsersResourceConfig(userIDs ...string) string {
+	return fmt.Sprintf(`
+resource "matrix_ignored_users" "test" {
+  ignored_user_ids = ["%s"]
+}
+`, strings.Join(userIDs, `", "`))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importCompositeID splits a slash-separated import identifier into the given
+// root attributes, e.g. importCompositeID(ctx, req, resp, "room_id", "user_id")
+// for "!room:example.com/@alice:example.com", and stores the identifier as
+// id. The framework calls Read afterwards to fill in everything else.
+//
+// The last attribute receives the remainder of the identifier, so it is the
+// only one that may contain slashes. It returns nil if the identifier is
+// malformed, in which case an error diagnostic was added.
+func importCompositeID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, attributes ...string) []string {
+	parts := strings.SplitN(req.ID, "/", len(attributes))
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+			break
+		}
+	}
+
+	if len(parts) != len(attributes) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: %s. Got: %q", strings.Join(attributes, "/"), req.ID),
+		)
+		return nil
+	}
+
+	for i, attribute := range attributes {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), parts[i])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	return parts
+}
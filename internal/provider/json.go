@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+)
+
+// jsonEqual reports whether two JSON documents are semantically equal, i.e.
+// they only differ in formatting and key order. It applies the semantic
+// equality of jsontypes.Normalized, so documents read from the homeserver
+// compare the same way as the JSON attributes. Invalid JSON is never equal.
+func jsonEqual(a []byte, b []byte) bool {
+	equal, diags := jsontypes.NewNormalizedValue(string(a)).StringSemanticEquals(context.Background(), jsontypes.NewNormalizedValue(string(b)))
+	return equal && !diags.HasError()
+}
+
+// canonicalJSON re-encodes a JSON document with sorted keys, without
+// insignificant whitespace and without escaping HTML characters, so equal
+// documents always encode to the same bytes.
+func canonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "testing"
+
+func TestJSONEqual(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b     string
+		expected bool
+	}{
+		"identical":  {a: `{"a":1}`, b: `{"a":1}`, expected: true},
+		"whitespace": {a: `{"a":1}`, b: "{\n  \"a\": 1\n}", expected: true},
+		"key-order":  {a: `{"a":1,"b":2}`, b: `{"b":2,"a":1}`, expected: true},
+		"numbers":    {a: `{"a":1}`, b: `{"a":1.0}`, expected: false},
+		"different":  {a: `{"a":1}`, b: `{"a":2}`, expected: false},
+		"extra-key":  {a: `{"a":1}`, b: `{"a":1,"b":2}`, expected: false},
+		"invalid":    {a: `{"a":1}`, b: `{"a":`, expected: false},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := jsonEqual([]byte(testCase.a), []byte(testCase.b)); actual != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       string
+		expected    string
+		expectError bool
+	}{
+		"compact":    {value: `{"a":1}`, expected: `{"a":1}`},
+		"whitespace": {value: "{\n  \"a\": 1\n}", expected: `{"a":1}`},
+		"key-order":  {value: `{"b":{"d":1,"c":2},"a":[3,1]}`, expected: `{"a":[3,1],"b":{"c":2,"d":1}}`},
+		"large-int":  {value: `{"ts":1700000000000123}`, expected: `{"ts":1700000000000123}`},
+		"html":       {value: `{"body":"<b>&</b>"}`, expected: `{"body":"<b>&</b>"}`},
+		"invalid":    {value: `{"a":`, expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := canonicalJSON([]byte(testCase.value))
+			if (err != nil) != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, err)
+			}
+			if string(actual) != testCase.expected {
+				t.Fatalf("expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
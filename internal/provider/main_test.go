@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/matrix-org/gomatrix"
+)
+
+const (
+	// testAccSynapseURL and testAccSynapseSharedSecret match
+	// testing/docker-compose.yml and testing/synapse/homeserver.yaml.
+	testAccSynapseURL          = "http://127.0.0.1:8008"
+	testAccSynapseSharedSecret = "terraform-acceptance-tests"
+	testAccSynapseComposeFile  = "../../testing/docker-compose.yml"
+
+	testAccAdminLocalpart = "tf-acc-admin"
+	testAccAdminPassword  = "tf-acc-admin-password"
+)
+
+// TestMain starts the Synapse homeserver from testing/docker-compose.yml for
+// acceptance tests, unless MATRIX_CLIENT_SERVER_URL points to another
+// homeserver. A homeserver that is already running, e.g. from `make synapse`,
+// is reused and left running.
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC") == "" || os.Getenv("MATRIX_CLIENT_SERVER_URL") != "" {
+		os.Exit(m.Run())
+	}
+
+	stop, err := testAccStartSynapse()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to start Synapse for acceptance tests: %s\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// testAccStartSynapse starts the homeserver if needed, registers the admin
+// user and configures the provider for it through the environment. The
+// returned function stops the homeserver again if it was started here.
+func testAccStartSynapse() (func(), error) {
+	stop := func() {}
+
+	if !testAccSynapseReachable() {
+		err := testAccCompose("up", "--detach", "--wait")
+		if err != nil {
+			return nil, err
+		}
+
+		stop = func() {
+			err := testAccCompose("down", "--volumes")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "unable to stop Synapse: %s\n", err)
+			}
+		}
+
+		deadline := time.Now().Add(2 * time.Minute)
+		for !testAccSynapseReachable() {
+			if time.Now().After(deadline) {
+				stop()
+				return nil, fmt.Errorf("homeserver at %s did not become ready", testAccSynapseURL)
+			}
+			time.Sleep(time.Second)
+		}
+	}
+
+	login, err := testAccRegisterAdmin()
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	env := map[string]string{
+		"MATRIX_CLIENT_SERVER_URL":    testAccSynapseURL,
+		"MATRIX_DEFAULT_ACCESS_TOKEN": login.AccessToken,
+		"MATRIX_DEFAULT_USERID":       login.UserID,
+	}
+	for key, value := range env {
+		err = os.Setenv(key, value)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+	}
+
+	return stop, nil
+}
+
+// testAccCompose runs docker compose with the acceptance test stack.
+func testAccCompose(args ...string) error {
+	cmd := exec.Command("docker", append([]string{"compose", "--file", testAccSynapseComposeFile}, args...)...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("docker compose %v failed: %w", args, err)
+	}
+
+	return nil
+}
+
+// testAccSynapseReachable reports whether the homeserver answers requests.
+func testAccSynapseReachable() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var versions map[string]any
+	found, err := getJSON(ctx, http.DefaultClient, testAccSynapseURL+"/_matrix/client/versions", &versions)
+	return err == nil && found
+}
+
+// testAccRegisterAdmin registers the admin user with the shared secret
+// registration admin API. If the user exists from an earlier run against the
+// same homeserver, it logs in instead.
+// See https://element-hq.github.io/synapse/latest/admin_api/register_api.html
+func testAccRegisterAdmin() (*gomatrix.RespLogin, error) {
+	client, err := gomatrix.NewClient(testAccSynapseURL, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce struct {
+		Nonce string `json:"nonce"`
+	}
+	err = client.MakeRequest("GET", synapseAdminURL(client, "v1", "register"), nil, &nonce)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get registration nonce: %w", err)
+	}
+
+	mac := hmac.New(sha1.New, []byte(testAccSynapseSharedSecret))
+	mac.Write([]byte(nonce.Nonce + "\x00" + testAccAdminLocalpart + "\x00" + testAccAdminPassword + "\x00admin"))
+
+	var login gomatrix.RespLogin
+	err = client.MakeRequest("POST", synapseAdminURL(client, "v1", "register"), map[string]any{
+		"nonce":    nonce.Nonce,
+		"username": testAccAdminLocalpart,
+		"password": testAccAdminPassword,
+		"admin":    true,
+		"mac":      hex.EncodeToString(mac.Sum(nil)),
+	}, &login)
+	if matrixErrCode(err) == "M_USER_IN_USE" {
+		loginResp, err := client.Login(&gomatrix.ReqLogin{
+			Type:     "m.login.password",
+			User:     testAccAdminLocalpart,
+			Password: testAccAdminPassword,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to log in as admin user: %w", err)
+		}
+
+		return loginResp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to register admin user: %w", err)
+	}
+
+	return &login, nil
+}
@@ -0,0 +1,325 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PolicyRoomResource{}
+var _ resource.ResourceWithImportState = &PolicyRoomResource{}
+
+// policyShortcodeEventType is the state event Mjolnir and Draupnir use to
+// name a policy room in their commands.
+const policyShortcodeEventType = "org.matrix.mjolnir.shortcode"
+
+// policyModeratorPowerLevel is the power level needed to change the policy
+// rules of a policy room.
+const policyModeratorPowerLevel = 50
+
+func NewPolicyRoomResource() resource.Resource {
+	return &PolicyRoomResource{}
+}
+
+// PolicyRoomResource defines the resource implementation.
+type PolicyRoomResource struct {
+	client         *gomatrix.Client
+	preventDestroy bool
+}
+
+// PolicyRoomResourceModel describes the resource data model.
+type PolicyRoomResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Topic       types.String `tfsdk:"topic"`
+	Shortcode   types.String `tfsdk:"shortcode"`
+	RoomVersion types.String `tfsdk:"room_version"`
+	OnDestroy   types.String `tfsdk:"on_destroy"`
+	RoomID      types.String `tfsdk:"room_id"`
+	Id          types.String `tfsdk:"id"`
+}
+
+func (r *PolicyRoomResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_room"
+}
+
+func (r *PolicyRoomResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a moderation policy room as described in [MSC2313](https://github.com/matrix-org/matrix-spec-proposals/pull/2313), " +
+			"a ban list that moderation bots like Mjolnir and Draupnir subscribe to. Add rules to it with `matrix_policy_rule`.\n\n" +
+			"The room is created like the policy lists of Mjolnir: anyone can join and read it, only members with power level " +
+			"50 or more can send events and change rules. Grant moderators that power with `matrix_room_power_level_user`.\n\n" +
+			"Like `matrix_room`, destroying this resource makes the provider user leave and forget the room by default.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the policy room.",
+				Optional:            true,
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "The topic of the policy room.",
+				Optional:            true,
+			},
+			"shortcode": schema.StringAttribute{
+				MarkdownDescription: "The short name moderation bots use for the list in their commands, e.g. `spam`.",
+				Optional:            true,
+			},
+			"room_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the room, e.g. `10`. Defaults to the `default_room_version` " +
+					"of the homeserver as reported by its capabilities. Changing it creates a new room.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": onDestroyAttribute("room"),
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the policy room, e.g. to subscribe moderation bots to it.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the policy room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PolicyRoomResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+	r.preventDestroy = providerData.PreventDestroyRooms
+}
+
+func (r *PolicyRoomResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PolicyRoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilities, err := getCapabilities(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read available room versions, got error: %s", err))
+		return
+	}
+
+	// Check a pinned version up front, the homeserver only answers with
+	// M_UNSUPPORTED_ROOM_VERSION.
+	if data.RoomVersion.IsUnknown() {
+		data.RoomVersion = types.StringValue(capabilities.Capabilities.RoomVersions.Default)
+	} else if message := capabilities.unsupportedRoomVersion(data.RoomVersion.ValueString()); message != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("room_version"), "Unsupported Room Version", message)
+		return
+	}
+
+	reqBody := createRoomRequest{
+		ReqCreateRoom: gomatrix.ReqCreateRoom{
+			Name:   data.Name.ValueString(),
+			Topic:  data.Topic.ValueString(),
+			Preset: "public_chat",
+		},
+		RoomVersion: data.RoomVersion.ValueString(),
+		// Policy rules are state events, so state_default guards them.
+		PowerLevelContentOverride: map[string]interface{}{
+			"events_default": policyModeratorPowerLevel,
+			"state_default":  policyModeratorPowerLevel,
+			"users_default":  0,
+		},
+	}
+
+	if !data.Shortcode.IsNull() {
+		stateKey := ""
+		reqBody.InitialState = append(reqBody.InitialState, gomatrix.Event{
+			Type:     policyShortcodeEventType,
+			StateKey: &stateKey,
+			Content:  map[string]interface{}{"shortcode": data.Shortcode.ValueString()},
+		})
+	}
+
+	var room gomatrix.RespCreateRoom
+	err = r.client.MakeRequest("POST", r.client.BuildURL("createRoom"), reqBody, &room)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create policy room, got error: %s", err))
+		return
+	}
+
+	data.RoomID = types.StringValue(room.RoomID)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "created policy room", map[string]any{"room_id": room.RoomID, "room_version": data.RoomVersion.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyRoomResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PolicyRoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var createJSON json.RawMessage
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.create", "", &createJSON)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the policy room, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read policy room, got error: %s", err))
+		return
+	}
+
+	var create roomCreateContent
+	err = json.Unmarshal(createJSON, &create)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse m.room.create event, got error: %s", err))
+		return
+	}
+
+	// Rooms created before room versions existed have no room_version.
+	if create.RoomVersion == "" {
+		create.RoomVersion = "1"
+	}
+	data.RoomVersion = types.StringValue(create.RoomVersion)
+
+	data.Name, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.name state event, got error: %s", err))
+		return
+	}
+
+	data.Topic, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.topic", "topic")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.topic state event, got error: %s", err))
+		return
+	}
+
+	data.Shortcode, err = getRoomStateField(r.client, data.RoomID.ValueString(), policyShortcodeEventType, "shortcode")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", policyShortcodeEventType, err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyRoomResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PolicyRoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	if !data.Name.Equal(state.Name) {
+		err := setRoomStateField(r.client, roomID, "m.room.name", "name", data.Name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.name state event, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Topic.Equal(state.Topic) {
+		err := setRoomStateField(r.client, roomID, "m.room.topic", "topic", data.Topic)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.topic state event, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Shortcode.Equal(state.Shortcode) {
+		err := setRoomStateField(r.client, roomID, policyShortcodeEventType, "shortcode", data.Shortcode)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", policyShortcodeEventType, err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyRoomResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PolicyRoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.preventDestroy {
+		resp.Diagnostics.AddError(
+			"Room Destruction Prevented",
+			fmt.Sprintf("The provider has prevent_destroy_rooms enabled, so the policy room %s cannot be destroyed. "+
+				"Disable prevent_destroy_rooms in the provider configuration or remove the policy room from the state "+
+				"with terraform state rm to stop managing it.", data.RoomID.ValueString()),
+		)
+		return
+	}
+
+	err := destroyRoom(ctx, r.client, data.RoomID.ValueString(), data.OnDestroy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete policy room, got error: %s", err))
+		return
+	}
+}
+
+func (r *PolicyRoomResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+
+	// on_destroy only exists in Terraform, assume the default.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), "leave")...)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccPolicyRoomResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPolicyRoomResourceConfig("spam"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_policy_room.test", "name", "Spam ban list"),
+					resource.TestCheckResourceAttr("matrix_policy_room.test", "shortcode", "spam"),
+					resource.TestCheckResourceAttrPair("matrix_policy_room.test", "id", "matrix_policy_room.test", "room_id"),
+					testAccCheckRoomStateEvent(t, "matrix_policy_room.test", "org.matrix.mjolnir.shortcode", "shortcode", "spam"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_policy_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing changes the shortcode in place
+			{
+				Config: testAccPolicyRoomResourceConfig("abuse"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_policy_room.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoomStateEvent(t, "matrix_policy_room.test", "org.matrix.mjolnir.shortcode", "shortcode", "abuse"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPolicyRoomResourceConfig(shortcode string) string {
+	return fmt.Sprintf(`
+resource "matrix_policy_room" "test" {
+  name      = "Spam ban list"
+  shortcode = %q
+}
+`, shortcode)
+}
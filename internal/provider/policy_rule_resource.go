@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PolicyRuleResource{}
+var _ resource.ResourceWithImportState = &PolicyRuleResource{}
+
+// policyRuleEntityTypes are the kinds of entities policy rules apply to,
+// each with its own m.policy.rule.<entity_type> state event type.
+var policyRuleEntityTypes = []string{"user", "room", "server"}
+
+func NewPolicyRuleResource() resource.Resource {
+	return &PolicyRuleResource{}
+}
+
+// PolicyRuleResource defines the resource implementation.
+type PolicyRuleResource struct {
+	client *gomatrix.Client
+}
+
+// PolicyRuleResourceModel describes the resource data model.
+type PolicyRuleResourceModel struct {
+	RoomID         types.String `tfsdk:"room_id"`
+	EntityType     types.String `tfsdk:"entity_type"`
+	Entity         types.String `tfsdk:"entity"`
+	Recommendation types.String `tfsdk:"recommendation"`
+	Reason         types.String `tfsdk:"reason"`
+	StateKey       types.String `tfsdk:"state_key"`
+	Id             types.String `tfsdk:"id"`
+}
+
+// policyRuleContent is the content of an m.policy.rule.* state event.
+type policyRuleContent struct {
+	Entity         string `json:"entity"`
+	Recommendation string `json:"recommendation"`
+	Reason         string `json:"reason"`
+}
+
+// eventType returns the state event type of the rule.
+func (m PolicyRuleResourceModel) eventType() string {
+	return "m.policy.rule." + m.EntityType.ValueString()
+}
+
+func (r *PolicyRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_rule"
+}
+
+func (r *PolicyRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a rule of a moderation policy room, e.g. from `matrix_policy_room`, as " +
+			"`m.policy.rule.user`, `m.policy.rule.room` or `m.policy.rule.server` state event. Moderation bots " +
+			"subscribed to the room apply the rule, e.g. by banning matching users from their protected rooms. " +
+			"Destroying the resource removes the rule.\n\n" +
+			"The provider user must be allowed to send the state event in the policy room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the policy room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"entity_type": schema.StringAttribute{
+				MarkdownDescription: "What the rule applies to, one of `user`, `room` or `server`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(policyRuleEntityTypes...),
+				},
+			},
+			"entity": schema.StringAttribute{
+				MarkdownDescription: "The user ID, room ID or alias, or server name the rule applies to. " +
+					"`*` and `?` are globs, e.g. `@*:evil.example` or `*.evil.example`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"recommendation": schema.StringAttribute{
+				MarkdownDescription: "What to do with matching entities. Defaults to `m.ban`, the only recommendation " +
+					"the specification defines.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("m.ban"),
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "Why the rule exists, shown to moderators and sometimes used as ban reason.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"state_key": schema.StringAttribute{
+				MarkdownDescription: "The state key of the rule event. Defaults to `rule:<entity>` like Mjolnir and Draupnir " +
+					"use, set it to adopt a rule with a different state key.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/entity_type/state_key`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PolicyRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// send sends the state event of the rule.
+func (r *PolicyRuleResource) send(data PolicyRuleResourceModel) error {
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.eventType(), data.StateKey.ValueString(), policyRuleContent{
+		Entity:         data.Entity.ValueString(),
+		Recommendation: data.Recommendation.ValueString(),
+		Reason:         data.Reason.ValueString(),
+	})
+	return err
+}
+
+func (r *PolicyRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PolicyRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.StateKey.IsUnknown() {
+		data.StateKey = types.StringValue("rule:" + data.Entity.ValueString())
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", data.eventType(), err))
+		return
+	}
+
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.EntityType.ValueString() + "/" + data.StateKey.ValueString())
+
+	tflog.Trace(ctx, "created policy rule", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PolicyRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content policyRuleContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), data.eventType(), data.StateKey.ValueString(), &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "policy rule no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", data.eventType(), err))
+		return
+	}
+
+	// Rules are removed by sending empty content.
+	if content.Entity == "" {
+		tflog.Warn(ctx, "policy rule was removed, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Entity = types.StringValue(content.Entity)
+	data.Recommendation = types.StringValue(content.Recommendation)
+	data.Reason = types.StringValue(content.Reason)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PolicyRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", data.eventType(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PolicyRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted, empty content removes the rule.
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.eventType(), data.StateKey.ValueString(), struct{}{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove policy rule, got error: %s", err))
+		return
+	}
+}
+
+func (r *PolicyRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "entity_type", "state_key")
+}
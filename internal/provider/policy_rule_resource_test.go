@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPolicyRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPolicyRuleResourceConfig("spam"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_policy_rule.test", "state_key", "rule:*.evil.example"),
+					resource.TestCheckResourceAttr("matrix_policy_rule.test", "recommendation", "m.ban"),
+					resource.TestCheckResourceAttr("matrix_policy_rule.test", "reason", "spam"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_policy_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPolicyRuleResourceConfig("spam and abuse"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_policy_rule.test", "reason", "spam and abuse"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPolicyRuleResourceConfig(reason string) string {
+	return fmt.Sprintf(`
+resource "matrix_policy_room" "test" {
+  name = "Ban list"
+}
+
+resource "matrix_policy_rule" "test" {
+  room_id     = matrix_policy_room.test.room_id
+  entity_type = "server"
+  entity      = "*.evil.example"
+  reason      = %q
+}
+`, reason)
+}
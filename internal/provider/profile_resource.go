@@ -0,0 +1,350 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProfileResource{}
+var _ resource.ResourceWithImportState = &ProfileResource{}
+var _ resource.ResourceWithConfigValidators = &ProfileResource{}
+var _ resource.ResourceWithModifyPlan = &ProfileResource{}
+
+func NewProfileResource() resource.Resource {
+	return &ProfileResource{}
+}
+
+// ProfileResource defines the resource implementation.
+type ProfileResource struct {
+	client *gomatrix.Client
+}
+
+// ProfileResourceModel describes the resource data model.
+type ProfileResourceModel struct {
+	UserID             types.String `tfsdk:"user_id"`
+	Displayname        types.String `tfsdk:"displayname"`
+	AvatarSource       types.String `tfsdk:"avatar_source"`
+	AvatarSourceSHA256 types.String `tfsdk:"avatar_source_sha256"`
+	AvatarURL          types.String `tfsdk:"avatar_url"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+func (r *ProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_profile"
+}
+
+func (r *ProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the display name and avatar of the provider user, e.g. to define the identity of a bot in code. " +
+			"Renames and avatar changes made in a client show up as drift and are reverted by the next apply. " +
+			"Unset attributes are left as they are. Destroying the resource does not change the profile.\n\n" +
+			"A local `avatar_source` is uploaded to the content repository, and uploaded again whenever its content changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the provider user.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"displayname": schema.StringAttribute{
+				MarkdownDescription: "The display name of the provider user.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"avatar_source": schema.StringAttribute{
+				MarkdownDescription: "The path of a local image to upload as avatar, e.g. `\"${path.module}/bot.png\"`. " +
+					"Conflicts with `avatar_url`.",
+				Optional: true,
+			},
+			"avatar_source_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the uploaded `avatar_source` file.",
+				Computed:            true,
+			},
+			"avatar_url": schema.StringAttribute{
+				MarkdownDescription: "The `mxc://` URI of the avatar. Set it to use media that is already uploaded, " +
+					"otherwise it is the URI of the uploaded `avatar_source` or the current avatar.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MxcURI(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the provider user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ProfileResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("avatar_source"),
+			path.MatchRoot("avatar_url"),
+		),
+	}
+}
+
+func (r *ProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *ProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to upload on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AvatarSource.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_source_sha256"), types.StringNull())...)
+		return
+	}
+
+	if plan.AvatarSource.IsUnknown() {
+		return
+	}
+
+	// Hash the file at plan time, so changed images show up as a diff.
+	file, err := readContentFile(plan.AvatarSource.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("avatar_source"), "Unable to Read Avatar", fmt.Sprintf("Unable to read %s, got error: %s", plan.AvatarSource.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_source_sha256"), file.sha256)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The uploaded media is still current, keep using it.
+		if state.AvatarSourceSHA256.ValueString() == file.sha256 {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_url"), types.StringUnknown())...)
+}
+
+// getDisplayname returns the display name of the provider user, or an empty
+// string if there is none.
+func (r *ProfileResource) getDisplayname() (string, error) {
+	displayname, err := r.client.GetOwnDisplayName()
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return displayname.DisplayName, nil
+}
+
+// apply uploads the avatar source if needed and updates the profile fields
+// that differ from prior. Unknown fields are read from the homeserver.
+func (r *ProfileResource) apply(ctx context.Context, data *ProfileResourceModel, prior ProfileResourceModel) error {
+	if data.Displayname.IsUnknown() {
+		displayname, err := r.getDisplayname()
+		if err != nil {
+			return fmt.Errorf("unable to read display name: %w", err)
+		}
+		data.Displayname = types.StringValue(displayname)
+	} else if !data.Displayname.Equal(prior.Displayname) {
+		err := r.client.SetDisplayName(data.Displayname.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to set display name: %w", err)
+		}
+	}
+
+	if !data.AvatarSource.IsNull() && data.AvatarURL.IsUnknown() {
+		file, err := readContentFile(data.AvatarSource.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to read avatar: %w", err)
+		}
+
+		contentURI, err := file.upload(r.client, file.contentType, filepath.Base(data.AvatarSource.ValueString()))
+		if err != nil {
+			return fmt.Errorf("unable to upload avatar: %w", err)
+		}
+
+		tflog.Trace(ctx, "uploaded avatar", map[string]any{"source": data.AvatarSource.ValueString(), "url": contentURI})
+
+		data.AvatarURL = types.StringValue(contentURI)
+		data.AvatarSourceSHA256 = types.StringValue(file.sha256)
+	}
+
+	if data.AvatarURL.IsUnknown() {
+		avatarURL, err := r.client.GetAvatarURL()
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("unable to read avatar URL: %w", err)
+		}
+		data.AvatarURL = types.StringValue(avatarURL)
+	} else if !data.AvatarURL.Equal(prior.AvatarURL) {
+		err := r.client.SetAvatarURL(data.AvatarURL.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to set avatar URL: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProfileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without prior state, every known field is sent.
+	err := r.apply(ctx, &data, ProfileResourceModel{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update profile, got error: %s", err))
+		return
+	}
+
+	data.UserID = types.StringValue(r.client.UserID)
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "updated profile", map[string]any{"user_id": r.client.UserID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProfileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The resource belongs to the provider user, which may have changed
+	// since the resource was created.
+	if data.UserID.ValueString() != r.client.UserID {
+		tflog.Warn(ctx, "provider user changed, removing from state", map[string]any{"user_id": data.UserID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	displayname, err := r.getDisplayname()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read display name, got error: %s", err))
+		return
+	}
+	data.Displayname = types.StringValue(displayname)
+
+	avatarURL, err := r.client.GetAvatarURL()
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read avatar URL, got error: %s", err))
+		return
+	}
+
+	// Forget the hash if the avatar was changed elsewhere, so the next plan
+	// uploads the source again.
+	if avatarURL != data.AvatarURL.ValueString() {
+		data.AvatarSourceSHA256 = types.StringNull()
+	}
+	data.AvatarURL = types.StringValue(avatarURL)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ProfileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apply(ctx, &data, state)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update profile, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The profile stays as it is, only forget the resource.
+}
+
+func (r *ProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != r.client.UserID {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("matrix_profile manages the provider user, expected %s, got: %q", r.client.UserID, req.ID),
+		)
+		return
+	}
+
+	importCompositeID(ctx, req, resp, "user_id")
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProfileResource(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "bot.png")
+	testAccWriteFile(t, source, "bot avatar")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProfileResourceConfig("Build Bot", source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_profile.test", "user_id", os.Getenv("MATRIX_DEFAULT_USERID")),
+					resource.TestCheckResourceAttr("matrix_profile.test", "displayname", "Build Bot"),
+					resource.TestMatchResourceAttr("matrix_profile.test", "avatar_url", regexp.MustCompile(`^mxc://`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_profile.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"avatar_source", "avatar_source_sha256"},
+			},
+			// A rename in a client is reverted
+			{
+				PreConfig: func() {
+					err := testAccClient(t).SetDisplayName("Renamed")
+					if err != nil {
+						t.Fatalf("unable to rename provider user: %s", err)
+					}
+				},
+				Config: testAccProfileResourceConfig("Build Bot", source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDisplayname(t, "Build Bot"),
+				),
+			},
+			// Update testing
+			{
+				Config: testAccProfileResourceConfig("Deploy Bot", source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDisplayname(t, "Deploy Bot"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckDisplayname asserts the display name of the provider user on
+// the homeserver.
+func testAccCheckDisplayname(t *testing.T, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		displayname, err := testAccClient(t).GetOwnDisplayName()
+		if err != nil {
+			return err
+		}
+
+		if displayname.DisplayName != expected {
+			return fmt.Errorf("expected display name %q, got %q", expected, displayname.DisplayName)
+		}
+
+		return nil
+	}
+}
+
+func testAccProfileResourceConfig(displayname string, source string) string {
+	return fmt.Sprintf(`
+resource "matrix_profile" "test" {
+  displayname   = %q
+  avatar_source = %q
+}
+`, displayname, source)
+}
@@ -5,13 +5,18 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/matrix-org/gomatrix"
@@ -19,6 +24,8 @@ import (
 
 // Ensure MatrixProvider satisfies various provider interfaces.
 var _ provider.Provider = &MatrixProvider{}
+var _ provider.ProviderWithMetaSchema = &MatrixProvider{}
+var _ provider.ProviderWithConfigValidators = &MatrixProvider{}
 
 // MatrixProvider defines the provider implementation.
 type MatrixProvider struct {
@@ -30,9 +37,21 @@ type MatrixProvider struct {
 
 // MatrixProviderModel describes the provider data model.
 type MatrixProviderModel struct {
-	ClientServerUrl    types.String `tfsdk:"client_server_url"`
-	DefaultAccessToken types.String `tfsdk:"default_access_token"`
-	DefaultUserID      types.String `tfsdk:"default_user_id"`
+	ClientServerUrl     types.String `tfsdk:"client_server_url"`
+	DefaultAccessToken  types.String `tfsdk:"default_access_token"`
+	DefaultUserID       types.String `tfsdk:"default_user_id"`
+	DiscoverWellKnown   types.Bool   `tfsdk:"discover_well_known"`
+	PreventDestroyRooms types.Bool   `tfsdk:"prevent_destroy_rooms"`
+}
+
+// MatrixProviderData is passed to the Configure method of every resource and
+// data source.
+type MatrixProviderData struct {
+	Client *gomatrix.Client
+
+	// PreventDestroyRooms makes destroying rooms fail instead of leaving
+	// them.
+	PreventDestroyRooms bool
 }
 
 func (p *MatrixProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -42,19 +61,59 @@ func (p *MatrixProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 
 func (p *MatrixProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages rooms and homeserver settings through the Matrix client-server API " +
+			"and the Synapse admin API.\n\n" +
+			"Each provider configuration talks to exactly one homeserver. To manage several homeservers from the same " +
+			"configuration, declare one aliased provider block per homeserver and select it with the `provider` " +
+			"meta-argument on resources, or pass it to modules through their `providers` map.",
+
 		Attributes: map[string]schema.Attribute{
 			"client_server_url": schema.StringAttribute{
-				MarkdownDescription: "Address of the matrix server you are acting upon",
-				Required:            true,
+				MarkdownDescription: "Address of the matrix server you are acting upon. Can also be set with the `MATRIX_CLIENT_SERVER_URL` environment variable.",
+				Optional:            true,
 			},
 			"default_access_token": schema.StringAttribute{
-				MarkdownDescription: "The default access token to use for things like content uploads.",
-				Required:            true,
+				MarkdownDescription: "The default access token to use for things like content uploads. Can also be set with the `MATRIX_DEFAULT_ACCESS_TOKEN` environment variable.",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"default_user_id": schema.StringAttribute{
-				MarkdownDescription: "The default user id to use for things like content uploads. This must match the access_token",
-				Required:            true,
+				MarkdownDescription: "The default user id to use for things like content uploads. This must match the access_token. Can also be set with the `MATRIX_DEFAULT_USERID` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"discover_well_known": schema.BoolAttribute{
+				MarkdownDescription: "Resolve `client_server_url` from the server name of `default_user_id` through its " +
+					"`/.well-known/matrix/client` file. Conflicts with `client_server_url`. Defaults to `false`.",
+				Optional: true,
+			},
+			"prevent_destroy_rooms": schema.BoolAttribute{
+				MarkdownDescription: "Make destroying a `matrix_room`, `matrix_space` or `matrix_policy_room` fail, like `lifecycle.prevent_destroy` for all rooms " +
+					"managed by this provider configuration. Defaults to `false`.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (p *MatrixProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		// Discovery is skipped when the URL is set, which would otherwise go
+		// unnoticed.
+		conflictingAttributesValidator{attributes: []string{"client_server_url", "discover_well_known"}},
+	}
+}
+
+func (p *MatrixProvider) MetaSchema(_ context.Context, _ provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"module_name": metaschema.StringAttribute{
+				MarkdownDescription: "The name of the module using the provider, set in a `provider_meta \"matrix\"` block " +
+					"of the module. It is informational only and does not influence which homeserver is used; " +
+					"pass an aliased provider to the module for that.",
+				Optional: true,
 			},
 		},
 	}
@@ -96,6 +155,24 @@ func (p *MatrixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.DiscoverWellKnown.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("discover_well_known"),
+			"Unknown Well-Known Discovery Setting",
+			"The provider cannot create the Matrix API client as there is an unknown configuration value for discover_well_known. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.PreventDestroyRooms.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prevent_destroy_rooms"),
+			"Unknown Prevent Destroy Rooms Setting",
+			"The provider cannot be configured as there is an unknown configuration value for prevent_destroy_rooms. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -119,13 +196,36 @@ func (p *MatrixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		default_user_id = config.DefaultUserID.ValueString()
 	}
 
+	// Every provider block, e.g. one alias per homeserver, gets its own
+	// client and does not share any HTTP state with the others.
+	httpClient := &http.Client{}
+
+	if client_server_url == "" && config.DiscoverWellKnown.ValueBool() && strings.Contains(default_user_id, ":") {
+		serverName := strings.SplitN(default_user_id, ":", 2)[1]
+
+		discovered, err := discoverHomeserver(ctx, httpClient, serverName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("discover_well_known"),
+				"Matrix Server Discovery Failed",
+				"The provider cannot discover the Matrix API host of "+serverName+". "+
+					"Set the client_server_url value in the configuration or use the MATRIX_CLIENT_SERVER_URL environment variable instead.\n\n"+
+					"Discovery Error: "+err.Error(),
+			)
+			return
+		}
+
+		client_server_url = discovered.HomeserverURL
+		tflog.Debug(ctx, "Discovered Matrix server URL", map[string]any{"server_name": serverName, "client_server_url": client_server_url})
+	}
+
 	if client_server_url == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("client_server_url"),
 			"Missing Matrix Server URL",
 			"The provider cannot create the Matrix API client as there is a missing or empty value for the Matrix API host. "+
-				"Set the client_server_url value in the configuration or use the MATRIX_CLIENT_SERVER_URL environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the client_server_url value in the configuration, use the MATRIX_CLIENT_SERVER_URL environment variable "+
+				"or enable discover_well_known. If either is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -147,6 +247,15 @@ func (p *MatrixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 				"Set the default_user_id value in the configuration or use the MATRIX_DEFAULT_USERID environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
+	} else if config.DefaultUserID.IsNull() {
+		// The schema validator only sees the configuration, check the value
+		// from the environment the same way.
+		userIDResp := &validator.StringResponse{}
+		validators.MatrixUserID().ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("default_user_id"),
+			ConfigValue: types.StringValue(default_user_id),
+		}, userIDResp)
+		resp.Diagnostics.Append(userIDResp.Diagnostics...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -171,18 +280,105 @@ func (p *MatrixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 		return
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// gomatrix still defaults to the deprecated r0 prefix. Use the stable v3
+	// one so endpoints added after r0 are reachable through BuildURL.
+	client.Prefix = "/_matrix/client/v3"
+	client.Client = httpClient
+
+	providerData := &MatrixProviderData{
+		Client:              client,
+		PreventDestroyRooms: config.PreventDestroyRooms.ValueBool(),
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 
 	tflog.Info(ctx, "Configured Matrix client", map[string]any{"success": true})
 }
 
 func (p *MatrixProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		NewAccountDataResource,
+		NewContentResource,
+		NewDMResource,
+		NewFilterResource,
+		NewIgnoredUsersResource,
+		NewPolicyRoomResource,
+		NewPolicyRuleResource,
+		NewProfileResource,
+		NewPushRuleResource,
+		NewPusherResource,
+		NewRoomAccountDataResource,
+		NewRoomAliasResource,
+		NewRoomAvatarResource,
+		NewRoomBotMembershipResource,
+		NewRoomCanonicalAliasResource,
+		NewRoomDirectoryListingResource,
+		NewRoomEncryptionResource,
+		NewRoomEventRedactionResource,
+		NewRoomEventResource,
+		NewRoomGuestAccessResource,
+		NewRoomHistoryVisibilityResource,
+		NewRoomInviteOnlyPresetResource,
+		NewRoomJoinRulesResource,
+		NewRoomMembershipResource,
+		NewRoomMessageResource,
+		NewRoomNotificationLevelResource,
+		NewRoomPinnedEventsResource,
+		NewRoomPowerLevelUserResource,
+		NewRoomPowerLevelsResource,
+		NewRoomReadMarkerResource,
+		NewRoomResource,
+		NewRoomRetentionResource,
+		NewRoomServerACLResource,
+		NewRoomStateEventResource,
+		NewRoomTagResource,
+		NewRoomUpgradeResource,
+		NewSpaceChildResource,
+		NewSpaceResource,
+		NewSynapseAccountValidityResource,
+		NewSynapseDeleteEventReportResource,
+		NewSynapseEmail3pidResource,
+		NewSynapseFederationAllowListResource,
+		NewSynapseForwardExtremitiesCleanupResource,
+		NewSynapseMediaQuarantineResource,
+		NewSynapsePurgeHistoryResource,
+		NewSynapseRatelimitResource,
+		NewSynapseRegistrationTokenResource,
+		NewSynapseRoomBlockResource,
+		NewSynapseRoomDeleteResource,
+		NewSynapseRoomMakeAdminResource,
+		NewSynapseRoomMediaQuarantineResource,
+		NewSynapseServerNoticeResource,
+		NewSynapseUserResource,
+		NewSynapseUserAdminResource,
+		NewSynapseUserDeviceDeleteResource,
+		NewSynapseUserExternalIDResource,
+		NewSynapseUserLoginResource,
+		NewSynapseUserPasswordResource,
+		NewSynapseUserShadowBanResource,
+		NewWidgetResource,
+	}
 }
 
 func (p *MatrixProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewPublicRoomsDataSource,
+		NewRoomEventStreamDataSource,
+		NewRoomRelationsDataSource,
+		NewRoomStateSnapshotDataSource,
+		NewServerCapabilitiesDataSource,
+		NewSynapseBackgroundUpdateStatusDataSource,
+		NewSynapseForwardExtremitiesDataSource,
+		NewSynapseRoomEventContextDataSource,
+		NewSynapseRoomDetailsDataSource,
+		NewSynapseRoomReportDataSource,
+		NewSynapseRoomTimestampToEventDataSource,
+		NewSynapseStatsRoomDataSource,
+		NewSynapseStatsUserDataSource,
+		NewSynapseUserDevicesDataSource,
+		NewSynapseUserWhoisDataSource,
+		NewWellKnownDiscoveryDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ provider.ConfigValidator = conflictingAttributesValidator{}
+
+// conflictingAttributesValidator ensures that at most one of the provider
+// attributes is configured, e.g. two ways of authenticating. Attributes set
+// through environment variables are not seen here.
+type conflictingAttributesValidator struct {
+	attributes []string
+}
+
+func (v conflictingAttributesValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("only one of %s can be configured", strings.Join(v.attributes, ", "))
+}
+
+func (v conflictingAttributesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v conflictingAttributesValidator) ValidateProvider(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var configured []string
+
+	for _, attribute := range v.attributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if isConfigured(value) {
+			configured = append(configured, attribute)
+		}
+	}
+
+	if len(configured) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(configured[1]),
+			"Conflicting Provider Configuration",
+			fmt.Sprintf("The provider configuration sets %s, but %s.", strings.Join(configured, " and "), v.Description(ctx)),
+		)
+	}
+}
+
+// isConfigured reports whether a provider attribute is set. Unknown values
+// count as set, and an explicit false does not, so that an option can be
+// disabled without conflicting with its alternatives.
+func isConfigured(value attr.Value) bool {
+	if value.IsNull() {
+		return false
+	}
+
+	if boolValue, ok := value.(types.Bool); ok && !boolValue.IsUnknown() {
+		return boolValue.ValueBool()
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConflictingAttributesValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		clientServerURL   tftypes.Value
+		discoverWellKnown tftypes.Value
+		expectError       bool
+	}{
+		"none": {
+			clientServerURL:   tftypes.NewValue(tftypes.String, nil),
+			discoverWellKnown: tftypes.NewValue(tftypes.Bool, nil),
+		},
+		"url": {
+			clientServerURL:   tftypes.NewValue(tftypes.String, "https://matrix.example.com"),
+			discoverWellKnown: tftypes.NewValue(tftypes.Bool, nil),
+		},
+		"discovery": {
+			clientServerURL:   tftypes.NewValue(tftypes.String, nil),
+			discoverWellKnown: tftypes.NewValue(tftypes.Bool, true),
+		},
+		"url-discovery-disabled": {
+			clientServerURL:   tftypes.NewValue(tftypes.String, "https://matrix.example.com"),
+			discoverWellKnown: tftypes.NewValue(tftypes.Bool, false),
+		},
+		"both": {
+			clientServerURL:   tftypes.NewValue(tftypes.String, "https://matrix.example.com"),
+			discoverWellKnown: tftypes.NewValue(tftypes.Bool, true),
+			expectError:       true,
+		},
+		"unknown-url": {
+			clientServerURL:   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			discoverWellKnown: tftypes.NewValue(tftypes.Bool, true),
+			expectError:       true,
+		},
+	}
+
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := provider.ValidateConfigRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw: tftypes.NewValue(configType, map[string]tftypes.Value{
+						"client_server_url":     testCase.clientServerURL,
+						"default_access_token":  tftypes.NewValue(tftypes.String, nil),
+						"default_user_id":       tftypes.NewValue(tftypes.String, nil),
+						"discover_well_known":   testCase.discoverWellKnown,
+						"prevent_destroy_rooms": tftypes.NewValue(tftypes.Bool, nil),
+					}),
+				},
+			}
+			resp := &provider.ValidateConfigResponse{}
+
+			conflictingAttributesValidator{attributes: []string{"client_server_url", "discover_well_known"}}.ValidateProvider(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
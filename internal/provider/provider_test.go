@@ -4,10 +4,15 @@
 package provider
 
 import (
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/matrix-org/gomatrix"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -19,7 +24,134 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// The provider is configured entirely through the environment during
+	// acceptance testing. The configured user must be a server admin, as
+	// most resources use the Synapse admin API.
+	for _, env := range []string{"MATRIX_CLIENT_SERVER_URL", "MATRIX_DEFAULT_ACCESS_TOKEN", "MATRIX_DEFAULT_USERID"} {
+		if os.Getenv(env) == "" {
+			t.Fatalf("%s must be set for acceptance tests", env)
+		}
+	}
 }
+
+// testAccClient returns a Matrix client for the test homeserver, which can be
+// used to prepare fixtures that the resource under test does not create.
+func testAccClient(t *testing.T) *gomatrix.Client {
+	client, err := gomatrix.NewClient(
+		os.Getenv("MATRIX_CLIENT_SERVER_URL"),
+		os.Getenv("MATRIX_DEFAULT_USERID"),
+		os.Getenv("MATRIX_DEFAULT_ACCESS_TOKEN"),
+	)
+	if err != nil {
+		t.Fatalf("unable to create Matrix client: %s", err)
+	}
+
+	return client
+}
+
+// testAccCreateUser registers a throwaway user on the test homeserver through
+// the Synapse admin API and returns its user ID.
+func testAccCreateUser(t *testing.T, localpart string) string {
+	client := testAccClient(t)
+	userID := "@" + localpart + ":" + testAccServerName()
+
+	err := client.MakeRequest("PUT", synapseAdminURL(client, "v2", "users", userID), map[string]any{
+		"password": localpart + "-password",
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create test user %s: %s", userID, err)
+	}
+
+	return userID
+}
+
+// testAccServerName returns the server name of the test homeserver, taken
+// from the configured default user ID.
+func testAccServerName() string {
+	return strings.SplitN(os.Getenv("MATRIX_DEFAULT_USERID"), ":", 2)[1]
+}
+
+// testAccCreateRoom creates a private room owned by the provider user and
+// returns its room ID.
+func testAccCreateRoom(t *testing.T) string {
+	room, err := testAccClient(t).CreateRoom(&gomatrix.ReqCreateRoom{Preset: "private_chat"})
+	if err != nil {
+		t.Fatalf("unable to create test room: %s", err)
+	}
+
+	return room.RoomID
+}
+
+// testAccSendMessage sends a text message as the provider user and returns
+// its event ID.
+func testAccSendMessage(t *testing.T, roomID string, body string) string {
+	resp, err := testAccClient(t).SendText(roomID, body)
+	if err != nil {
+		t.Fatalf("unable to send test message: %s", err)
+	}
+
+	return resp.EventID
+}
+
+// testAccReportEvent reports an event as the provider user and returns the
+// ID of the new event report.
+func testAccReportEvent(t *testing.T, roomID string, eventID string) int64 {
+	client := testAccClient(t)
+
+	err := client.MakeRequest("POST", client.BuildURL("rooms", roomID, "report", eventID), map[string]any{
+		"reason": "tf-acc-report",
+		"score":  -100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to report test event: %s", err)
+	}
+
+	var reportsResp struct {
+		EventReports []synapseEventReport `json:"event_reports"`
+	}
+	err = client.MakeRequest("GET", synapseAdminURL(client, "v1", "event_reports")+"?room_id="+url.QueryEscape(roomID), nil, &reportsResp)
+	if err != nil {
+		t.Fatalf("unable to list test event reports: %s", err)
+	}
+
+	for _, report := range reportsResp.EventReports {
+		if report.EventID == eventID {
+			return report.Id
+		}
+	}
+
+	t.Fatalf("event report for %s not found", eventID)
+	return 0
+}
+
+func TestAccProviderAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderAliasesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room.default", "room_id"),
+					resource.TestCheckResourceAttrSet("matrix_room.aliased", "room_id"),
+				),
+			},
+		},
+	})
+}
+
+// Both provider blocks are configured from the environment, the test only
+// ensures aliased configurations work side by side.
+const testAccProviderAliasesConfig = `
+provider "matrix" {}
+
+provider "matrix" {
+  alias = "second"
+}
+
+resource "matrix_room" "default" {}
+
+resource "matrix_room" "aliased" {
+  provider = matrix.second
+}
+`
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PublicRoomsDataSource{}
+
+func NewPublicRoomsDataSource() datasource.DataSource {
+	return &PublicRoomsDataSource{}
+}
+
+// PublicRoomsDataSource defines the data source implementation.
+type PublicRoomsDataSource struct {
+	client *gomatrix.Client
+}
+
+// PublicRoomsDataSourceModel describes the data source data model.
+type PublicRoomsDataSourceModel struct {
+	GenericSearchTerm      types.String             `tfsdk:"generic_search_term"`
+	Limit                  types.Int64              `tfsdk:"limit"`
+	Since                  types.String             `tfsdk:"since"`
+	MaxRooms               types.Int64              `tfsdk:"max_rooms"`
+	Rooms                  []PublicRoomSummaryModel `tfsdk:"rooms"`
+	TotalRoomCountEstimate types.Int64              `tfsdk:"total_room_count_estimate"`
+	NextBatch              types.String             `tfsdk:"next_batch"`
+	Id                     types.String             `tfsdk:"id"`
+}
+
+// PublicRoomSummaryModel describes a single room of the public room directory.
+type PublicRoomSummaryModel struct {
+	RoomID           types.String `tfsdk:"room_id"`
+	Name             types.String `tfsdk:"name"`
+	Topic            types.String `tfsdk:"topic"`
+	NumJoinedMembers types.Int64  `tfsdk:"num_joined_members"`
+	CanonicalAlias   types.String `tfsdk:"canonical_alias"`
+	AvatarURL        types.String `tfsdk:"avatar_url"`
+	JoinRule         types.String `tfsdk:"join_rule"`
+	WorldReadable    types.Bool   `tfsdk:"world_readable"`
+	GuestCanJoin     types.Bool   `tfsdk:"guest_can_join"`
+}
+
+// publicRoomsRequest is the request body of the public rooms endpoint.
+type publicRoomsRequest struct {
+	Limit  int64  `json:"limit,omitempty"`
+	Since  string `json:"since,omitempty"`
+	Filter *struct {
+		GenericSearchTerm string `json:"generic_search_term,omitempty"`
+	} `json:"filter,omitempty"`
+}
+
+// publicRoomsResponse is a page of the public room directory.
+type publicRoomsResponse struct {
+	Chunk []struct {
+		RoomID           string `json:"room_id"`
+		Name             string `json:"name"`
+		Topic            string `json:"topic"`
+		NumJoinedMembers int64  `json:"num_joined_members"`
+		CanonicalAlias   string `json:"canonical_alias"`
+		AvatarURL        string `json:"avatar_url"`
+		JoinRule         string `json:"join_rule"`
+		WorldReadable    bool   `json:"world_readable"`
+		GuestCanJoin     bool   `json:"guest_can_join"`
+	} `json:"chunk"`
+	NextBatch              string `json:"next_batch"`
+	TotalRoomCountEstimate int64  `json:"total_room_count_estimate"`
+}
+
+// defaultMaxPublicRooms caps how many rooms are fetched if max_rooms is unset.
+const defaultMaxPublicRooms = 1000
+
+func (d *PublicRoomsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_rooms"
+}
+
+func (d *PublicRoomsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the rooms in the public room directory of the homeserver. " +
+			"All pages are fetched until `max_rooms` rooms were returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"generic_search_term": schema.StringAttribute{
+				MarkdownDescription: "Only return rooms whose name, topic or alias contains this term.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The number of rooms to request per page. Defaults to the homeserver default.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"since": schema.StringAttribute{
+				MarkdownDescription: "A pagination token to start from, e.g. the `next_batch` of another lookup.",
+				Optional:            true,
+			},
+			"max_rooms": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of rooms to return. Defaults to `%d`.", defaultMaxPublicRooms),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rooms": schema.ListNestedAttribute{
+				MarkdownDescription: "The rooms in the directory, in the order returned by the homeserver.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"room_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the room.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the room, empty if it has none.",
+							Computed:            true,
+						},
+						"topic": schema.StringAttribute{
+							MarkdownDescription: "The topic of the room, empty if it has none.",
+							Computed:            true,
+						},
+						"num_joined_members": schema.Int64Attribute{
+							MarkdownDescription: "The number of members joined to the room.",
+							Computed:            true,
+						},
+						"canonical_alias": schema.StringAttribute{
+							MarkdownDescription: "The canonical alias of the room, empty if it has none.",
+							Computed:            true,
+						},
+						"avatar_url": schema.StringAttribute{
+							MarkdownDescription: "The MXC URI of the room avatar, empty if it has none.",
+							Computed:            true,
+						},
+						"join_rule": schema.StringAttribute{
+							MarkdownDescription: "The join rule of the room, e.g. `public` or `knock`.",
+							Computed:            true,
+						},
+						"world_readable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the room history can be read without joining.",
+							Computed:            true,
+						},
+						"guest_can_join": schema.BoolAttribute{
+							MarkdownDescription: "Whether guest users may join the room.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"total_room_count_estimate": schema.Int64Attribute{
+				MarkdownDescription: "The estimate of the homeserver for the total number of rooms in the directory.",
+				Computed:            true,
+			},
+			"next_batch": schema.StringAttribute{
+				MarkdownDescription: "A pagination token for the rooms after the returned ones, empty if all rooms were returned.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PublicRoomsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *PublicRoomsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PublicRoomsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxRooms := int64(defaultMaxPublicRooms)
+	if !data.MaxRooms.IsNull() {
+		maxRooms = data.MaxRooms.ValueInt64()
+	}
+
+	reqBody := publicRoomsRequest{
+		Since: data.Since.ValueString(),
+	}
+	if term := data.GenericSearchTerm.ValueString(); term != "" {
+		reqBody.Filter = &struct {
+			GenericSearchTerm string `json:"generic_search_term,omitempty"`
+		}{GenericSearchTerm: term}
+	}
+
+	data.Rooms = []PublicRoomSummaryModel{}
+
+	for {
+		// Never request more rooms than are still missing, so next_batch
+		// always points right behind the last returned room.
+		reqBody.Limit = maxRooms - int64(len(data.Rooms))
+		if !data.Limit.IsNull() && data.Limit.ValueInt64() < reqBody.Limit {
+			reqBody.Limit = data.Limit.ValueInt64()
+		}
+
+		var page publicRoomsResponse
+		err := d.client.MakeRequest("POST", d.client.BuildURL("publicRooms"), reqBody, &page)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read public rooms, got error: %s", err))
+			return
+		}
+
+		for _, room := range page.Chunk {
+			data.Rooms = append(data.Rooms, PublicRoomSummaryModel{
+				RoomID:           types.StringValue(room.RoomID),
+				Name:             types.StringValue(room.Name),
+				Topic:            types.StringValue(room.Topic),
+				NumJoinedMembers: types.Int64Value(room.NumJoinedMembers),
+				CanonicalAlias:   types.StringValue(room.CanonicalAlias),
+				AvatarURL:        types.StringValue(room.AvatarURL),
+				JoinRule:         types.StringValue(room.JoinRule),
+				WorldReadable:    types.BoolValue(room.WorldReadable),
+				GuestCanJoin:     types.BoolValue(room.GuestCanJoin),
+			})
+		}
+
+		data.TotalRoomCountEstimate = types.Int64Value(page.TotalRoomCountEstimate)
+		data.NextBatch = types.StringValue(page.NextBatch)
+
+		if page.NextBatch == "" || len(page.Chunk) == 0 || int64(len(data.Rooms)) >= maxRooms {
+			break
+		}
+
+		reqBody.Since = page.NextBatch
+	}
+
+	data.Id = types.StringValue("public_rooms")
+
+	tflog.Trace(ctx, "read public rooms", map[string]any{"rooms": len(data.Rooms)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestAccPublicRoomsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			for _, name := range []string{"tf-acc-public-a", "tf-acc-public-b"} {
+				_, err := testAccClient(t).CreateRoom(&gomatrix.ReqCreateRoom{
+					Preset:     "public_chat",
+					Visibility: "public",
+					Name:       name,
+				})
+				if err != nil {
+					t.Fatalf("unable to create public test room: %s", err)
+				}
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing across pages
+			{
+				Config: testAccPublicRoomsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_public_rooms.test", "rooms.#", "2"),
+					resource.TestCheckResourceAttr("data.matrix_public_rooms.test", "rooms.0.join_rule", "public"),
+					resource.TestCheckResourceAttrSet("data.matrix_public_rooms.test", "total_room_count_estimate"),
+				),
+			},
+			// max_rooms caps the result
+			{
+				Config: testAccPublicRoomsDataSourceConfigMaxRooms,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_public_rooms.test", "rooms.#", "1"),
+					resource.TestCheckResourceAttrSet("data.matrix_public_rooms.test", "next_batch"),
+				),
+			},
+		},
+	})
+}
+
+const testAccPublicRoomsDataSourceConfig = `
+data "matrix_public_rooms" "test" {
+  generic_search_term = "tf-acc-public"
+  limit               = 1
+}
+`
+
+const testAccPublicRoomsDataSourceConfigMaxRooms = `
+data "matrix_public_rooms" "test" {
+  generic_search_term = "tf-acc-public"
+  max_rooms           = 1
+}
+`
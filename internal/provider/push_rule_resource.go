@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PushRuleResource{}
+var _ resource.ResourceWithImportState = &PushRuleResource{}
+var _ resource.ResourceWithValidateConfig = &PushRuleResource{}
+var _ resource.ResourceWithConfigValidators = &PushRuleResource{}
+
+// pushRuleKinds are the kinds of push rules, in the order the homeserver
+// evaluates them.
+var pushRuleKinds = []string{"override", "content", "room", "sender", "underride"}
+
+// userPushRuleIDRegexp rejects the IDs of server-default rules, which start
+// with a dot and cannot be created or deleted.
+var userPushRuleIDRegexp = regexp.MustCompile(`^[^.]`)
+
+func NewPushRuleResource() resource.Resource {
+	return &PushRuleResource{}
+}
+
+// PushRuleResource defines the resource implementation.
+type PushRuleResource struct {
+	client *gomatrix.Client
+}
+
+// PushRuleResourceModel describes the resource data model.
+type PushRuleResourceModel struct {
+	Kind           types.String         `tfsdk:"kind"`
+	RuleID         types.String         `tfsdk:"rule_id"`
+	ConditionsJSON jsontypes.Normalized `tfsdk:"conditions_json"`
+	Pattern        types.String         `tfsdk:"pattern"`
+	ActionsJSON    jsontypes.Normalized `tfsdk:"actions_json"`
+	Enabled        types.Bool           `tfsdk:"enabled"`
+	Before         types.String         `tfsdk:"before"`
+	After          types.String         `tfsdk:"after"`
+	Id             types.String         `tfsdk:"id"`
+}
+
+// pushRule is a push rule as returned by the homeserver.
+type pushRule struct {
+	Actions    json.RawMessage `json:"actions"`
+	Conditions json.RawMessage `json:"conditions,omitempty"`
+	Pattern    *string         `json:"pattern,omitempty"`
+	Enabled    bool            `json:"enabled"`
+}
+
+// pushRuleURL builds the URL of a push rule of the provider user, or of one of
+// its attributes such as "enabled".
+func (r *PushRuleResource) pushRuleURL(data PushRuleResourceModel, attribute ...string) string {
+	return r.client.BuildURL(append([]string{"pushrules", "global", data.Kind.ValueString(), data.RuleID.ValueString()}, attribute...)...)
+}
+
+func (r *PushRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_push_rule"
+}
+
+func (r *PushRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a push rule of the provider user, which decides when the user is notified, e.g. to " +
+			"configure the notifications of a bot or bridge account. Changes made in a client show up as drift and are " +
+			"reverted by the next apply. Destroying the resource deletes the push rule.\n\n" +
+			"Use `matrix_room_notification_level` to simply mute or unmute a room. Server-default rules, whose IDs start " +
+			"with a dot, cannot be managed by this resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "The kind of the rule, one of `override`, `content`, `room`, `sender` or `underride`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(pushRuleKinds...),
+				},
+			},
+			"rule_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the rule. For `room` rules the room ID and for `sender` rules the user ID it applies to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(userPushRuleIDRegexp, "value must not start with a dot, which is reserved for server-default rules"),
+				},
+			},
+			"conditions_json": schema.StringAttribute{
+				MarkdownDescription: "The conditions of an `override` or `underride` rule as JSON array, e.g. from `jsonencode`. " +
+					"All conditions must match for the rule to apply.",
+				CustomType: jsontypes.NormalizedType{},
+				Optional:   true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: "The glob pattern the body of a message must match, required for `content` rules.",
+				Optional:            true,
+			},
+			"actions_json": schema.StringAttribute{
+				MarkdownDescription: "The actions of the rule as JSON array, e.g. " +
+					"`jsonencode([\"notify\", { set_tweak = \"sound\", value = \"default\" }])`. Use `[]` to not notify.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the rule is enabled. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"before": schema.StringAttribute{
+				MarkdownDescription: "The ID of a rule of the same kind which this rule is placed before. Rules are evaluated " +
+					"in order, so this decides which rule wins. Only used when the rule is written, conflicts with `after`.",
+				Optional: true,
+			},
+			"after": schema.StringAttribute{
+				MarkdownDescription: "The ID of a rule of the same kind which this rule is placed after. " +
+					"Only used when the rule is written, conflicts with `before`.",
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `kind/rule_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PushRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("before"),
+			path.MatchRoot("after"),
+		),
+	}
+}
+
+func (r *PushRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PushRuleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value jsontypes.Normalized
+	}{
+		{name: "conditions_json", value: data.ConditionsJSON},
+		{name: "actions_json", value: data.ActionsJSON},
+	} {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+
+		// Invalid JSON is reported by the custom type.
+		var array []json.RawMessage
+		content := []byte(attribute.value.ValueString())
+		if json.Valid(content) && json.Unmarshal(content, &array) != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid Push Rule", fmt.Sprintf("%s must be a JSON array.", attribute.name))
+		}
+	}
+
+	if data.Kind.IsUnknown() {
+		return
+	}
+
+	kind := data.Kind.ValueString()
+
+	if !data.ConditionsJSON.IsNull() && kind != "override" && kind != "underride" {
+		resp.Diagnostics.AddAttributeError(path.Root("conditions_json"), "Invalid Push Rule",
+			fmt.Sprintf("Only override and underride rules have conditions, %s rules match by their kind.", kind))
+	}
+
+	if kind == "content" && data.Pattern.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("pattern"), "Invalid Push Rule", "Content rules require a pattern.")
+	}
+	if kind != "content" && !data.Pattern.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("pattern"), "Invalid Push Rule", "Only content rules have a pattern.")
+	}
+
+	if data.RuleID.IsUnknown() {
+		return
+	}
+
+	// Room and sender rules are named after what they match.
+	var ruleIDValidator validator.String
+	switch kind {
+	case "room":
+		ruleIDValidator = validators.MatrixRoomID()
+	case "sender":
+		ruleIDValidator = validators.MatrixUserID()
+	default:
+		return
+	}
+
+	ruleIDResp := &validator.StringResponse{}
+	ruleIDValidator.ValidateString(ctx, validator.StringRequest{
+		Path:        path.Root("rule_id"),
+		ConfigValue: data.RuleID,
+	}, ruleIDResp)
+	resp.Diagnostics.Append(ruleIDResp.Diagnostics...)
+}
+
+func (r *PushRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// put creates or replaces the push rule and sets whether it is enabled.
+func (r *PushRuleResource) put(data PushRuleResourceModel) error {
+	body := map[string]any{
+		"actions": json.RawMessage(data.ActionsJSON.ValueString()),
+	}
+	if !data.ConditionsJSON.IsNull() {
+		body["conditions"] = json.RawMessage(data.ConditionsJSON.ValueString())
+	}
+	if !data.Pattern.IsNull() {
+		body["pattern"] = data.Pattern.ValueString()
+	}
+
+	ruleURL := r.pushRuleURL(data)
+	query := url.Values{}
+	if !data.Before.IsNull() {
+		query.Set("before", data.Before.ValueString())
+	}
+	if !data.After.IsNull() {
+		query.Set("after", data.After.ValueString())
+	}
+	if len(query) > 0 {
+		ruleURL += "?" + query.Encode()
+	}
+
+	err := r.client.MakeRequest("PUT", ruleURL, body, nil)
+	if err != nil {
+		return err
+	}
+
+	// New rules are enabled, and replacing a rule keeps its enabled state.
+	return r.client.MakeRequest("PUT", r.pushRuleURL(data, "enabled"), map[string]bool{
+		"enabled": data.Enabled.ValueBool(),
+	}, nil)
+}
+
+func (r *PushRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PushRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.put(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set push rule, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.Kind.ValueString() + "/" + data.RuleID.ValueString())
+
+	tflog.Trace(ctx, "set push rule", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PushRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PushRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rule pushRule
+	err := r.client.MakeRequest("GET", r.pushRuleURL(data), nil, &rule)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "push rule no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read push rule, got error: %s", err))
+		return
+	}
+
+	// Semantic equality keeps the configured formatting unless the rule
+	// really changed.
+	data.ActionsJSON = jsontypes.NewNormalizedValue(string(rule.Actions))
+
+	// Homeservers may return empty conditions for rules created without.
+	if len(rule.Conditions) > 0 && !(data.ConditionsJSON.IsNull() && jsonEqual(rule.Conditions, []byte("[]"))) {
+		data.ConditionsJSON = jsontypes.NewNormalizedValue(string(rule.Conditions))
+	}
+
+	data.Pattern = types.StringPointerValue(rule.Pattern)
+	data.Enabled = types.BoolValue(rule.Enabled)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PushRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PushRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.put(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set push rule, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PushRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PushRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("DELETE", r.pushRuleURL(data), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete push rule, got error: %s", err))
+		return
+	}
+}
+
+func (r *PushRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "kind", "rule_id")
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPushRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: `
+resource "matrix_push_rule" "test" {
+  kind         = "content"
+  rule_id      = "tf-acc-deploy"
+  actions_json = jsonencode(["notify"])
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Content rules require a pattern"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPushRuleResourceConfig(`["notify"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_push_rule.test", "id", "override/tf-acc-deploy"),
+					resource.TestCheckResourceAttr("matrix_push_rule.test", "actions_json", `["notify"]`),
+					resource.TestCheckResourceAttr("matrix_push_rule.test", "enabled", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_push_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPushRuleResourceConfig(`["notify", { set_tweak = "highlight" }]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_push_rule.test", "actions_json", `["notify",{"set_tweak":"highlight"}]`),
+					resource.TestCheckResourceAttr("matrix_push_rule.test", "enabled", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPushRuleResourceConfig(actions string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "matrix_push_rule" "test" {
+  kind    = "override"
+  rule_id = "tf-acc-deploy"
+  conditions_json = jsonencode([
+    { kind = "event_match", key = "content.body", pattern = "deploy*" },
+  ])
+  actions_json = jsonencode(%s)
+  enabled      = %t
+}
+`, actions, enabled)
+}
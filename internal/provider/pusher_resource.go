@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PusherResource{}
+var _ resource.ResourceWithImportState = &PusherResource{}
+
+// httpURLRegexp matches absolute HTTP(S) URLs, e.g. of push gateways.
+var httpURLRegexp = regexp.MustCompile(`^https?://[^/?#\s]+`)
+
+func NewPusherResource() resource.Resource {
+	return &PusherResource{}
+}
+
+// PusherResource defines the resource implementation.
+type PusherResource struct {
+	client *gomatrix.Client
+}
+
+// PusherResourceModel describes the resource data model.
+type PusherResourceModel struct {
+	AppID             types.String `tfsdk:"app_id"`
+	Pushkey           types.String `tfsdk:"pushkey"`
+	AppDisplayName    types.String `tfsdk:"app_display_name"`
+	DeviceDisplayName types.String `tfsdk:"device_display_name"`
+	URL               types.String `tfsdk:"url"`
+	Format            types.String `tfsdk:"format"`
+	Lang              types.String `tfsdk:"lang"`
+	ProfileTag        types.String `tfsdk:"profile_tag"`
+	Id                types.String `tfsdk:"id"`
+}
+
+// pusher is an HTTP pusher as used by the pushers API. Kind is a pointer, as
+// a null kind deletes the pusher.
+type pusher struct {
+	AppID             string     `json:"app_id"`
+	Pushkey           string     `json:"pushkey"`
+	Kind              *string    `json:"kind"`
+	AppDisplayName    string     `json:"app_display_name,omitempty"`
+	DeviceDisplayName string     `json:"device_display_name,omitempty"`
+	Lang              string     `json:"lang,omitempty"`
+	ProfileTag        string     `json:"profile_tag,omitempty"`
+	Data              pusherData `json:"data"`
+}
+
+// pusherData tells the homeserver how to reach the push gateway.
+type pusherData struct {
+	URL    string `json:"url,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+func (r *PusherResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pusher"
+}
+
+func (r *PusherResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an HTTP pusher of the provider user, which makes the homeserver send notifications " +
+			"to a push gateway such as Sygnal, e.g. to wire a monitoring account into an alerting pipeline. " +
+			"Destroying the resource removes the pusher.\n\n" +
+			"Homeservers may remove pushers when the access token they were created with is logged out.",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the application, in reverse-DNS style, e.g. `com.example.alerts`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+			},
+			"pushkey": schema.StringAttribute{
+				MarkdownDescription: "The key identifying the device at the push gateway, at most 512 bytes long.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_display_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the application, shown to the user in clients.",
+				Required:            true,
+			},
+			"device_display_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the device, shown to the user in clients.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL notifications are sent to, e.g. `https://push.example.com/_matrix/push/v1/notify`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpURLRegexp, "value must be an absolute http or https URL"),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The format of the notifications. Set to `event_id_only` to only send the IDs of events " +
+					"and rooms instead of their content.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("event_id_only"),
+				},
+			},
+			"lang": schema.StringAttribute{
+				MarkdownDescription: "The preferred language of the notifications. Defaults to `en`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("en"),
+			},
+			"profile_tag": schema.StringAttribute{
+				MarkdownDescription: "The tag of the device-specific push rules set to apply to this pusher.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `app_id/pushkey`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PusherResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// set creates the pusher, or replaces the one with the same app ID and
+// pushkey.
+func (r *PusherResource) set(data PusherResourceModel) error {
+	kind := "http"
+	return r.client.MakeRequest("POST", r.client.BuildURL("pushers", "set"), struct {
+		pusher
+		Append bool `json:"append"`
+	}{
+		pusher: pusher{
+			AppID:             data.AppID.ValueString(),
+			Pushkey:           data.Pushkey.ValueString(),
+			Kind:              &kind,
+			AppDisplayName:    data.AppDisplayName.ValueString(),
+			DeviceDisplayName: data.DeviceDisplayName.ValueString(),
+			Lang:              data.Lang.ValueString(),
+			ProfileTag:        data.ProfileTag.ValueString(),
+			Data: pusherData{
+				URL:    data.URL.ValueString(),
+				Format: data.Format.ValueString(),
+			},
+		},
+	}, nil)
+}
+
+func (r *PusherResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PusherResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.set(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set pusher, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.AppID.ValueString() + "/" + data.Pushkey.ValueString())
+
+	tflog.Trace(ctx, "set pusher", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PusherResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PusherResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var pushers struct {
+		Pushers []pusher `json:"pushers"`
+	}
+	err := r.client.MakeRequest("GET", r.client.BuildURL("pushers"), nil, &pushers)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read pushers, got error: %s", err))
+		return
+	}
+
+	var found *pusher
+	for i, p := range pushers.Pushers {
+		if p.AppID == data.AppID.ValueString() && p.Pushkey == data.Pushkey.ValueString() {
+			found = &pushers.Pushers[i]
+			break
+		}
+	}
+
+	if found == nil {
+		tflog.Warn(ctx, "pusher no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.AppDisplayName = types.StringValue(found.AppDisplayName)
+	data.DeviceDisplayName = types.StringValue(found.DeviceDisplayName)
+	data.URL = types.StringValue(found.Data.URL)
+	data.Lang = types.StringValue(found.Lang)
+
+	// Empty optional fields are kept null.
+	if found.Data.Format != "" || !data.Format.IsNull() {
+		data.Format = types.StringValue(found.Data.Format)
+	}
+	if found.ProfileTag != "" || !data.ProfileTag.IsNull() {
+		data.ProfileTag = types.StringValue(found.ProfileTag)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PusherResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PusherResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.set(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set pusher, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PusherResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PusherResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A null kind removes the pusher.
+	err := r.client.MakeRequest("POST", r.client.BuildURL("pushers", "set"), pusher{
+		AppID:   data.AppID.ValueString(),
+		Pushkey: data.Pushkey.ValueString(),
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove pusher, got error: %s", err))
+		return
+	}
+}
+
+func (r *PusherResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "app_id", "pushkey")
+}
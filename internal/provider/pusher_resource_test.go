@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPusherResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPusherResourceConfig("Monitoring"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_pusher.test", "id", "com.example.tf-acc/tf-acc-pushkey"),
+					resource.TestCheckResourceAttr("matrix_pusher.test", "device_display_name", "Monitoring"),
+					resource.TestCheckResourceAttr("matrix_pusher.test", "lang", "en"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_pusher.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPusherResourceConfig("Alerting"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_pusher.test", "device_display_name", "Alerting"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPusherResourceConfig(deviceDisplayName string) string {
+	return fmt.Sprintf(`
+resource "matrix_pusher" "test" {
+  app_id              = "com.example.tf-acc"
+  pushkey             = "tf-acc-pushkey"
+  app_display_name    = "Terraform"
+  device_display_name = %q
+  url                 = "https://push.example.com/_matrix/push/v1/notify"
+  format              = "event_id_only"
+}
+`, deviceDisplayName)
+}
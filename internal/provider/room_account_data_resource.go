@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomAccountDataResource{}
+var _ resource.ResourceWithImportState = &RoomAccountDataResource{}
+
+func NewRoomAccountDataResource() resource.Resource {
+	return &RoomAccountDataResource{}
+}
+
+// RoomAccountDataResource defines the resource implementation.
+type RoomAccountDataResource struct {
+	client *gomatrix.Client
+}
+
+// RoomAccountDataResourceModel describes the resource data model.
+type RoomAccountDataResourceModel struct {
+	UserID types.String         `tfsdk:"user_id"`
+	RoomID types.String         `tfsdk:"room_id"`
+	Type   types.String         `tfsdk:"type"`
+	Data   jsontypes.Normalized `tfsdk:"data"`
+	Id     types.String         `tfsdk:"id"`
+}
+
+// accountDataURL builds the URL of an account data entry of a room.
+func (r *RoomAccountDataResource) accountDataURL(data RoomAccountDataResourceModel) string {
+	return r.client.BuildURL("user", data.UserID.ValueString(), "rooms", data.RoomID.ValueString(), "account_data", data.Type.ValueString())
+}
+
+func (r *RoomAccountDataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_account_data"
+}
+
+func (r *RoomAccountDataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Stores account data of the provider user scoped to a room, e.g. settings of a bot per room. " +
+			"Account data cannot be deleted, so destroying the resource replaces it with an empty object.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user owning the account data. Users can only access their own account data, " +
+					"so this defaults to and must be the provider user.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room the account data belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the account data, e.g. `org.example.bot.state`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"data": schema.StringAttribute{
+				MarkdownDescription: "The account data as JSON object, e.g. from `jsonencode`. " +
+					"Differences in key order or whitespace are ignored.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/room_id/type`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomAccountDataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomAccountDataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomAccountDataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsUnknown() {
+		data.UserID = types.StringValue(r.client.UserID)
+	}
+
+	err := r.client.MakeRequest("PUT", r.accountDataURL(data), json.RawMessage(data.Data.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room account data, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + data.RoomID.ValueString() + "/" + data.Type.ValueString())
+
+	tflog.Trace(ctx, "set room account data", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAccountDataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomAccountDataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content json.RawMessage
+	err := r.client.MakeRequest("GET", r.accountDataURL(data), nil, &content)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "room account data no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room account data, got error: %s", err))
+		return
+	}
+
+	// Destroying the resource leaves an empty object behind.
+	if jsonEqual(content, []byte("{}")) && !jsonEqual([]byte(data.Data.ValueString()), []byte("{}")) {
+		tflog.Warn(ctx, "room account data was cleared, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Semantic equality keeps the configured formatting unless the data
+	// really changed.
+	data.Data = jsontypes.NewNormalizedValue(string(content))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAccountDataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomAccountDataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("PUT", r.accountDataURL(data), json.RawMessage(data.Data.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room account data, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAccountDataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomAccountDataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("PUT", r.accountDataURL(data), map[string]any{}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear room account data, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomAccountDataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "user_id", "room_id", "type") == nil {
+		return
+	}
+
+	// Read replaces the placeholder with the current data.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), "{}")...)
+}
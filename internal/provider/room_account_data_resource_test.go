@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomAccountDataResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomAccountDataResourceConfig(`"[]"`),
+				ExpectError: regexp.MustCompile(`JSON object`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomAccountDataResourceConfig(`jsonencode({ last_event = "$first" })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_account_data.test", "type", "org.example.bot.state"),
+					resource.TestCheckResourceAttrSet("matrix_room_account_data.test", "user_id"),
+					resource.TestCheckResourceAttr("matrix_room_account_data.test", "data", `{"last_event":"$first"}`),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_account_data.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomAccountDataResourceConfig(`jsonencode({ last_event = "$second" })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_account_data.test", "data", `{"last_event":"$second"}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomAccountDataResourceConfig(data string) string {
+	return `
+variable "room_id" {}
+
+resource "matrix_room_account_data" "test" {
+  room_id = var.room_id
+  type    = "org.example.bot.state"
+  data    = ` + data + `
+}
+`
+}
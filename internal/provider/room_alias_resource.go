@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomAliasResource{}
+var _ resource.ResourceWithImportState = &RoomAliasResource{}
+
+func NewRoomAliasResource() resource.Resource {
+	return &RoomAliasResource{}
+}
+
+// RoomAliasResource defines the resource implementation.
+type RoomAliasResource struct {
+	client *gomatrix.Client
+}
+
+// RoomAliasResourceModel describes the resource data model.
+type RoomAliasResourceModel struct {
+	Alias  types.String `tfsdk:"alias"`
+	RoomID types.String `tfsdk:"room_id"`
+	Id     types.String `tfsdk:"id"`
+}
+
+// roomAlias is the room an alias points to.
+type roomAlias struct {
+	RoomID  string   `json:"room_id"`
+	Servers []string `json:"servers,omitempty"`
+}
+
+// getRoomAlias returns the room an alias points to, nil if the alias does not
+// exist.
+func getRoomAlias(client *gomatrix.Client, alias string) (*roomAlias, error) {
+	var resolved roomAlias
+	err := client.MakeRequest("GET", client.BuildURL("directory", "room", alias), nil, &resolved)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &resolved, nil
+}
+
+func (r *RoomAliasResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_alias"
+}
+
+func (r *RoomAliasResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Publishes a room alias on the homeserver of the provider user, e.g. `#lobby:example.com`, " +
+			"so the room can be joined by its alias. Destroying the resource deletes the alias.\n\n" +
+			"This does not change the canonical alias of the room, which is part of the room state.",
+
+		Attributes: map[string]schema.Attribute{
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "The room alias, whose server name must be the one of the homeserver.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomAlias(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room the alias points to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The room alias",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomAliasResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomAliasResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias := data.Alias.ValueString()
+
+	err := r.client.MakeRequest("PUT", r.client.BuildURL("directory", "room", alias), map[string]string{
+		"room_id": data.RoomID.ValueString(),
+	}, nil)
+	if err != nil {
+		if !isConflict(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create room alias, got error: %s", err))
+			return
+		}
+
+		// The alias already exists. Pointing to the configured room is fine,
+		// e.g. after the state was lost, anything else needs a decision.
+		existing, getErr := getRoomAlias(r.client, alias)
+		if getErr != nil || existing == nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create room alias, got error: %s", err))
+			return
+		}
+
+		if existing.RoomID != data.RoomID.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("alias"),
+				"Room Alias Conflict",
+				fmt.Sprintf("The alias %s already points to %s instead of %s. Delete the alias first or choose another one.", alias, existing.RoomID, data.RoomID.ValueString()),
+			)
+			return
+		}
+	}
+
+	data.Id = data.Alias
+
+	tflog.Trace(ctx, "created room alias", map[string]any{"alias": alias, "room_id": data.RoomID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomAliasResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolved, err := getRoomAlias(r.client, data.Alias.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room alias, got error: %s", err))
+		return
+	}
+
+	if resolved == nil {
+		tflog.Warn(ctx, "room alias no longer exists, removing from state", map[string]any{"alias": data.Alias.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// An alias pointing to another room shows up as drift and is replaced.
+	data.RoomID = types.StringValue(resolved.RoomID)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomAliasResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomAliasResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("DELETE", r.client.BuildURL("directory", "room", data.Alias.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete room alias, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomAliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "alias")
+}
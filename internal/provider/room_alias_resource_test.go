@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomAliasResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_server_name", testAccServerName())
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomAliasResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room_alias.test", "room_id", "matrix_room.first", "room_id"),
+					resource.TestCheckResourceAttrPair("matrix_room_alias.test", "id", "matrix_room_alias.test", "alias"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// An alias pointing to another room is not taken over
+			{
+				Config:      testAccRoomAliasResourceConfig + testAccRoomAliasResourceConfigConflict,
+				ExpectError: regexp.MustCompile(`Room Alias Conflict`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccRoomAliasResourceConfig = `
+variable "server_name" {}
+
+resource "matrix_room" "first" {}
+
+resource "matrix_room" "second" {}
+
+resource "matrix_room_alias" "test" {
+  alias   = "#tf-acc-alias:${var.server_name}"
+  room_id = matrix_room.first.room_id
+}
+`
+
+const testAccRoomAliasResourceConfigConflict = `
+resource "matrix_room_alias" "conflict" {
+  alias   = matrix_room_alias.test.alias
+  room_id = matrix_room.second.room_id
+}
+`
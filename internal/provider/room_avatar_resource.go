@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomAvatarResource{}
+var _ resource.ResourceWithImportState = &RoomAvatarResource{}
+var _ resource.ResourceWithConfigValidators = &RoomAvatarResource{}
+var _ resource.ResourceWithModifyPlan = &RoomAvatarResource{}
+
+func NewRoomAvatarResource() resource.Resource {
+	return &RoomAvatarResource{}
+}
+
+// RoomAvatarResource defines the resource implementation.
+type RoomAvatarResource struct {
+	client *gomatrix.Client
+}
+
+// RoomAvatarResourceModel describes the resource data model.
+type RoomAvatarResourceModel struct {
+	RoomID       types.String `tfsdk:"room_id"`
+	Source       types.String `tfsdk:"source"`
+	SourceSHA256 types.String `tfsdk:"source_sha256"`
+	URL          types.String `tfsdk:"url"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// roomAvatarContent is the content of the m.room.avatar state event.
+type roomAvatarContent struct {
+	URL  string          `json:"url"`
+	Info *roomAvatarInfo `json:"info,omitempty"`
+}
+
+// roomAvatarInfo describes the uploaded image, so clients can decide whether
+// to download it.
+type roomAvatarInfo struct {
+	Mimetype string `json:"mimetype"`
+	Size     int64  `json:"size"`
+}
+
+func (r *RoomAvatarResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_avatar"
+}
+
+func (r *RoomAvatarResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.avatar` state event of a room. The avatar is either a local image, " +
+			"which is uploaded to the content repository of the homeserver, or an existing `mxc://` URI. " +
+			"Local images are uploaded again whenever their content changes, or when the avatar was changed in a client. " +
+			"Destroying the resource removes the avatar, uploaded media stays in the content repository.\n\n" +
+			"The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The path of a local image to upload, e.g. `\"${path.module}/avatar.png\"`. " +
+					"Exactly one of `source` and `url` must be set.",
+				Optional: true,
+			},
+			"source_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the uploaded `source` file.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The `mxc://` URI of the avatar. Set it to use media that is already uploaded, " +
+					"otherwise it is the URI of the uploaded `source`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					validators.MxcURI(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomAvatarResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("source"),
+			path.MatchRoot("url"),
+		),
+	}
+}
+
+func (r *RoomAvatarResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomAvatarResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to upload on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RoomAvatarResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A configured url is used as it is.
+	if plan.Source.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_sha256"), types.StringNull())...)
+		return
+	}
+
+	if plan.Source.IsUnknown() {
+		return
+	}
+
+	// Hash the file at plan time, so changed images show up as a diff.
+	file, err := readContentFile(plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Unable to Read Avatar", fmt.Sprintf("Unable to read %s, got error: %s", plan.Source.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_sha256"), file.sha256)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The uploaded media is still current, keep using it.
+		if state.SourceSHA256.ValueString() == file.sha256 {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), state.URL)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), types.StringUnknown())...)
+}
+
+// upload uploads the source file to the content repository.
+func (r *RoomAvatarResource) upload(data *RoomAvatarResourceModel) (*roomAvatarInfo, error) {
+	file, err := readContentFile(data.Source.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	contentURI, err := file.upload(r.client, file.contentType, filepath.Base(data.Source.ValueString()))
+	if err != nil {
+		return nil, err
+	}
+
+	data.URL = types.StringValue(contentURI)
+	data.SourceSHA256 = types.StringValue(file.sha256)
+
+	return &roomAvatarInfo{Mimetype: file.contentType, Size: file.size}, nil
+}
+
+// apply uploads the source file if needed and sends the m.room.avatar state
+// event.
+func (r *RoomAvatarResource) apply(ctx context.Context, data *RoomAvatarResourceModel) error {
+	var info *roomAvatarInfo
+	if !data.Source.IsNull() && data.URL.IsUnknown() {
+		var err error
+		info, err = r.upload(data)
+		if err != nil {
+			return fmt.Errorf("unable to upload avatar: %w", err)
+		}
+
+		tflog.Trace(ctx, "uploaded avatar", map[string]any{"source": data.Source.ValueString(), "url": data.URL.ValueString()})
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.avatar", "", roomAvatarContent{
+		URL:  data.URL.ValueString(),
+		Info: info,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to send m.room.avatar state event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RoomAvatarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomAvatarResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apply(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room avatar, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set room avatar", map[string]any{"room_id": data.RoomID.ValueString(), "url": data.URL.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAvatarResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomAvatarResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url, err := getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.avatar", "url")
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.avatar state event, got error: %s", err))
+		return
+	}
+
+	// Forget the hash if the avatar was changed elsewhere, so the next plan
+	// uploads the source again.
+	if !url.Equal(data.URL) {
+		data.SourceSHA256 = types.StringNull()
+	}
+	data.URL = url
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAvatarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomAvatarResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apply(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room avatar, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomAvatarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomAvatarResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted, empty content removes the avatar.
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.avatar", "url", types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove room avatar, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomAvatarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccRoomAvatarResource(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "avatar.png")
+	testAccWriteFile(t, source, "first avatar")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomAvatarResourceConfigSource(source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("matrix_room_avatar.test", "url", regexp.MustCompile(`^mxc://`)),
+					resource.TestCheckResourceAttrSet("matrix_room_avatar.test", "source_sha256"),
+					resource.TestCheckResourceAttrPair("matrix_room_avatar.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_room_avatar.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "source_sha256"},
+			},
+			// Update testing uploads a changed file again
+			{
+				PreConfig: func() { testAccWriteFile(t, source, "second avatar") },
+				Config:    testAccRoomAvatarResourceConfigSource(source),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_room_avatar.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("matrix_room_avatar.test", tfjsonpath.New("url")),
+					},
+				},
+			},
+			// Switching to an existing URI does not upload anything
+			{
+				Config: testAccRoomAvatarResourceConfigURL,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_avatar.test", "url", "mxc://example.com/avatar"),
+					resource.TestCheckNoResourceAttr("matrix_room_avatar.test", "source_sha256"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccWriteFile writes a fixture file that a configuration reads.
+func testAccWriteFile(t *testing.T, name string, content string) {
+	err := os.WriteFile(name, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("unable to write %s: %s", name, err)
+	}
+}
+
+func testAccRoomAvatarResourceConfigSource(source string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_avatar" "test" {
+  room_id = matrix_room.test.room_id
+  source  = %q
+}
+`, source)
+}
+
+const testAccRoomAvatarResourceConfigURL = `
+resource "matrix_room" "test" {}
+
+resource "matrix_room_avatar" "test" {
+  room_id = matrix_room.test.room_id
+  url     = "mxc://example.com/avatar"
+}
+`
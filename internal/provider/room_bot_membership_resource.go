@@ -0,0 +1,388 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomBotMembershipResource{}
+var _ resource.ResourceWithImportState = &RoomBotMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &RoomBotMembershipResource{}
+
+func NewRoomBotMembershipResource() resource.Resource {
+	return &RoomBotMembershipResource{}
+}
+
+// RoomBotMembershipResource defines the resource implementation.
+type RoomBotMembershipResource struct {
+	client *gomatrix.Client
+}
+
+// RoomBotMembershipResourceModel describes the resource data model.
+type RoomBotMembershipResourceModel struct {
+	UserID            types.String   `tfsdk:"user_id"`
+	Password          types.String   `tfsdk:"password"`
+	PasswordWoVersion types.Int64    `tfsdk:"password_wo_version"`
+	RoomIDs           []types.String `tfsdk:"room_ids"`
+	DisplayName       types.String   `tfsdk:"display_name"`
+	Admin             types.Bool     `tfsdk:"admin"`
+	Id                types.String   `tfsdk:"id"`
+}
+
+func (r *RoomBotMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_bot_membership"
+}
+
+func (r *RoomBotMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a local bot account using the Synapse admin API and invites it to a set of rooms. " +
+			"Existing accounts are not taken over, import them instead. " +
+			"Rooms removed from `room_ids` kick the bot, so the provider user needs permission to invite and kick in every room. " +
+			"Destroying the resource deactivates the account, which also makes it leave all rooms. The account of the provider user is never deactivated.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the bot account, e.g. `@bot:example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password of the bot account. Write-only, it is not stored in the Terraform state. " +
+					"It is set on create and whenever `password_wo_version` changes, changes made outside of Terraform are not detected. " +
+					"Requires Terraform 1.11 or later.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to set the password to the current value of `password` again.",
+				Optional:            true,
+			},
+			"room_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the rooms to invite the bot to.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixRoomID()),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the bot. Defaults to the localpart of the user ID.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the bot is a server admin. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the bot account",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomBotMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// ModifyPlan refuses to plan deactivating the provider user, which would
+// lock the provider out of the homeserver for good.
+func (r *RoomBotMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only destroy plans are checked, and only once the provider is
+	// configured.
+	if !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var state RoomBotMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.UserID.ValueString() == r.client.UserID {
+		resp.Diagnostics.AddError("Refusing to Deactivate Provider User", providerUserDeactivationMessage(state.UserID.ValueString()))
+	}
+}
+
+// putUser creates or modifies the bot account. The admin flag and the
+// password version are only sent if they changed from prior, which is nil on
+// create, so an imported account keeps its settings. The password is
+// write-only, so it is taken from the configuration rather than the plan.
+// Setting it also logs out all devices of the bot.
+func (r *RoomBotMembershipResource) putUser(ctx context.Context, config tfsdk.Config, data *RoomBotMembershipResourceModel, prior *RoomBotMembershipResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	reqBody := map[string]any{}
+	if prior == nil || !data.Admin.Equal(prior.Admin) {
+		reqBody["admin"] = data.Admin.ValueBool()
+	}
+	if !data.DisplayName.IsUnknown() && !data.DisplayName.IsNull() {
+		reqBody["displayname"] = data.DisplayName.ValueString()
+	}
+	if prior == nil || !data.PasswordWoVersion.Equal(prior.PasswordWoVersion) {
+		var password types.String
+		diags.Append(config.GetAttribute(ctx, path.Root("password"), &password)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		if !password.IsNull() {
+			reqBody["password"] = password.ValueString()
+		}
+	}
+
+	err := r.client.MakeRequest("PUT", synapseAdminURL(r.client, "v2", "users", data.UserID.ValueString()), reqBody, nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to save bot user, got error: %s", err))
+		return diags
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read bot user, got error: %s", err))
+		return diags
+	}
+
+	data.DisplayName = types.StringValue(user.Displayname)
+	return diags
+}
+
+// invite invites the bot to the room unless it already joined or is
+// invited.
+func (r *RoomBotMembershipResource) invite(roomID string, userID string) error {
+	membership, err := getMembership(r.client, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if membership == "join" || membership == "invite" {
+		return nil
+	}
+
+	_, err = r.client.InviteUser(roomID, &gomatrix.ReqInviteUser{UserID: userID})
+	return err
+}
+
+func (r *RoomBotMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomBotMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The admin API modifies existing users, which must be imported instead
+	// of silently taken over.
+	_, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err == nil {
+		resp.Diagnostics.AddError(
+			"User Already Exists",
+			fmt.Sprintf("The user %s already exists. Import it with terraform import to manage it.", data.UserID.ValueString()),
+		)
+		return
+	}
+	if !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bot user, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.putUser(ctx, req.Config, &data, nil)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.UserID
+
+	// Save the user before inviting, so a failed invite does not leave an
+	// untracked account behind.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	for _, roomID := range data.RoomIDs {
+		err = r.invite(roomID.ValueString(), data.UserID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite bot to %s, got error: %s", roomID.ValueString(), err))
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "created bot user", map[string]any{"user_id": data.UserID.ValueString(), "rooms": len(data.RoomIDs)})
+}
+
+func (r *RoomBotMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomBotMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bot user, got error: %s", err))
+		return
+	}
+
+	if user.Deactivated {
+		tflog.Warn(ctx, "bot user was deactivated, removing from state", map[string]any{"user_id": data.UserID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.DisplayName = types.StringValue(user.Displayname)
+	data.Admin = types.BoolValue(user.Admin)
+
+	// Drop rooms the bot left or was removed from, so the next apply
+	// invites it again.
+	roomIDs := make([]types.String, 0, len(data.RoomIDs))
+	for _, roomID := range data.RoomIDs {
+		membership, err := getMembership(r.client, roomID.ValueString(), data.UserID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read membership in %s, got error: %s", roomID.ValueString(), err))
+			return
+		}
+
+		if membership == "join" || membership == "invite" {
+			roomIDs = append(roomIDs, roomID)
+		}
+	}
+	data.RoomIDs = roomIDs
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomBotMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RoomBotMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.putUser(ctx, req.Config, &data, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := make(map[string]bool, len(data.RoomIDs))
+	for _, roomID := range data.RoomIDs {
+		wanted[roomID.ValueString()] = true
+
+		err := r.invite(roomID.ValueString(), data.UserID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite bot to %s, got error: %s", roomID.ValueString(), err))
+			return
+		}
+	}
+
+	for _, roomID := range state.RoomIDs {
+		if wanted[roomID.ValueString()] {
+			continue
+		}
+
+		_, err := r.client.KickUser(roomID.ValueString(), &gomatrix.ReqKickUser{UserID: data.UserID.ValueString()})
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove bot from %s, got error: %s", roomID.ValueString(), err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomBotMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomBotMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Checked at plan time already, but a changed provider user could slip
+	// through with a stale plan.
+	if data.UserID.ValueString() == r.client.UserID {
+		resp.Diagnostics.AddError("Refusing to Deactivate Provider User", providerUserDeactivationMessage(data.UserID.ValueString()))
+		return
+	}
+
+	// Deactivation also makes the user leave all rooms and rejects pending
+	// invites.
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "deactivate", data.UserID.ValueString()), map[string]any{"erase": false}, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deactivate bot user, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomBotMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id")
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRoomBotMembershipResource(t *testing.T) {
+	var userID, firstRoomID, secondRoomID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			// Deactivated users cannot be registered again.
+			userID = "@tf-acc-bot-" + acctest.RandString(8) + ":" + testAccServerName()
+			firstRoomID = testAccCreateRoom(t)
+			secondRoomID = testAccCreateRoom(t)
+			t.Setenv("TF_VAR_user_id", userID)
+			t.Setenv("TF_VAR_first_room_id", firstRoomID)
+			t.Setenv("TF_VAR_second_room_id", secondRoomID)
+		},
+		// The password is write-only.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			user, err := getSynapseUser(testAccClient(t), userID)
+			if err != nil {
+				return err
+			}
+			if !user.Deactivated {
+				return fmt.Errorf("expected %s to be deactivated", userID)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomBotMembershipResourceConfig("var.first_room_id, var.second_room_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_bot_membership.test", "display_name", "Test Bot"),
+					resource.TestCheckResourceAttr("matrix_room_bot_membership.test", "admin", "false"),
+					resource.TestCheckResourceAttr("matrix_room_bot_membership.test", "room_ids.#", "2"),
+					resource.TestCheckNoResourceAttr("matrix_room_bot_membership.test", "password"),
+					func(s *terraform.State) error {
+						return testAccCheckRoomMembership(t, firstRoomID, userID, "invite")
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_room_bot_membership.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password_wo_version", "room_ids"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomBotMembershipResourceConfig("var.first_room_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_bot_membership.test", "room_ids.#", "1"),
+					func(s *terraform.State) error {
+						return testAccCheckRoomMembership(t, secondRoomID, userID, "leave")
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckRoomMembership asserts the membership of a user in a room.
+func testAccCheckRoomMembership(t *testing.T, roomID string, userID string, expected string) error {
+	membership, err := getMembership(testAccClient(t), roomID, userID)
+	if err != nil {
+		return err
+	}
+
+	if membership != expected {
+		return fmt.Errorf("expected membership of %s in %s to be %q, got %q", userID, roomID, expected, membership)
+	}
+
+	return nil
+}
+
+func testAccRoomBotMembershipResourceConfig(roomIDs string) string {
+	return `
+variable "user_id" {}
+variable "first_room_id" {}
+variable "second_room_id" {}
+
+resource "matrix_room_bot_membership" "test" {
+  user_id      = var.user_id
+  password            = "tf-acc-bot-password"
+  password_wo_version = 1
+  display_name        = "Test Bot"
+  room_ids            = [` + roomIDs + `]
+}
+`
+}
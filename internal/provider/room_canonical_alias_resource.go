@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomCanonicalAliasResource{}
+var _ resource.ResourceWithImportState = &RoomCanonicalAliasResource{}
+
+func NewRoomCanonicalAliasResource() resource.Resource {
+	return &RoomCanonicalAliasResource{}
+}
+
+// RoomCanonicalAliasResource defines the resource implementation.
+type RoomCanonicalAliasResource struct {
+	client *gomatrix.Client
+}
+
+// RoomCanonicalAliasResourceModel describes the resource data model.
+type RoomCanonicalAliasResourceModel struct {
+	RoomID     types.String   `tfsdk:"room_id"`
+	Alias      types.String   `tfsdk:"alias"`
+	AltAliases []types.String `tfsdk:"alt_aliases"`
+	Id         types.String   `tfsdk:"id"`
+}
+
+// roomCanonicalAliasContent is the content of the m.room.canonical_alias
+// state event.
+type roomCanonicalAliasContent struct {
+	Alias      string   `json:"alias,omitempty"`
+	AltAliases []string `json:"alt_aliases,omitempty"`
+}
+
+func (r *RoomCanonicalAliasResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_canonical_alias"
+}
+
+func (r *RoomCanonicalAliasResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.canonical_alias` state event, the aliases a room advertises to clients. " +
+			"The aliases must exist and point to the room, e.g. by publishing them with `matrix_room_alias`. " +
+			"Destroying the resource removes the advertised aliases, the aliases themselves keep working.\n\n" +
+			"The provider user must be allowed to send the state event, usually by having enough power in the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "The main alias of the room, shown by clients instead of the room ID.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomAlias(),
+				},
+			},
+			"alt_aliases": schema.SetAttribute{
+				MarkdownDescription: "Further aliases of the room, e.g. the aliases on a previous domain.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixRoomAlias()),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomCanonicalAliasResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// send sends the m.room.canonical_alias state event. The homeserver rejects
+// aliases which do not point to the room.
+func (r *RoomCanonicalAliasResource) send(data RoomCanonicalAliasResourceModel) error {
+	content := roomCanonicalAliasContent{
+		Alias: data.Alias.ValueString(),
+	}
+	for _, alias := range data.AltAliases {
+		content.AltAliases = append(content.AltAliases, alias.ValueString())
+	}
+	sort.Strings(content.AltAliases)
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.canonical_alias", "", content)
+	return err
+}
+
+func (r *RoomCanonicalAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomCanonicalAliasResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.canonical_alias state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set canonical alias", map[string]any{"room_id": data.RoomID.ValueString(), "alias": data.Alias.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomCanonicalAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomCanonicalAliasResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content roomCanonicalAliasContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.canonical_alias", "", &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "canonical alias no longer exists, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.canonical_alias state event, got error: %s", err))
+		return
+	}
+
+	data.Alias = types.StringNull()
+	if content.Alias != "" {
+		data.Alias = types.StringValue(content.Alias)
+	}
+
+	// Keep an empty set as configured instead of turning it into null.
+	if len(content.AltAliases) > 0 || data.AltAliases != nil {
+		data.AltAliases = make([]types.String, 0, len(content.AltAliases))
+		for _, alias := range content.AltAliases {
+			data.AltAliases = append(data.AltAliases, types.StringValue(alias))
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomCanonicalAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomCanonicalAliasResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.canonical_alias state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomCanonicalAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomCanonicalAliasResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted, empty content removes the aliases.
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.canonical_alias", "", roomCanonicalAliasContent{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove m.room.canonical_alias state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomCanonicalAliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
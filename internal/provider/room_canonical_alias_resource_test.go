@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomCanonicalAliasResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_server_name", testAccServerName())
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomCanonicalAliasResourceConfig("old", "[]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room_canonical_alias.test", "alias", "matrix_room_alias.old", "alias"),
+					resource.TestCheckResourceAttr("matrix_room_canonical_alias.test", "alt_aliases.#", "0"),
+					resource.TestCheckResourceAttrPair("matrix_room_canonical_alias.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_canonical_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing rotates the main alias and keeps the old one
+			{
+				Config: testAccRoomCanonicalAliasResourceConfig("new", "[matrix_room_alias.old.alias]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room_canonical_alias.test", "alias", "matrix_room_alias.new", "alias"),
+					resource.TestCheckResourceAttr("matrix_room_canonical_alias.test", "alt_aliases.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomCanonicalAliasResourceConfig(alias string, altAliases string) string {
+	return fmt.Sprintf(`
+variable "server_name" {}
+
+resource "matrix_room" "test" {}
+
+resource "matrix_room_alias" "old" {
+  alias   = "#tf-acc-canonical-old:${var.server_name}"
+  room_id = matrix_room.test.room_id
+}
+
+resource "matrix_room_alias" "new" {
+  alias   = "#tf-acc-canonical-new:${var.server_name}"
+  room_id = matrix_room.test.room_id
+}
+
+resource "matrix_room_canonical_alias" "test" {
+  room_id     = matrix_room.test.room_id
+  alias       = matrix_room_alias.%s.alias
+  alt_aliases = %s
+}
+`, alias, altAliases)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomDirectoryListingResource{}
+var _ resource.ResourceWithImportState = &RoomDirectoryListingResource{}
+
+func NewRoomDirectoryListingResource() resource.Resource {
+	return &RoomDirectoryListingResource{}
+}
+
+// RoomDirectoryListingResource defines the resource implementation.
+type RoomDirectoryListingResource struct {
+	client *gomatrix.Client
+}
+
+// RoomDirectoryListingResourceModel describes the resource data model.
+type RoomDirectoryListingResourceModel struct {
+	RoomID     types.String `tfsdk:"room_id"`
+	Visibility types.String `tfsdk:"visibility"`
+	Id         types.String `tfsdk:"id"`
+}
+
+// roomDirectoryVisibility is the request and response body of the room
+// directory visibility endpoint.
+type roomDirectoryVisibility struct {
+	Visibility string `json:"visibility"`
+}
+
+func (r *RoomDirectoryListingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_directory_listing"
+}
+
+func (r *RoomDirectoryListingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Publishes a room in or hides it from the public room directory of the homeserver. " +
+			"Destroying the resource hides the room again.\n\n" +
+			"The provider user must be allowed to change the directory listing, usually by having enough power in the room. " +
+			"Synapse also allows server admins to change the listing of rooms they are not a member of, so the directory " +
+			"listing of rooms owned by other users can be managed as well. The homeserver's `room_list_publication_rules` still apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Either `public` to list the room in the directory or `private` to hide it. Defaults to `private`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("private"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "private"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomDirectoryListingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setVisibility changes the directory visibility of the room.
+func (r *RoomDirectoryListingResource) setVisibility(roomID string, visibility string) error {
+	url := r.client.BuildURL("directory", "list", "room", roomID)
+	return r.client.MakeRequest("PUT", url, roomDirectoryVisibility{Visibility: visibility}, nil)
+}
+
+func (r *RoomDirectoryListingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomDirectoryListingResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setVisibility(data.RoomID.ValueString(), data.Visibility.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room directory visibility, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set room directory visibility", map[string]any{"room_id": data.RoomID.ValueString(), "visibility": data.Visibility.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomDirectoryListingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomDirectoryListingResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var visibility roomDirectoryVisibility
+	err := r.client.MakeRequest("GET", r.client.BuildURL("directory", "list", "room", data.RoomID.ValueString()), nil, &visibility)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "room is unknown to the homeserver, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room directory visibility, got error: %s", err))
+		return
+	}
+
+	data.Visibility = types.StringValue(visibility.Visibility)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomDirectoryListingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomDirectoryListingResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setVisibility(data.RoomID.ValueString(), data.Visibility.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update room directory visibility, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomDirectoryListingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomDirectoryListingResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setVisibility(data.RoomID.ValueString(), "private")
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to hide room from directory, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomDirectoryListingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRoomDirectoryListingResource(t *testing.T) {
+	var roomID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID = testAccCreateRoom(t)
+			t.Setenv("TF_VAR_room_id", roomID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckRoomInDirectory(t, roomID, false)
+		},
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomDirectoryListingResourceConfig("secret"),
+				ExpectError: regexp.MustCompile(`value must be one of: \["public" "private"\]`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomDirectoryListingResourceConfig("public"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_directory_listing.test", "visibility", "public"),
+					func(s *terraform.State) error {
+						return testAccCheckRoomInDirectory(t, roomID, true)
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_directory_listing.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomDirectoryListingResourceConfig("private"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_directory_listing.test", "visibility", "private"),
+					func(s *terraform.State) error {
+						return testAccCheckRoomInDirectory(t, roomID, false)
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestAccRoomDirectoryListingResource_notMember checks that a server admin
+// provider user can list a room it is no longer a member of.
+func TestAccRoomDirectoryListingResource_notMember(t *testing.T) {
+	var roomID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID = testAccCreateRoom(t)
+			if _, err := testAccClient(t).LeaveRoom(roomID); err != nil {
+				t.Fatalf("unable to leave test room: %s", err)
+			}
+			t.Setenv("TF_VAR_room_id", roomID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckRoomInDirectory(t, roomID, false)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoomDirectoryListingResourceConfig("public"),
+				Check: func(s *terraform.State) error {
+					return testAccCheckRoomInDirectory(t, roomID, true)
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckRoomInDirectory asserts whether the room is listed in the
+// public room directory of the test homeserver.
+func testAccCheckRoomInDirectory(t *testing.T, roomID string, expected bool) error {
+	client := testAccClient(t)
+
+	var publicRooms struct {
+		Chunk []struct {
+			RoomID string `json:"room_id"`
+		} `json:"chunk"`
+	}
+	err := client.MakeRequest("GET", client.BuildURL("publicRooms"), nil, &publicRooms)
+	if err != nil {
+		return err
+	}
+
+	listed := false
+	for _, room := range publicRooms.Chunk {
+		if room.RoomID == roomID {
+			listed = true
+		}
+	}
+
+	if listed != expected {
+		return fmt.Errorf("expected %s to be listed in the room directory: %t", roomID, expected)
+	}
+
+	return nil
+}
+
+func testAccRoomDirectoryListingResourceConfig(visibility string) string {
+	return fmt.Sprintf(`
+variable "room_id" {}
+
+resource "matrix_room_directory_listing" "test" {
+  room_id    = var.room_id
+  visibility = %[1]q
+}
+`, visibility)
+}
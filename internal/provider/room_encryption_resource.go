@@ -0,0 +1,251 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomEncryptionResource{}
+var _ resource.ResourceWithImportState = &RoomEncryptionResource{}
+var _ resource.ResourceWithModifyPlan = &RoomEncryptionResource{}
+
+func NewRoomEncryptionResource() resource.Resource {
+	return &RoomEncryptionResource{}
+}
+
+// RoomEncryptionResource defines the resource implementation.
+type RoomEncryptionResource struct {
+	client *gomatrix.Client
+}
+
+// RoomEncryptionResourceModel describes the resource data model.
+type RoomEncryptionResourceModel struct {
+	RoomID             types.String `tfsdk:"room_id"`
+	Algorithm          types.String `tfsdk:"algorithm"`
+	RotationPeriodMs   types.Int64  `tfsdk:"rotation_period_ms"`
+	RotationPeriodMsgs types.Int64  `tfsdk:"rotation_period_msgs"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+// roomEncryptionContent is the content of the m.room.encryption state event.
+type roomEncryptionContent struct {
+	Algorithm          string `json:"algorithm"`
+	RotationPeriodMs   *int64 `json:"rotation_period_ms,omitempty"`
+	RotationPeriodMsgs *int64 `json:"rotation_period_msgs,omitempty"`
+}
+
+func (r *RoomEncryptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_encryption"
+}
+
+func (r *RoomEncryptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enables end-to-end encryption of a room with the `m.room.encryption` state event. " +
+			"Encryption cannot be disabled again: destroying the resource only removes it from the Terraform state " +
+			"and the room stays encrypted. If the state event is missing, e.g. because the room was replaced, " +
+			"the next apply enables encryption again.\n\n" +
+			"Messages sent before this resource was applied stay unencrypted. To guarantee a room is encrypted " +
+			"from its first message, add `m.room.encryption` to `initial_state` of `matrix_room` instead. " +
+			"The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "The encryption algorithm. Defaults to `m.megolm.v1.aes-sha2`, the only algorithm clients support.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("m.megolm.v1.aes-sha2"),
+			},
+			"rotation_period_ms": schema.Int64Attribute{
+				MarkdownDescription: "How long a session is used before clients rotate it, in milliseconds. " +
+					"Clients default to one week if unset.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rotation_period_msgs": schema.Int64Attribute{
+				MarkdownDescription: "How many messages are sent in a session before clients rotate it. " +
+					"Clients default to 100 if unset.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomEncryptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomEncryptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Both enabling and "disabling" encryption deserve a warning, as neither
+	// can be undone.
+	if req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() {
+		// The room ID is unknown if the room is created in the same apply.
+		resp.Diagnostics.AddWarning(
+			"Encryption Cannot Be Disabled",
+			"Enabling encryption in a room is irreversible. Destroying the resource later will not disable it, "+
+				"and bots or bridges without encryption support will no longer be able to read the room.",
+		)
+	}
+
+	if !req.State.Raw.IsNull() && req.Plan.Raw.IsNull() {
+		var state RoomEncryptionResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Encryption Stays Enabled",
+			fmt.Sprintf("Encryption cannot be disabled, %s stays encrypted. The resource is only removed from the Terraform state.", state.RoomID.ValueString()),
+		)
+	}
+}
+
+// send sends the m.room.encryption state event.
+func (r *RoomEncryptionResource) send(data RoomEncryptionResourceModel) error {
+	content := roomEncryptionContent{
+		Algorithm:          data.Algorithm.ValueString(),
+		RotationPeriodMs:   data.RotationPeriodMs.ValueInt64Pointer(),
+		RotationPeriodMsgs: data.RotationPeriodMsgs.ValueInt64Pointer(),
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.encryption", "", content)
+	return err
+}
+
+func (r *RoomEncryptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomEncryptionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.encryption state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "enabled encryption", map[string]any{"room_id": data.RoomID.ValueString(), "algorithm": data.Algorithm.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEncryptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomEncryptionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content roomEncryptionContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.encryption", "", &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "room is not encrypted, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.encryption state event, got error: %s", err))
+		return
+	}
+
+	data.Algorithm = types.StringValue(content.Algorithm)
+	data.RotationPeriodMs = types.Int64PointerValue(content.RotationPeriodMs)
+	data.RotationPeriodMsgs = types.Int64PointerValue(content.RotationPeriodMsgs)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEncryptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomEncryptionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.encryption state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEncryptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Encryption cannot be disabled, only forget the resource.
+}
+
+func (r *RoomEncryptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
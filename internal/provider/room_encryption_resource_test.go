@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomEncryptionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomEncryptionResourceConfig(100),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_encryption.test", "algorithm", "m.megolm.v1.aes-sha2"),
+					resource.TestCheckResourceAttr("matrix_room_encryption.test", "rotation_period_msgs", "100"),
+					resource.TestCheckNoResourceAttr("matrix_room_encryption.test", "rotation_period_ms"),
+					resource.TestCheckResourceAttrPair("matrix_room_encryption.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_encryption.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomEncryptionResourceConfig(50),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_encryption.test", "rotation_period_msgs", "50"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomEncryptionResourceConfig(rotationPeriodMsgs int) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_encryption" "test" {
+  room_id              = matrix_room.test.room_id
+  rotation_period_msgs = %d
+}
+`, rotationPeriodMsgs)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomEventRedactionResource{}
+var _ resource.ResourceWithImportState = &RoomEventRedactionResource{}
+
+func NewRoomEventRedactionResource() resource.Resource {
+	return &RoomEventRedactionResource{}
+}
+
+// RoomEventRedactionResource defines the resource implementation.
+type RoomEventRedactionResource struct {
+	client *gomatrix.Client
+}
+
+// RoomEventRedactionResourceModel describes the resource data model.
+type RoomEventRedactionResourceModel struct {
+	RoomID           types.String `tfsdk:"room_id"`
+	EventID          types.String `tfsdk:"event_id"`
+	Reason           types.String `tfsdk:"reason"`
+	RedactionEventID types.String `tfsdk:"redaction_event_id"`
+	Id               types.String `tfsdk:"id"`
+}
+
+// redactionEvent is the subset of the redaction event in the unsigned
+// redacted_because field of a redacted event.
+type redactionEvent struct {
+	EventID string `json:"event_id"`
+	Content struct {
+		Reason *string `json:"reason"`
+	} `json:"content"`
+}
+
+// redactionTxnID derives the transaction ID of a redaction from the redacted
+// event and the reason. The homeserver answers a retried transaction with the
+// original redaction instead of sending a second one, so the reason is part
+// of the ID to make a changed reason send a new redaction.
+func redactionTxnID(roomID string, eventID string, reason string) string {
+	sum := sha256.Sum256([]byte(roomID + "/" + eventID + "/" + reason))
+	return "tf-redact-" + hex.EncodeToString(sum[:16])
+}
+
+func (r *RoomEventRedactionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_event_redaction"
+}
+
+func (r *RoomEventRedactionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Redacts an event in a room as the provider user. " +
+			"The transaction ID is derived from the room, event ID and reason, so retrying a failed apply does not send a second redaction, while changing the reason sends a new one.\n\n" +
+			"Redactions cannot be undone, destroying the resource only removes it from the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room containing the event.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event to redact.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixEventID(),
+				},
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "The reason for the redaction, shown to other users.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"redaction_event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the redaction event.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/event_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomEventRedactionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomEventRedactionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomEventRedactionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := gomatrix.ReqRedact{Reason: data.Reason.ValueString()}
+	txnID := redactionTxnID(data.RoomID.ValueString(), data.EventID.ValueString(), data.Reason.ValueString())
+
+	var sent gomatrix.RespSendEvent
+	err := r.client.MakeRequest("PUT", r.client.BuildURL("rooms", data.RoomID.ValueString(), "redact", data.EventID.ValueString(), txnID), reqBody, &sent)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to redact event, got error: %s", err))
+		return
+	}
+
+	data.RedactionEventID = types.StringValue(sent.EventID)
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.EventID.ValueString())
+
+	tflog.Trace(ctx, "redacted event", map[string]any{"id": data.Id.ValueString(), "redaction_event_id": sent.EventID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEventRedactionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomEventRedactionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var event roomEvent
+	err := r.client.MakeRequest("GET", r.client.BuildURL("rooms", data.RoomID.ValueString(), "event", data.EventID.ValueString()), nil, &event)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "redacted event is gone, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event, got error: %s", err))
+		return
+	}
+
+	if len(event.Unsigned.RedactedBecause) == 0 {
+		tflog.Warn(ctx, "event is not redacted, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The event may have been redacted more than once and only the first
+	// redaction is reported, so only fill in the redaction on import.
+	if data.RedactionEventID.IsNull() {
+		var redaction redactionEvent
+		err = json.Unmarshal(event.Unsigned.RedactedBecause, &redaction)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse redaction, got error: %s", err))
+			return
+		}
+
+		data.RedactionEventID = types.StringValue(redaction.EventID)
+		data.Reason = types.StringPointerValue(redaction.Content.Reason)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEventRedactionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomEventRedactionResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEventRedactionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Redactions are irreversible, only forget the resource.
+}
+
+func (r *RoomEventRedactionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "event_id")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomEventRedactionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", testAccSendMessage(t, roomID, "spam"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomEventRedactionResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room_event_redaction.test", "redaction_event_id"),
+					resource.TestCheckResourceAttr("matrix_room_event_redaction.test", "reason", "Spam"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_event_redaction.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestRedactionTxnID(t *testing.T) {
+	first := redactionTxnID("!room:example.com", "$event", "Spam")
+	if first != redactionTxnID("!room:example.com", "$event", "Spam") {
+		t.Errorf("expected the transaction ID to be deterministic")
+	}
+	if first == redactionTxnID("!room:example.com", "$other", "Spam") {
+		t.Errorf("expected different events to use different transaction IDs")
+	}
+	if first == redactionTxnID("!room:example.com", "$event", "Abuse") {
+		t.Errorf("expected different reasons to use different transaction IDs")
+	}
+}
+
+const testAccRoomEventRedactionResourceConfig = `
+variable "room_id" {}
+variable "event_id" {}
+
+resource "matrix_room_event_redaction" "test" {
+  room_id  = var.room_id
+  event_id = var.event_id
+  reason   = "Spam"
+}
+`
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomEventResource{}
+var _ resource.ResourceWithImportState = &RoomEventResource{}
+
+func NewRoomEventResource() resource.Resource {
+	return &RoomEventResource{}
+}
+
+// RoomEventResource defines the resource implementation.
+type RoomEventResource struct {
+	client *gomatrix.Client
+}
+
+// RoomEventResourceModel describes the resource data model.
+type RoomEventResourceModel struct {
+	RoomID    types.String         `tfsdk:"room_id"`
+	EventType types.String         `tfsdk:"event_type"`
+	StateKey  types.String         `tfsdk:"state_key"`
+	Content   jsontypes.Normalized `tfsdk:"content"`
+	EventID   types.String         `tfsdk:"event_id"`
+	Id        types.String         `tfsdk:"id"`
+}
+
+// roomEvent is an event as returned by the client-server API, with the
+// content kept as raw JSON.
+type roomEvent struct {
+	Type     string          `json:"type"`
+	StateKey *string         `json:"state_key"`
+	Content  json.RawMessage `json:"content"`
+	Unsigned struct {
+		RedactedBecause json.RawMessage `json:"redacted_because"`
+	} `json:"unsigned"`
+}
+
+func (r *RoomEventResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_event"
+}
+
+func (r *RoomEventResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends an arbitrary event to a room as the provider user. " +
+			"Setting `state_key` sends a state event, otherwise a message event is sent. " +
+			"Changing the content of a state event sends a new state event, while message events are replaced.\n\n" +
+			"Destroying the resource redacts the event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to send the event to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"event_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the event, e.g. `org.example.custom`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"state_key": schema.StringAttribute{
+				MarkdownDescription: "The state key of the event. Set it, even to an empty string, to send a state event.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the event as JSON object, e.g. from `jsonencode`. " +
+					"Differences in key order or whitespace are ignored.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var stateKey types.String
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("state_key"), &stateKey)...)
+							resp.RequiresReplace = stateKey.IsNull()
+						},
+						"Message events cannot be changed, so changing their content sends a new event.",
+						"Message events cannot be changed, so changing their content sends a new event.",
+					),
+				},
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the sent event.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/event_id`",
+			},
+		},
+	}
+}
+
+func (r *RoomEventResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// send sends the event and stores its ID in the model.
+func (r *RoomEventResource) send(data *RoomEventResourceModel) error {
+	content := json.RawMessage(data.Content.ValueString())
+
+	var sent *gomatrix.RespSendEvent
+	var err error
+	if data.StateKey.IsNull() {
+		sent, err = r.client.SendMessageEvent(data.RoomID.ValueString(), data.EventType.ValueString(), content)
+	} else {
+		sent, err = r.client.SendStateEvent(data.RoomID.ValueString(), data.EventType.ValueString(), data.StateKey.ValueString(), content)
+	}
+	if err != nil {
+		return err
+	}
+
+	data.EventID = types.StringValue(sent.EventID)
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + sent.EventID)
+	return nil
+}
+
+func (r *RoomEventResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomEventResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send event, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "sent event", map[string]any{"id": data.Id.ValueString(), "type": data.EventType.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEventResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomEventResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var event roomEvent
+	err := r.client.MakeRequest("GET", r.client.BuildURL("rooms", data.RoomID.ValueString(), "event", data.EventID.ValueString()), nil, &event)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "event is gone, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event, got error: %s", err))
+		return
+	}
+
+	if len(event.Unsigned.RedactedBecause) > 0 {
+		tflog.Warn(ctx, "event was redacted, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.EventType = types.StringValue(event.Type)
+	data.StateKey = types.StringPointerValue(event.StateKey)
+
+	// A state event may have been replaced since, compare against the
+	// current state instead.
+	content := event.Content
+	if event.StateKey != nil {
+		err = r.client.StateEvent(data.RoomID.ValueString(), event.Type, *event.StateKey, &content)
+		if err != nil {
+			if isNotFound(err) {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read current state event, got error: %s", err))
+			return
+		}
+	}
+
+	// Semantic equality keeps the configured formatting unless the content
+	// really changed.
+	data.Content = jsontypes.NewNormalizedValue(string(content))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEventResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomEventResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the content of state events can be updated, anything else
+	// requires replacement.
+	err := r.send(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomEventResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomEventResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.RedactEvent(data.RoomID.ValueString(), data.EventID.ValueString(), &gomatrix.ReqRedact{})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to redact event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomEventResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "event_id")
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomEventResource_state(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomEventResourceStateConfig(`"[1, 2]"`),
+				ExpectError: regexp.MustCompile(`Invalid JSON Object`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomEventResourceStateConfig(`jsonencode({ prefix = "!" })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room_event.test", "event_id"),
+					testAccCheckRoomStateEvent(t, "matrix_room_event.test", "org.example.test", "prefix", "!"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_event.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomEventResourceStateConfig(`jsonencode({ prefix = "?" })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoomStateEvent(t, "matrix_room_event.test", "org.example.test", "prefix", "?"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoomEventResource_message(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomEventResourceMessageConfig("first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room_event.test", "event_id"),
+					resource.TestCheckNoResourceAttr("matrix_room_event.test", "state_key"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_event.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace and Read testing
+			{
+				Config: testAccRoomEventResourceMessageConfig("second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_event.test", "content", `{"body":"second","msgtype":"m.text"}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomEventResourceStateConfig(content string) string {
+	return `
+variable "room_id" {}
+
+resource "matrix_room_event" "test" {
+  room_id    = var.room_id
+  event_type = "org.example.test"
+  state_key  = ""
+  content    = ` + content + `
+}
+`
+}
+
+func testAccRoomEventResourceMessageConfig(body string) string {
+	return `
+variable "room_id" {}
+
+resource "matrix_room_event" "test" {
+  room_id    = var.room_id
+  event_type = "m.room.message"
+  content = jsonencode({
+    msgtype = "m.text"
+    body    = "` + body + `"
+  })
+}
+`
+}
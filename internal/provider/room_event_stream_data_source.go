@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoomEventStreamDataSource{}
+
+func NewRoomEventStreamDataSource() datasource.DataSource {
+	return &RoomEventStreamDataSource{}
+}
+
+// RoomEventStreamDataSource defines the data source implementation.
+type RoomEventStreamDataSource struct {
+	client *gomatrix.Client
+}
+
+// RoomEventStreamDataSourceModel describes the data source data model.
+type RoomEventStreamDataSourceModel struct {
+	RoomID types.String                `tfsdk:"room_id"`
+	Limit  types.Int64                 `tfsdk:"limit"`
+	From   types.String                `tfsdk:"from"`
+	Filter types.String                `tfsdk:"filter"`
+	Start  types.String                `tfsdk:"start"`
+	End    types.String                `tfsdk:"end"`
+	Chunk  []RoomEventStreamEventModel `tfsdk:"chunk"`
+	State  []RoomEventStreamEventModel `tfsdk:"state"`
+	Id     types.String                `tfsdk:"id"`
+}
+
+// RoomEventStreamEventModel describes a single event of the room timeline.
+type RoomEventStreamEventModel struct {
+	EventID        types.String `tfsdk:"event_id"`
+	Type           types.String `tfsdk:"type"`
+	StateKey       types.String `tfsdk:"state_key"`
+	ContentJSON    types.String `tfsdk:"content_json"`
+	Sender         types.String `tfsdk:"sender"`
+	OriginServerTs types.Int64  `tfsdk:"origin_server_ts"`
+}
+
+// roomTimelineEvent is an event as returned by the messages endpoint. Only
+// state events have a state key.
+type roomTimelineEvent struct {
+	EventID        string          `json:"event_id"`
+	Type           string          `json:"type"`
+	StateKey       *string         `json:"state_key"`
+	Content        json.RawMessage `json:"content"`
+	Sender         string          `json:"sender"`
+	OriginServerTs int64           `json:"origin_server_ts"`
+}
+
+// defaultRoomEventStreamLimit is the number of events read if no limit is
+// configured, the default of the specification.
+const defaultRoomEventStreamLimit = 10
+
+// roomEventStreamEventAttributes are the attributes of the events in the
+// chunk and state lists.
+func roomEventStreamEventAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"event_id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the event.",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The type of the event.",
+			Computed:            true,
+		},
+		"state_key": schema.StringAttribute{
+			MarkdownDescription: "The state key of the event, null for events which are not state events.",
+			Computed:            true,
+		},
+		"content_json": schema.StringAttribute{
+			MarkdownDescription: "The content of the event as canonical JSON.",
+			Computed:            true,
+		},
+		"sender": schema.StringAttribute{
+			MarkdownDescription: "The ID of the user who sent the event.",
+			Computed:            true,
+		},
+		"origin_server_ts": schema.Int64Attribute{
+			MarkdownDescription: "When the event was sent, in milliseconds since the epoch.",
+			Computed:            true,
+		},
+	}
+}
+
+// roomEventStreamEvents converts timeline events into their model.
+func roomEventStreamEvents(events []roomTimelineEvent) ([]RoomEventStreamEventModel, error) {
+	result := make([]RoomEventStreamEventModel, 0, len(events))
+	for _, event := range events {
+		content := []byte("{}")
+		if len(event.Content) > 0 {
+			var err error
+			content, err = canonicalJSON(event.Content)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode content of %s: %w", event.EventID, err)
+			}
+		}
+
+		result = append(result, RoomEventStreamEventModel{
+			EventID:        types.StringValue(event.EventID),
+			Type:           types.StringValue(event.Type),
+			StateKey:       types.StringPointerValue(event.StateKey),
+			ContentJSON:    types.StringValue(string(content)),
+			Sender:         types.StringValue(event.Sender),
+			OriginServerTs: types.Int64Value(event.OriginServerTs),
+		})
+	}
+
+	return result, nil
+}
+
+func (d *RoomEventStreamDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_event_stream"
+}
+
+func (d *RoomEventStreamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the most recent events of a room, newest first, e.g. to check that a bot posted a message.\n\n" +
+			"The provider user must be able to see the room history, usually by being joined to the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of events to return. Defaults to %d.", defaultRoomEventStreamLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "The token to start reading backwards from, usually the `end` of a previous read. " +
+					"Starts at the latest event if unset.",
+				Optional: true,
+			},
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A room event filter as JSON object, e.g. `jsonencode({ types = [\"m.room.message\"] })`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "The token the events were read from.",
+				Computed:            true,
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: "The `from` token to read older events with. Null if there are no older events.",
+				Computed:            true,
+			},
+			"chunk": schema.ListNestedAttribute{
+				MarkdownDescription: "The events, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: roomEventStreamEventAttributes(),
+				},
+			},
+			"state": schema.ListNestedAttribute{
+				MarkdownDescription: "State events relevant to showing the `chunk`, e.g. the members who sent the events. " +
+					"Only returned if the filter enables lazy loading of members.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: roomEventStreamEventAttributes(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoomEventStreamDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *RoomEventStreamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoomEventStreamDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(defaultRoomEventStreamLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	query := url.Values{}
+	query.Set("dir", "b")
+	query.Set("limit", strconv.FormatInt(limit, 10))
+	if !data.From.IsNull() {
+		query.Set("from", data.From.ValueString())
+	}
+	if !data.Filter.IsNull() {
+		query.Set("filter", data.Filter.ValueString())
+	}
+
+	var messagesResp struct {
+		Start string              `json:"start"`
+		End   *string             `json:"end"`
+		Chunk []roomTimelineEvent `json:"chunk"`
+		State []roomTimelineEvent `json:"state"`
+	}
+	messagesURL := d.client.BuildURL("rooms", data.RoomID.ValueString(), "messages") + "?" + query.Encode()
+	err := d.client.MakeRequest("GET", messagesURL, nil, &messagesResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room messages, got error: %s", err))
+		return
+	}
+
+	data.Chunk, err = roomEventStreamEvents(messagesResp.Chunk)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room messages, got error: %s", err))
+		return
+	}
+
+	data.State, err = roomEventStreamEvents(messagesResp.State)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room messages, got error: %s", err))
+		return
+	}
+
+	data.Start = types.StringValue(messagesResp.Start)
+	data.End = types.StringPointerValue(messagesResp.End)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "read room messages", map[string]any{"room_id": data.RoomID.ValueString(), "count": len(data.Chunk)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
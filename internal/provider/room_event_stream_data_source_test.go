@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomEventStreamDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			testAccSendMessage(t, roomID, "first")
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", testAccSendMessage(t, roomID, "second"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomEventStreamDataSourceConfig(`"[]"`),
+				ExpectError: regexp.MustCompile(`JSON object`),
+			},
+			// Read testing
+			{
+				Config: testAccRoomEventStreamDataSourceConfig(`jsonencode({ types = ["m.room.message"] })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_room_event_stream.test", "chunk.#", "1"),
+					resource.TestCheckOutput("latest", "true"),
+					resource.TestMatchResourceAttr("data.matrix_room_event_stream.test", "chunk.0.content_json", regexp.MustCompile(`"body":"second"`)),
+					resource.TestCheckNoResourceAttr("data.matrix_room_event_stream.test", "chunk.0.state_key"),
+					resource.TestCheckResourceAttrSet("data.matrix_room_event_stream.test", "start"),
+					resource.TestCheckResourceAttrSet("data.matrix_room_event_stream.test", "end"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRoomEventStreamDataSourceConfig(filter string) string {
+	return `
+variable "room_id" {}
+variable "event_id" {}
+
+data "matrix_room_event_stream" "test" {
+  room_id = var.room_id
+  limit   = 1
+  filter  = ` + filter + `
+}
+
+output "latest" {
+  value = data.matrix_room_event_stream.test.chunk[0].event_id == var.event_id
+}
+`
+}
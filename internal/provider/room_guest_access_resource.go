@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomGuestAccessResource{}
+var _ resource.ResourceWithImportState = &RoomGuestAccessResource{}
+
+func NewRoomGuestAccessResource() resource.Resource {
+	return &RoomGuestAccessResource{}
+}
+
+// RoomGuestAccessResource defines the resource implementation.
+type RoomGuestAccessResource struct {
+	client *gomatrix.Client
+}
+
+// RoomGuestAccessResourceModel describes the resource data model.
+type RoomGuestAccessResourceModel struct {
+	RoomID      types.String `tfsdk:"room_id"`
+	GuestAccess types.String `tfsdk:"guest_access"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// defaultGuestAccess applies to rooms without m.room.guest_access state
+// event.
+const defaultGuestAccess = "forbidden"
+
+func (r *RoomGuestAccessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_guest_access"
+}
+
+func (r *RoomGuestAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.guest_access` state event, which decides whether guest users can join a room. " +
+			"Changes made in a client show up as drift and are reverted by the next apply. " +
+			"State events cannot be deleted, destroying the resource sets the guest access back to `forbidden`, " +
+			"the default of rooms without the state event.\n\n" +
+			"The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"guest_access": schema.StringAttribute{
+				MarkdownDescription: "Whether guest users can join the room, either `can_join` or `forbidden`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("can_join", "forbidden"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomGuestAccessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomGuestAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomGuestAccessResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.guest_access", "guest_access", data.GuestAccess)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.guest_access state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set guest access", map[string]any{"room_id": data.RoomID.ValueString(), "guest_access": data.GuestAccess.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomGuestAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomGuestAccessResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guestAccess, err := getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.guest_access", "guest_access")
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.guest_access state event, got error: %s", err))
+		return
+	}
+
+	if guestAccess.IsNull() {
+		guestAccess = types.StringValue(defaultGuestAccess)
+	}
+	data.GuestAccess = guestAccess
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomGuestAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomGuestAccessResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.guest_access", "guest_access", data.GuestAccess)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.guest_access state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomGuestAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomGuestAccessResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.guest_access", "guest_access", types.StringValue(defaultGuestAccess))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset m.room.guest_access state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomGuestAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
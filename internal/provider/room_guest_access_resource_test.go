@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomGuestAccessResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomGuestAccessResourceConfig("can_join"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_guest_access.test", "guest_access", "can_join"),
+					resource.TestCheckResourceAttrPair("matrix_room_guest_access.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_guest_access.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomGuestAccessResourceConfig("forbidden"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_guest_access.test", "guest_access", "forbidden"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomGuestAccessResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_guest_access" "test" {
+  room_id      = matrix_room.test.room_id
+  guest_access = %q
+}
+`, value)
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomHistoryVisibilityResource{}
+var _ resource.ResourceWithImportState = &RoomHistoryVisibilityResource{}
+
+func NewRoomHistoryVisibilityResource() resource.Resource {
+	return &RoomHistoryVisibilityResource{}
+}
+
+// RoomHistoryVisibilityResource defines the resource implementation.
+type RoomHistoryVisibilityResource struct {
+	client *gomatrix.Client
+}
+
+// RoomHistoryVisibilityResourceModel describes the resource data model.
+type RoomHistoryVisibilityResourceModel struct {
+	RoomID            types.String `tfsdk:"room_id"`
+	HistoryVisibility types.String `tfsdk:"history_visibility"`
+	Id                types.String `tfsdk:"id"`
+}
+
+// defaultHistoryVisibility applies to rooms without m.room.history_visibility
+// state event.
+const defaultHistoryVisibility = "shared"
+
+func (r *RoomHistoryVisibilityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_history_visibility"
+}
+
+func (r *RoomHistoryVisibilityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.history_visibility` state event, which decides who can read the history of a room. " +
+			"Changes made in a client show up as drift and are reverted by the next apply. " +
+			"State events cannot be deleted, destroying the resource sets the history visibility back to `shared`, " +
+			"the default of rooms without the state event.\n\n" +
+			"The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"history_visibility": schema.StringAttribute{
+				MarkdownDescription: "Who can read the history, one of `world_readable`, `shared` (all members, including " +
+					"history from before they joined), `invited` (members since they were invited) or `joined` " +
+					"(members since they joined).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("world_readable", "shared", "invited", "joined"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomHistoryVisibilityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomHistoryVisibilityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomHistoryVisibilityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.history_visibility", "history_visibility", data.HistoryVisibility)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.history_visibility state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set history visibility", map[string]any{"room_id": data.RoomID.ValueString(), "history_visibility": data.HistoryVisibility.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomHistoryVisibilityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomHistoryVisibilityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	historyVisibility, err := getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.history_visibility", "history_visibility")
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.history_visibility state event, got error: %s", err))
+		return
+	}
+
+	if historyVisibility.IsNull() {
+		historyVisibility = types.StringValue(defaultHistoryVisibility)
+	}
+	data.HistoryVisibility = historyVisibility
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomHistoryVisibilityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomHistoryVisibilityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.history_visibility", "history_visibility", data.HistoryVisibility)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.history_visibility state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomHistoryVisibilityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomHistoryVisibilityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setRoomStateField(r.client, data.RoomID.ValueString(), "m.room.history_visibility", "history_visibility", types.StringValue(defaultHistoryVisibility))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset m.room.history_visibility state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomHistoryVisibilityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
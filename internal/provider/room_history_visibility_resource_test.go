@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomHistoryVisibilityResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomHistoryVisibilityResourceConfig("invited"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_history_visibility.test", "history_visibility", "invited"),
+					resource.TestCheckResourceAttrPair("matrix_room_history_visibility.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_history_visibility.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomHistoryVisibilityResourceConfig("joined"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_history_visibility.test", "history_visibility", "joined"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomHistoryVisibilityResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_history_visibility" "test" {
+  room_id            = matrix_room.test.room_id
+  history_visibility = %q
+}
+`, value)
+}
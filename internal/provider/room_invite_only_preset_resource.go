@@ -0,0 +1,509 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomInviteOnlyPresetResource{}
+var _ resource.ResourceWithImportState = &RoomInviteOnlyPresetResource{}
+
+func NewRoomInviteOnlyPresetResource() resource.Resource {
+	return &RoomInviteOnlyPresetResource{}
+}
+
+// RoomInviteOnlyPresetResource defines the resource implementation.
+type RoomInviteOnlyPresetResource struct {
+	client *gomatrix.Client
+}
+
+// RoomInviteOnlyPresetResourceModel describes the resource data model.
+type RoomInviteOnlyPresetResourceModel struct {
+	Name              types.String   `tfsdk:"name"`
+	Topic             types.String   `tfsdk:"topic"`
+	Admins            []types.String `tfsdk:"admins"`
+	InitialMembers    []types.String `tfsdk:"initial_members"`
+	JoinRule          types.String   `tfsdk:"join_rule"`
+	HistoryVisibility types.String   `tfsdk:"history_visibility"`
+	GuestAccess       types.String   `tfsdk:"guest_access"`
+	Encrypted         types.Bool     `tfsdk:"encrypted"`
+	RoomID            types.String   `tfsdk:"room_id"`
+	Id                types.String   `tfsdk:"id"`
+}
+
+// The settings every invite-only preset room keeps. Read reports the actual
+// settings, so changes made out-of-band show up as drift and the next apply
+// restores them.
+const (
+	inviteOnlyJoinRule          = "invite"
+	inviteOnlyHistoryVisibility = "shared"
+	inviteOnlyGuestAccess       = "forbidden"
+	inviteOnlyAdminPowerLevel   = 100
+)
+
+// inviteOnlyEncryption is the m.room.encryption content of new rooms.
+var inviteOnlyEncryption = map[string]any{"algorithm": "m.megolm.v1.aes-sha2"}
+
+// inviteOnlyStateEvent maps an invariant attribute to the state event keeping
+// it.
+type inviteOnlyStateEvent struct {
+	eventType string
+	field     string
+	content   map[string]any
+}
+
+var inviteOnlyStateEvents = []inviteOnlyStateEvent{
+	{"m.room.join_rules", "join_rule", map[string]any{"join_rule": inviteOnlyJoinRule}},
+	{"m.room.history_visibility", "history_visibility", map[string]any{"history_visibility": inviteOnlyHistoryVisibility}},
+	{"m.room.guest_access", "guest_access", map[string]any{"guest_access": inviteOnlyGuestAccess}},
+}
+
+func (r *RoomInviteOnlyPresetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_invite_only_preset"
+}
+
+func (r *RoomInviteOnlyPresetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates an encrypted, invite-only room owned by the provider user in a single step. " +
+			"The room only lets invited users join, shares its history with members only and keeps guests out. " +
+			"Changes to these settings made outside of Terraform show up as drift and are reverted on the next apply.\n\n" +
+			"Rooms cannot be deleted through the client-server API, so destroying this resource makes the provider user " +
+			"leave and forget the room. The room keeps existing for everyone else in it.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the room.",
+				Required:            true,
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "The topic of the room.",
+				Optional:            true,
+			},
+			"admins": schema.SetAttribute{
+				MarkdownDescription: "Users to give power level 100 in the room, in addition to the provider user. " +
+					"Users removed from the set are reset to the default power level.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixUserID()),
+				},
+			},
+			"initial_members": schema.SetAttribute{
+				MarkdownDescription: "Users to invite to the room. Users added later are invited on the next apply, " +
+					"removing a user does not kick them.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixUserID()),
+				},
+			},
+			"join_rule": schema.StringAttribute{
+				MarkdownDescription: "The join rule of the room. Always `invite`.",
+				Computed:            true,
+				Default:             stringdefault.StaticString(inviteOnlyJoinRule),
+			},
+			"history_visibility": schema.StringAttribute{
+				MarkdownDescription: "The history visibility of the room. Always `shared`.",
+				Computed:            true,
+				Default:             stringdefault.StaticString(inviteOnlyHistoryVisibility),
+			},
+			"guest_access": schema.StringAttribute{
+				MarkdownDescription: "The guest access of the room. Always `forbidden`.",
+				Computed:            true,
+				Default:             stringdefault.StaticString(inviteOnlyGuestAccess),
+			},
+			"encrypted": schema.BoolAttribute{
+				MarkdownDescription: "Whether the room is end-to-end encrypted. Always `true`.",
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomInviteOnlyPresetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setAdmins raises the given users to the admin power level and resets the
+// removed ones in a single m.room.power_levels event.
+func (r *RoomInviteOnlyPresetResource) setAdmins(roomID string, admins []types.String, removed []types.String) error {
+	var powerLevels map[string]any
+	err := r.client.StateEvent(roomID, "m.room.power_levels", "", &powerLevels)
+	if err != nil {
+		return err
+	}
+
+	users, _ := powerLevels["users"].(map[string]any)
+	if users == nil {
+		users = map[string]any{}
+	}
+
+	for _, userID := range removed {
+		delete(users, userID.ValueString())
+	}
+	for _, userID := range admins {
+		users[userID.ValueString()] = inviteOnlyAdminPowerLevel
+	}
+	powerLevels["users"] = users
+
+	_, err = r.client.SendStateEvent(roomID, "m.room.power_levels", "", powerLevels)
+	return err
+}
+
+// invite invites the given users, skipping those who already joined or have
+// a pending invite.
+func (r *RoomInviteOnlyPresetResource) invite(roomID string, userIDs []types.String) error {
+	for _, userID := range userIDs {
+		var member struct {
+			Membership string `json:"membership"`
+		}
+		err := r.client.StateEvent(roomID, "m.room.member", userID.ValueString(), &member)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		if member.Membership == "join" || member.Membership == "invite" {
+			continue
+		}
+
+		_, err = r.client.InviteUser(roomID, &gomatrix.ReqInviteUser{UserID: userID.ValueString()})
+		if err != nil {
+			return fmt.Errorf("unable to invite %s: %w", userID.ValueString(), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RoomInviteOnlyPresetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomInviteOnlyPresetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := gomatrix.ReqCreateRoom{
+		Name:   data.Name.ValueString(),
+		Topic:  data.Topic.ValueString(),
+		Preset: "private_chat",
+	}
+
+	// All settings go into the creation request, so the room is never
+	// reachable without them.
+	emptyStateKey := ""
+	reqBody.InitialState = append(reqBody.InitialState, gomatrix.Event{
+		Type:     "m.room.encryption",
+		StateKey: &emptyStateKey,
+		Content:  inviteOnlyEncryption,
+	})
+	for _, stateEvent := range inviteOnlyStateEvents {
+		reqBody.InitialState = append(reqBody.InitialState, gomatrix.Event{
+			Type:     stateEvent.eventType,
+			StateKey: &emptyStateKey,
+			Content:  stateEvent.content,
+		})
+	}
+
+	room, err := r.client.CreateRoom(&reqBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create room, got error: %s", err))
+		return
+	}
+
+	data.RoomID = types.StringValue(room.RoomID)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "created invite-only room", map[string]any{"room_id": room.RoomID})
+
+	// Save the room before anything else can fail, so it is not lost.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.Admins) > 0 {
+		err = r.setAdmins(room.RoomID, data.Admins, nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room admins, got error: %s", err))
+			return
+		}
+	}
+
+	err = r.invite(room.RoomID, data.InitialMembers)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite initial members, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomInviteOnlyPresetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomInviteOnlyPresetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	var name struct {
+		Name string `json:"name"`
+	}
+	err := r.client.StateEvent(roomID, "m.room.name", "", &name)
+	if err != nil && !isNotFound(err) {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"room_id": roomID})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room name, got error: %s", err))
+		return
+	}
+	data.Name = types.StringValue(name.Name)
+
+	var topic struct {
+		Topic string `json:"topic"`
+	}
+	err = r.client.StateEvent(roomID, "m.room.topic", "", &topic)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room topic, got error: %s", err))
+		return
+	}
+	// An unset topic and an empty one are the same to clients.
+	if topic.Topic != "" || !data.Topic.IsNull() {
+		data.Topic = types.StringValue(topic.Topic)
+	}
+
+	for _, stateEvent := range inviteOnlyStateEvents {
+		var content map[string]any
+		err := r.client.StateEvent(roomID, stateEvent.eventType, "", &content)
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", stateEvent.eventType, err))
+			return
+		}
+
+		value, _ := content[stateEvent.field].(string)
+		switch stateEvent.field {
+		case "join_rule":
+			data.JoinRule = types.StringValue(value)
+		case "history_visibility":
+			data.HistoryVisibility = types.StringValue(value)
+		case "guest_access":
+			data.GuestAccess = types.StringValue(value)
+		}
+	}
+
+	var encryption map[string]any
+	err = r.client.StateEvent(roomID, "m.room.encryption", "", &encryption)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.encryption state event, got error: %s", err))
+		return
+	}
+	data.Encrypted = types.BoolValue(err == nil && encryption["algorithm"] != nil)
+
+	var powerLevels struct {
+		Users map[string]int64 `json:"users"`
+	}
+	err = r.client.StateEvent(roomID, "m.room.power_levels", "", &powerLevels)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room power levels, got error: %s", err))
+		return
+	}
+
+	if data.Admins == nil {
+		// After import, every admin but the provider user is managed.
+		for userID, level := range powerLevels.Users {
+			if level >= inviteOnlyAdminPowerLevel && userID != r.client.UserID {
+				data.Admins = append(data.Admins, types.StringValue(userID))
+			}
+		}
+	} else {
+		// Admins demoted out-of-band drop out of the set, so the next apply
+		// promotes them again.
+		admins := make([]types.String, 0, len(data.Admins))
+		for _, userID := range data.Admins {
+			if powerLevels.Users[userID.ValueString()] >= inviteOnlyAdminPowerLevel {
+				admins = append(admins, userID)
+			}
+		}
+		data.Admins = admins
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomInviteOnlyPresetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RoomInviteOnlyPresetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	if !data.Name.Equal(state.Name) {
+		_, err := r.client.SendStateEvent(roomID, "m.room.name", "", map[string]any{"name": data.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room name, got error: %s", err))
+			return
+		}
+	}
+
+	if data.Topic.ValueString() != state.Topic.ValueString() {
+		_, err := r.client.SendStateEvent(roomID, "m.room.topic", "", map[string]any{"topic": data.Topic.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room topic, got error: %s", err))
+			return
+		}
+	}
+
+	// Restore the settings changed out-of-band.
+	actual := map[string]types.String{
+		"join_rule":          state.JoinRule,
+		"history_visibility": state.HistoryVisibility,
+		"guest_access":       state.GuestAccess,
+	}
+	for _, stateEvent := range inviteOnlyStateEvents {
+		if actual[stateEvent.field].ValueString() == stateEvent.content[stateEvent.field] {
+			continue
+		}
+
+		_, err := r.client.SendStateEvent(roomID, stateEvent.eventType, "", stateEvent.content)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", stateEvent.eventType, err))
+			return
+		}
+
+		tflog.Trace(ctx, "restored room setting", map[string]any{"room_id": roomID, "type": stateEvent.eventType})
+	}
+
+	if !state.Encrypted.ValueBool() {
+		_, err := r.client.SendStateEvent(roomID, "m.room.encryption", "", inviteOnlyEncryption)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable encryption, got error: %s", err))
+			return
+		}
+	}
+
+	wanted := make(map[string]bool, len(data.Admins))
+	for _, userID := range data.Admins {
+		wanted[userID.ValueString()] = true
+	}
+	current := make(map[string]bool, len(state.Admins))
+	var removed []types.String
+	for _, userID := range state.Admins {
+		current[userID.ValueString()] = true
+		if !wanted[userID.ValueString()] {
+			removed = append(removed, userID)
+		}
+	}
+	adminsChanged := len(removed) > 0
+	for _, userID := range data.Admins {
+		if !current[userID.ValueString()] {
+			adminsChanged = true
+		}
+	}
+
+	if adminsChanged {
+		err := r.setAdmins(roomID, data.Admins, removed)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room admins, got error: %s", err))
+			return
+		}
+	}
+
+	err := r.invite(roomID, data.InitialMembers)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite initial members, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomInviteOnlyPresetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomInviteOnlyPresetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.LeaveRoom(data.RoomID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to leave room, got error: %s", err))
+		return
+	}
+
+	_, err = r.client.ForgetRoom(data.RoomID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to forget room, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomInviteOnlyPresetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
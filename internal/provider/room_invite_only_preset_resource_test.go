@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRoomInviteOnlyPresetResource(t *testing.T) {
+	var roomID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_admin_id", testAccCreateUser(t, "tf-acc-preset-admin"))
+			t.Setenv("TF_VAR_member_id", testAccCreateUser(t, "tf-acc-preset-member"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomInviteOnlyPresetResourceConfig("Team Room"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "name", "Team Room"),
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "join_rule", "invite"),
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "history_visibility", "shared"),
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "guest_access", "forbidden"),
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "encrypted", "true"),
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "admins.#", "1"),
+					testAccCheckRoomStateEvent(t, "matrix_room_invite_only_preset.test", "m.room.encryption", "algorithm", "m.megolm.v1.aes-sha2"),
+					func(s *terraform.State) error {
+						roomID = s.RootModule().Resources["matrix_room_invite_only_preset.test"].Primary.Attributes["room_id"]
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_invite_only_preset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Invites are only sent, not read back.
+				ImportStateVerifyIgnore: []string{"initial_members"},
+			},
+			// Settings changed out-of-band are restored
+			{
+				PreConfig: func() {
+					_, err := testAccClient(t).SendStateEvent(roomID, "m.room.join_rules", "", map[string]any{"join_rule": "public"})
+					if err != nil {
+						t.Fatalf("unable to change join rule: %s", err)
+					}
+				},
+				Config: testAccRoomInviteOnlyPresetResourceConfig("Team Room"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "join_rule", "invite"),
+					testAccCheckRoomStateEvent(t, "matrix_room_invite_only_preset.test", "m.room.join_rules", "join_rule", "invite"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomInviteOnlyPresetResourceConfig("Renamed Team Room"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_invite_only_preset.test", "name", "Renamed Team Room"),
+					testAccCheckRoomStateEvent(t, "matrix_room_invite_only_preset.test", "m.room.name", "name", "Renamed Team Room"),
+					func(s *terraform.State) error {
+						return testAccCheckRoomMembership(t, roomID, "@tf-acc-preset-member:"+testAccServerName(), "invite")
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomInviteOnlyPresetResourceConfig(name string) string {
+	return fmt.Sprintf(`
+variable "admin_id" {}
+variable "member_id" {}
+
+resource "matrix_room_invite_only_preset" "test" {
+  name            = %[1]q
+  topic           = "Managed by Terraform"
+  admins          = [var.admin_id]
+  initial_members = [var.admin_id, var.member_id]
+}
+`, name)
+}
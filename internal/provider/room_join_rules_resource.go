@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomJoinRulesResource{}
+var _ resource.ResourceWithImportState = &RoomJoinRulesResource{}
+var _ resource.ResourceWithValidateConfig = &RoomJoinRulesResource{}
+
+func NewRoomJoinRulesResource() resource.Resource {
+	return &RoomJoinRulesResource{}
+}
+
+// RoomJoinRulesResource defines the resource implementation.
+type RoomJoinRulesResource struct {
+	client *gomatrix.Client
+}
+
+// RoomJoinRulesResourceModel describes the resource data model.
+type RoomJoinRulesResourceModel struct {
+	RoomID   types.String   `tfsdk:"room_id"`
+	JoinRule types.String   `tfsdk:"join_rule"`
+	Allow    []types.String `tfsdk:"allow"`
+	Id       types.String   `tfsdk:"id"`
+}
+
+// roomJoinRulesContent is the content of the m.room.join_rules state event.
+type roomJoinRulesContent struct {
+	JoinRule string                   `json:"join_rule"`
+	Allow    []roomJoinRulesAllowRule `json:"allow,omitempty"`
+}
+
+// roomJoinRulesAllowRule lets members of another room join a restricted
+// room. m.room_membership is the only type defined by the specification.
+type roomJoinRulesAllowRule struct {
+	Type   string `json:"type"`
+	RoomID string `json:"room_id,omitempty"`
+}
+
+const roomMembershipAllowRuleType = "m.room_membership"
+
+// restrictedJoinRules are the join rules which use the allow rules.
+var restrictedJoinRules = map[string]bool{
+	"restricted":       true,
+	"knock_restricted": true,
+}
+
+func (r *RoomJoinRulesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_join_rules"
+}
+
+func (r *RoomJoinRulesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.join_rules` state event, which decides who can join a room. " +
+			"With the `restricted` join rule, members of the rooms in `allow`, usually spaces, can join without an invite. " +
+			"State events cannot be deleted, destroying the resource sets the join rule back to `invite`.\n\n" +
+			"`restricted` requires room version 8 or later, `knock` version 7 and `knock_restricted` version 10. " +
+			"The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"join_rule": schema.StringAttribute{
+				MarkdownDescription: "Who can join the room, one of `public`, `invite`, `knock`, `restricted` or `knock_restricted`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "invite", "knock", "restricted", "knock_restricted"),
+				},
+			},
+			"allow": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the rooms or spaces whose members can join the room without an invite. " +
+					"Required for the `restricted` and `knock_restricted` join rules and not allowed otherwise.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixRoomID()),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomJoinRulesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomJoinRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoomJoinRulesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.JoinRule.IsUnknown() || data.JoinRule.IsNull() {
+		return
+	}
+
+	restricted := restrictedJoinRules[data.JoinRule.ValueString()]
+
+	// A restricted room without allow rules can only be joined by invite,
+	// which is what the invite join rule is for.
+	if restricted && data.Allow == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow"),
+			"Missing Allow Rooms",
+			fmt.Sprintf("The %s join rule requires allow to name at least one room whose members can join.", data.JoinRule.ValueString()),
+		)
+	}
+
+	if !restricted && data.Allow != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow"),
+			"Unused Allow Rooms",
+			fmt.Sprintf("allow only applies to the restricted and knock_restricted join rules, not %s.", data.JoinRule.ValueString()),
+		)
+	}
+}
+
+// send sends the m.room.join_rules state event. The allow rules are sorted,
+// so the state event does not change between applies.
+func (r *RoomJoinRulesResource) send(data RoomJoinRulesResourceModel) error {
+	content := roomJoinRulesContent{
+		JoinRule: data.JoinRule.ValueString(),
+	}
+
+	allow := make([]string, 0, len(data.Allow))
+	for _, roomID := range data.Allow {
+		allow = append(allow, roomID.ValueString())
+	}
+	sort.Strings(allow)
+
+	for _, roomID := range allow {
+		content.Allow = append(content.Allow, roomJoinRulesAllowRule{Type: roomMembershipAllowRuleType, RoomID: roomID})
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.join_rules", "", content)
+	return err
+}
+
+func (r *RoomJoinRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomJoinRulesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.join_rules state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set join rules", map[string]any{"room_id": data.RoomID.ValueString(), "join_rule": data.JoinRule.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomJoinRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomJoinRulesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content roomJoinRulesContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.join_rules", "", &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "join rules no longer exist, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.join_rules state event, got error: %s", err))
+		return
+	}
+
+	data.JoinRule = types.StringValue(content.JoinRule)
+
+	// Allow rules of types the provider does not know are not managed.
+	data.Allow = nil
+	for _, rule := range content.Allow {
+		if rule.Type == roomMembershipAllowRuleType && rule.RoomID != "" {
+			data.Allow = append(data.Allow, types.StringValue(rule.RoomID))
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomJoinRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomJoinRulesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.join_rules state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomJoinRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomJoinRulesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted and every room needs a join rule, so
+	// fall back to the most closed one.
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.join_rules", "", roomJoinRulesContent{JoinRule: "invite"})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset m.room.join_rules state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomJoinRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomJoinRulesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomJoinRulesResourceConfig("restricted", "[matrix_space.test.room_id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_join_rules.test", "join_rule", "restricted"),
+					resource.TestCheckResourceAttr("matrix_room_join_rules.test", "allow.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("matrix_room_join_rules.test", "allow.*", "matrix_space.test", "room_id"),
+					resource.TestCheckResourceAttrPair("matrix_room_join_rules.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_join_rules.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomJoinRulesResourceConfig("knock", "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_join_rules.test", "join_rule", "knock"),
+					resource.TestCheckNoResourceAttr("matrix_room_join_rules.test", "allow"),
+				),
+			},
+			// Allow rooms are required for restricted rooms
+			{
+				Config:      testAccRoomJoinRulesResourceConfig("restricted", "null"),
+				ExpectError: regexp.MustCompile("Missing Allow Rooms"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomJoinRulesResourceConfig(joinRule string, allow string) string {
+	return fmt.Sprintf(`
+resource "matrix_space" "test" {}
+
+resource "matrix_room" "test" {}
+
+resource "matrix_room_join_rules" "test" {
+  room_id   = matrix_room.test.room_id
+  join_rule = %[1]q
+  allow     = %[2]s
+}
+`, joinRule, allow)
+}
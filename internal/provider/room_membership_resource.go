@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomMembershipResource{}
+var _ resource.ResourceWithImportState = &RoomMembershipResource{}
+
+func NewRoomMembershipResource() resource.Resource {
+	return &RoomMembershipResource{}
+}
+
+// RoomMembershipResource defines the resource implementation.
+type RoomMembershipResource struct {
+	client *gomatrix.Client
+}
+
+// RoomMembershipResourceModel describes the resource data model.
+type RoomMembershipResourceModel struct {
+	RoomID     types.String `tfsdk:"room_id"`
+	UserID     types.String `tfsdk:"user_id"`
+	Membership types.String `tfsdk:"membership"`
+	Reason     types.String `tfsdk:"reason"`
+	Id         types.String `tfsdk:"id"`
+}
+
+// getMembership returns the membership of the user in the room as seen by
+// the provider user, `leave` if the user never was in the room.
+func getMembership(client *gomatrix.Client, roomID string, userID string) (string, error) {
+	var content struct {
+		Membership string `json:"membership"`
+	}
+	err := client.StateEvent(roomID, "m.room.member", userID, &content)
+	if err != nil {
+		if isNotFound(err) {
+			return "leave", nil
+		}
+
+		return "", err
+	}
+
+	return content.Membership, nil
+}
+
+// changeMembership invites, kicks, bans or unbans the user with the given
+// action, which is the last path segment of the endpoint.
+func (r *RoomMembershipResource) changeMembership(data RoomMembershipResourceModel, action string) error {
+	reqBody := map[string]string{"user_id": data.UserID.ValueString()}
+	if !data.Reason.IsNull() {
+		reqBody["reason"] = data.Reason.ValueString()
+	}
+
+	return r.client.MakeRequest("POST", r.client.BuildURL("rooms", data.RoomID.ValueString(), action), reqBody, nil)
+}
+
+// applyMembership moves the user from the current to the configured
+// membership. Joined users satisfy an invite, as they accepted it.
+func (r *RoomMembershipResource) applyMembership(data RoomMembershipResourceModel, current string) error {
+	desired := data.Membership.ValueString()
+
+	switch {
+	case desired == "ban" && current != "ban":
+		return r.changeMembership(data, "ban")
+	case desired == "invite" && current != "invite" && current != "join":
+		// Banned users have to be unbanned before they can be invited.
+		if current == "ban" {
+			err := r.changeMembership(data, "unban")
+			if err != nil {
+				return err
+			}
+		}
+		return r.changeMembership(data, "invite")
+	case desired == "leave" && current == "ban":
+		return r.changeMembership(data, "unban")
+	case desired == "leave" && (current == "invite" || current == "join" || current == "knock"):
+		return r.changeMembership(data, "kick")
+	}
+
+	return nil
+}
+
+func (r *RoomMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_membership"
+}
+
+func (r *RoomMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the membership of a user in a room by inviting, kicking, banning and unbanning them. " +
+			"A user who declines an invite or leaves the room shows up as drift and is invited again on the next apply. " +
+			"Destroying the resource kicks invited and joined users and unbans banned users.\n\n" +
+			"The provider user must have enough power in the room to invite, kick and ban users.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"membership": schema.StringAttribute{
+				MarkdownDescription: "The membership of the user, one of `invite` to invite them (users who joined count as invited), " +
+					"`ban` to ban them or `leave` to kick or unban them.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("invite", "ban", "leave"),
+				},
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "The reason shown to the user and other members whenever the membership changes.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/user_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getMembership(r.client, data.RoomID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room membership, got error: %s", err))
+		return
+	}
+
+	err = r.applyMembership(data, current)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change room membership, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.UserID.ValueString())
+
+	tflog.Trace(ctx, "changed room membership", map[string]any{"id": data.Id.ValueString(), "membership": data.Membership.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getMembership(r.client, data.RoomID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room membership, got error: %s", err))
+		return
+	}
+
+	// Joined users accepted their invite, knocking users are not in the
+	// room yet.
+	switch current {
+	case "join":
+		current = "invite"
+	case "knock":
+		current = "leave"
+	}
+	data.Membership = types.StringValue(current)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The state may be stale, e.g. when only the reason changed.
+	current, err := getMembership(r.client, data.RoomID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room membership, got error: %s", err))
+		return
+	}
+
+	err = r.applyMembership(data, current)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change room membership, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := getMembership(r.client, data.RoomID.ValueString(), data.UserID.ValueString())
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room membership, got error: %s", err))
+		return
+	}
+
+	data.Membership = types.StringValue("leave")
+	err = r.applyMembership(data, current)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change room membership, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "user_id")
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomMembershipResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-membership"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomMembershipResourceConfig("invite"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_membership.test", "membership", "invite"),
+					resource.TestCheckResourceAttrSet("matrix_room_membership.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_room_membership.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"reason"},
+			},
+			// Update testing bans the invited user
+			{
+				Config: testAccRoomMembershipResourceConfig("ban"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_membership.test", "membership", "ban"),
+				),
+			},
+			// Update testing unbans the user again
+			{
+				Config: testAccRoomMembershipResourceConfig("leave"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_membership.test", "membership", "leave"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomMembershipResourceConfig(membership string) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_room" "test" {}
+
+resource "matrix_room_membership" "test" {
+  room_id    = matrix_room.test.room_id
+  user_id    = var.user_id
+  membership = %q
+  reason     = "Managed by Terraform"
+}
+`, membership)
+}
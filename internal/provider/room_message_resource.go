@@ -0,0 +1,385 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomMessageResource{}
+var _ resource.ResourceWithImportState = &RoomMessageResource{}
+
+func NewRoomMessageResource() resource.Resource {
+	return &RoomMessageResource{}
+}
+
+// RoomMessageResource defines the resource implementation.
+type RoomMessageResource struct {
+	client *gomatrix.Client
+}
+
+// RoomMessageResourceModel describes the resource data model.
+type RoomMessageResourceModel struct {
+	RoomID        types.String `tfsdk:"room_id"`
+	MsgType       types.String `tfsdk:"msgtype"`
+	Body          types.String `tfsdk:"body"`
+	FormattedBody types.String `tfsdk:"formatted_body"`
+	Pinned        types.Bool   `tfsdk:"pinned"`
+	EventID       types.String `tfsdk:"event_id"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// roomMessageContent is the content of an m.room.message event.
+type roomMessageContent struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// roomMessageEdit is the content of an m.room.message event replacing
+// another one. The top-level fields are the fallback for clients without
+// support for edits.
+type roomMessageEdit struct {
+	roomMessageContent
+	NewContent roomMessageContent `json:"m.new_content"`
+	RelatesTo  struct {
+		RelType string `json:"rel_type"`
+		EventID string `json:"event_id"`
+	} `json:"m.relates_to"`
+}
+
+// content builds the message content from the model.
+func (m RoomMessageResourceModel) content() roomMessageContent {
+	content := roomMessageContent{
+		MsgType: m.MsgType.ValueString(),
+		Body:    m.Body.ValueString(),
+	}
+	if !m.FormattedBody.IsNull() {
+		content.Format = "org.matrix.custom.html"
+		content.FormattedBody = m.FormattedBody.ValueString()
+	}
+
+	return content
+}
+
+func (r *RoomMessageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_message"
+}
+
+func (r *RoomMessageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Posts a message to a room as the provider user and keeps it up to date, e.g. a message of " +
+			"the day or an on-call rota. Changing the message sends an `m.replace` edit, so it stays in place in the " +
+			"timeline. Edits made by the provider user in a client show up as drift. Destroying the resource redacts the message.\n\n" +
+			"Do not combine `pinned` with `matrix_room_pinned_events` for the same room, they would revert each other.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to post to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"msgtype": schema.StringAttribute{
+				MarkdownDescription: "The type of the message, `m.text` (the default), `m.notice` for messages of bots " +
+					"or `m.emote`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("m.text"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("m.text", "m.notice", "m.emote"),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The plain text of the message, shown by clients without HTML support and in notifications.",
+				Required:            true,
+			},
+			"formatted_body": schema.StringAttribute{
+				MarkdownDescription: "The message as HTML, shown by most clients instead of `body`.",
+				Optional:            true,
+			},
+			"pinned": schema.BoolAttribute{
+				MarkdownDescription: "Whether to pin the message in the room. Other pinned events are kept. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the message event. Edits keep the ID, so it can be linked to.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/event_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomMessageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// newRoomMessageEdit builds an edit replacing the content of eventID. The
+// fallback body is prefixed with an asterisk, as clients do.
+func newRoomMessageEdit(eventID string, content roomMessageContent) roomMessageEdit {
+	edit := roomMessageEdit{
+		roomMessageContent: content,
+		NewContent:         content,
+	}
+	edit.Body = "* " + edit.Body
+	if edit.FormattedBody != "" {
+		edit.FormattedBody = "* " + edit.FormattedBody
+	}
+	edit.RelatesTo.RelType = "m.replace"
+	edit.RelatesTo.EventID = eventID
+
+	return edit
+}
+
+// latestMessageEdit returns the content of the newest edit of a message by
+// the provider user, or nil if it was never edited. Edits by other users are
+// invalid and ignored.
+func latestMessageEdit(client *gomatrix.Client, roomID string, eventID string) (*roomMessageContent, error) {
+	query := url.Values{}
+	query.Set("dir", "b")
+	relationsURL := clientV1URL(client, "rooms", roomID, "relations", eventID, "m.replace", "m.room.message")
+
+	var relations struct {
+		Chunk []roomTimelineEvent `json:"chunk"`
+	}
+	err := client.MakeRequest("GET", relationsURL+"?"+query.Encode(), nil, &relations)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range relations.Chunk {
+		if event.Sender != client.UserID {
+			continue
+		}
+
+		var edit roomMessageEdit
+		err = json.Unmarshal(event.Content, &edit)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse edit %s: %w", event.EventID, err)
+		}
+
+		return &edit.NewContent, nil
+	}
+
+	return nil, nil
+}
+
+func (r *RoomMessageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomMessageResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sent, err := r.client.SendMessageEvent(data.RoomID.ValueString(), "m.room.message", data.content())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send message, got error: %s", err))
+		return
+	}
+
+	data.EventID = types.StringValue(sent.EventID)
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + sent.EventID)
+
+	if data.Pinned.ValueBool() {
+		err = setEventPinned(r.client, data.RoomID.ValueString(), sent.EventID, true)
+		if err != nil {
+			// Keep the message in the state, the next plan shows the missing
+			// pin as drift.
+			data.Pinned = types.BoolValue(false)
+			resp.Diagnostics.AddWarning(
+				"Message Not Pinned",
+				fmt.Sprintf("The message was sent, but pinning it failed with error: %s", err),
+			)
+		}
+	}
+
+	tflog.Trace(ctx, "sent message", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMessageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomMessageResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var event roomEvent
+	err := r.client.MakeRequest("GET", r.client.BuildURL("rooms", data.RoomID.ValueString(), "event", data.EventID.ValueString()), nil, &event)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "message is gone, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read message, got error: %s", err))
+		return
+	}
+
+	if len(event.Unsigned.RedactedBecause) > 0 {
+		tflog.Warn(ctx, "message was redacted, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var content roomMessageContent
+	err = json.Unmarshal(event.Content, &content)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse message, got error: %s", err))
+		return
+	}
+
+	edit, err := latestMessageEdit(r.client, data.RoomID.ValueString(), data.EventID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read message edits, got error: %s", err))
+		return
+	}
+	if edit != nil {
+		content = *edit
+	}
+
+	data.MsgType = types.StringValue(content.MsgType)
+	data.Body = types.StringValue(content.Body)
+	if content.FormattedBody != "" {
+		data.FormattedBody = types.StringValue(content.FormattedBody)
+	} else {
+		data.FormattedBody = types.StringNull()
+	}
+
+	pinned, err := getPinnedEvents(r.client, data.RoomID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.pinned_events state event, got error: %s", err))
+		return
+	}
+	data.Pinned = types.BoolValue(false)
+	for _, eventID := range pinned {
+		if eventID == data.EventID.ValueString() {
+			data.Pinned = types.BoolValue(true)
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMessageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RoomMessageResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.content() != state.content() {
+		edit := newRoomMessageEdit(data.EventID.ValueString(), data.content())
+		_, err := r.client.SendMessageEvent(data.RoomID.ValueString(), "m.room.message", edit)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to edit message, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Pinned.Equal(state.Pinned) {
+		err := setEventPinned(r.client, data.RoomID.ValueString(), data.EventID.ValueString(), data.Pinned.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to pin message, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMessageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomMessageResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Pinned.ValueBool() {
+		err := setEventPinned(r.client, data.RoomID.ValueString(), data.EventID.ValueString(), false)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unpin message, got error: %s", err))
+			return
+		}
+	}
+
+	// Clients hide the edits of redacted messages.
+	_, err := r.client.RedactEvent(data.RoomID.ValueString(), data.EventID.ValueString(), &gomatrix.ReqRedact{})
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to redact message, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomMessageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "event_id")
+}
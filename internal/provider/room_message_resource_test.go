@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccRoomMessageResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomMessageResourceConfig("On call: Alice", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_message.test", "body", "On call: Alice"),
+					resource.TestCheckResourceAttr("matrix_room_message.test", "msgtype", "m.notice"),
+					resource.TestCheckResourceAttr("matrix_room_message.test", "pinned", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_message.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing edits and pins the message in place
+			{
+				Config: testAccRoomMessageResourceConfig("On call: Bob", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_room_message.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_message.test", "body", "On call: Bob"),
+					resource.TestCheckResourceAttr("matrix_room_message.test", "pinned", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomMessageResourceConfig(body string, pinned bool) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  name = "Operations"
+}
+
+resource "matrix_room_message" "test" {
+  room_id = matrix_room.test.room_id
+  msgtype = "m.notice"
+  body    = %q
+  pinned  = %t
+}
+`, body, pinned)
+}
@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomNotificationLevelResource{}
+var _ resource.ResourceWithImportState = &RoomNotificationLevelResource{}
+
+func NewRoomNotificationLevelResource() resource.Resource {
+	return &RoomNotificationLevelResource{}
+}
+
+// RoomNotificationLevelResource defines the resource implementation.
+type RoomNotificationLevelResource struct {
+	client *gomatrix.Client
+}
+
+// RoomNotificationLevelResourceModel describes the resource data model.
+type RoomNotificationLevelResourceModel struct {
+	UserID  types.String   `tfsdk:"user_id"`
+	RoomID  types.String   `tfsdk:"room_id"`
+	Actions []types.String `tfsdk:"actions"`
+	Id      types.String   `tfsdk:"id"`
+}
+
+// pushRuleActions are the push rule actions defined by the specification
+// which are plain strings. Tweaks are objects and not supported here.
+var pushRuleActions = []string{"notify", "dont_notify", "coalesce"}
+
+// pushRuleActionsEqual returns whether two lists of push rule actions have the
+// same effect. dont_notify is a no-op since Matrix 1.7, so homeservers may
+// drop it.
+func pushRuleActionsEqual(a, b []string) bool {
+	withoutDontNotify := func(actions []string) []string {
+		result := make([]string, 0, len(actions))
+		for _, action := range actions {
+			if action != "dont_notify" {
+				result = append(result, action)
+			}
+		}
+		return result
+	}
+
+	a, b = withoutDontNotify(a), withoutDontNotify(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pushRuleURL builds the URL of the room push rule of the provider user.
+func (r *RoomNotificationLevelResource) pushRuleURL(roomID string) string {
+	return r.client.BuildURL("pushrules", "global", "room", roomID)
+}
+
+func (r *RoomNotificationLevelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_notification_level"
+}
+
+func (r *RoomNotificationLevelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Overrides the notification level of the provider user for a room using a room push rule, " +
+			"e.g. to mute high-volume rooms for a bot account. Destroying the resource deletes the push rule.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user owning the push rule. Users can only manage their own push rules, " +
+					"so this defaults to and must be the provider user.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"actions": schema.ListAttribute{
+				MarkdownDescription: "The actions of the push rule, each one of `notify`, `dont_notify` or `coalesce`. " +
+					"Use `[\"notify\"]` to be notified of every message or `[]` to never be notified.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(pushRuleActions...)),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomNotificationLevelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setActions creates or replaces the room push rule.
+func (r *RoomNotificationLevelResource) setActions(roomID string, actions []types.String) error {
+	values := make([]string, 0, len(actions))
+	for _, action := range actions {
+		values = append(values, action.ValueString())
+	}
+
+	return r.client.MakeRequest("PUT", r.pushRuleURL(roomID), map[string]any{
+		"actions": values,
+	}, nil)
+}
+
+func (r *RoomNotificationLevelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomNotificationLevelResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsUnknown() {
+		data.UserID = types.StringValue(r.client.UserID)
+	}
+
+	// The push rule API has no user in its path, it always acts on the
+	// provider user.
+	if data.UserID.ValueString() != r.client.UserID {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Unsupported User",
+			fmt.Sprintf("Push rules can only be set for the provider user %s, not for %s.", r.client.UserID, data.UserID.ValueString()),
+		)
+		return
+	}
+
+	err := r.setActions(data.RoomID.ValueString(), data.Actions)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room push rule, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set room push rule", map[string]any{"room_id": data.RoomID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomNotificationLevelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomNotificationLevelResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rule struct {
+		Actions []any `json:"actions"`
+	}
+	err := r.client.MakeRequest("GET", r.pushRuleURL(data.RoomID.ValueString()), nil, &rule)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "room push rule no longer exists, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room push rule, got error: %s", err))
+		return
+	}
+
+	// Tweaks set by other clients are objects, they are not managed here.
+	actions := make([]string, 0, len(rule.Actions))
+	for _, action := range rule.Actions {
+		if value, ok := action.(string); ok {
+			actions = append(actions, value)
+		}
+	}
+
+	configured := make([]string, 0, len(data.Actions))
+	for _, action := range data.Actions {
+		configured = append(configured, action.ValueString())
+	}
+
+	// Actions are unknown after import.
+	if data.Actions == nil || !pushRuleActionsEqual(actions, configured) {
+		data.Actions = make([]types.String, 0, len(actions))
+		for _, action := range actions {
+			data.Actions = append(data.Actions, types.StringValue(action))
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomNotificationLevelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomNotificationLevelResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setActions(data.RoomID.ValueString(), data.Actions)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room push rule, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomNotificationLevelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomNotificationLevelResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("DELETE", r.pushRuleURL(data.RoomID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete room push rule, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomNotificationLevelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "room_id") == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), r.client.UserID)...)
+}
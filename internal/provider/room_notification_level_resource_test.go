@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPushRuleActionsEqual(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b     []string
+		expected bool
+	}{
+		"equal":                 {a: []string{"notify"}, b: []string{"notify"}, expected: true},
+		"different":             {a: []string{"notify"}, b: []string{"coalesce"}, expected: false},
+		"different-length":      {a: []string{"notify"}, b: []string{"notify", "coalesce"}, expected: false},
+		"dont-notify-dropped":   {a: []string{"dont_notify"}, b: []string{}, expected: true},
+		"dont-notify-vs-notify": {a: []string{"dont_notify"}, b: []string{"notify"}, expected: false},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := pushRuleActionsEqual(testCase.a, testCase.b); actual != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccRoomNotificationLevelResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomNotificationLevelResourceConfig(`["mention"]`),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomNotificationLevelResourceConfig(`["dont_notify"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room_notification_level.test", "id", "matrix_room_notification_level.test", "room_id"),
+					resource.TestCheckResourceAttrSet("matrix_room_notification_level.test", "user_id"),
+					resource.TestCheckResourceAttr("matrix_room_notification_level.test", "actions.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_room_notification_level.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"actions"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomNotificationLevelResourceConfig(`["notify"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_notification_level.test", "actions.0", "notify"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomNotificationLevelResourceConfig(actions string) string {
+	return `
+variable "room_id" {}
+
+resource "matrix_room_notification_level" "test" {
+  room_id = var.room_id
+  actions = ` + actions + `
+}
+`
+}
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomPinnedEventsResource{}
+var _ resource.ResourceWithImportState = &RoomPinnedEventsResource{}
+
+func NewRoomPinnedEventsResource() resource.Resource {
+	return &RoomPinnedEventsResource{}
+}
+
+// RoomPinnedEventsResource defines the resource implementation.
+type RoomPinnedEventsResource struct {
+	client *gomatrix.Client
+}
+
+// RoomPinnedEventsResourceModel describes the resource data model.
+type RoomPinnedEventsResourceModel struct {
+	RoomID   types.String   `tfsdk:"room_id"`
+	EventIDs []types.String `tfsdk:"event_ids"`
+	Id       types.String   `tfsdk:"id"`
+}
+
+// roomPinnedEventsContent is the content of the m.room.pinned_events state
+// event.
+type roomPinnedEventsContent struct {
+	Pinned []string `json:"pinned"`
+}
+
+// getPinnedEvents reads the IDs of the pinned events of a room, which are
+// empty for rooms without m.room.pinned_events.
+func getPinnedEvents(client *gomatrix.Client, roomID string) ([]string, error) {
+	var content roomPinnedEventsContent
+	err := client.StateEvent(roomID, "m.room.pinned_events", "", &content)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	return content.Pinned, nil
+}
+
+// setEventPinned pins or unpins a single event, keeping all other pinned
+// events.
+func setEventPinned(client *gomatrix.Client, roomID string, eventID string, pinned bool) error {
+	current, err := getPinnedEvents(client, roomID)
+	if err != nil {
+		return fmt.Errorf("unable to read m.room.pinned_events state event: %w", err)
+	}
+
+	content := roomPinnedEventsContent{Pinned: make([]string, 0, len(current)+1)}
+	for _, id := range current {
+		if id != eventID {
+			content.Pinned = append(content.Pinned, id)
+		}
+	}
+	if pinned {
+		content.Pinned = append(content.Pinned, eventID)
+	}
+
+	_, err = client.SendStateEvent(roomID, "m.room.pinned_events", "", content)
+	if err != nil {
+		return fmt.Errorf("unable to send m.room.pinned_events state event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RoomPinnedEventsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_pinned_events"
+}
+
+func (r *RoomPinnedEventsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.pinned_events` state event, the events clients show pinned at the top " +
+			"of a room, e.g. the rules or an announcement. Changes made in a client show up as drift and are reverted by the next apply. " +
+			"Destroying the resource unpins all events.\n\n" +
+			"The provider user must be allowed to send the state event, usually by having enough power in the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"event_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the pinned events, in the order clients show them.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validators.MatrixEventID()),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomPinnedEventsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// send sends the m.room.pinned_events state event.
+func (r *RoomPinnedEventsResource) send(data RoomPinnedEventsResourceModel) error {
+	content := roomPinnedEventsContent{
+		Pinned: make([]string, 0, len(data.EventIDs)),
+	}
+	for _, eventID := range data.EventIDs {
+		content.Pinned = append(content.Pinned, eventID.ValueString())
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.pinned_events", "", content)
+	return err
+}
+
+func (r *RoomPinnedEventsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomPinnedEventsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.pinned_events state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "pinned events", map[string]any{"room_id": data.RoomID.ValueString(), "event_ids": len(data.EventIDs)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPinnedEventsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomPinnedEventsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content roomPinnedEventsContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.pinned_events", "", &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "pinned events no longer exist, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.pinned_events state event, got error: %s", err))
+		return
+	}
+
+	data.EventIDs = make([]types.String, 0, len(content.Pinned))
+	for _, eventID := range content.Pinned {
+		data.EventIDs = append(data.EventIDs, types.StringValue(eventID))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPinnedEventsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomPinnedEventsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.pinned_events state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPinnedEventsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomPinnedEventsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted, an empty list unpins all events.
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.pinned_events", "", roomPinnedEventsContent{Pinned: []string{}})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unpin events, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomPinnedEventsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomPinnedEventsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomPinnedEventsResourceConfig("[matrix_room_event.rules.event_id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_pinned_events.test", "event_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("matrix_room_pinned_events.test", "event_ids.0", "matrix_room_event.rules", "event_id"),
+					resource.TestCheckResourceAttrPair("matrix_room_pinned_events.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_pinned_events.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing keeps the configured order
+			{
+				Config: testAccRoomPinnedEventsResourceConfig("[matrix_room_event.announcement.event_id, matrix_room_event.rules.event_id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_pinned_events.test", "event_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("matrix_room_pinned_events.test", "event_ids.0", "matrix_room_event.announcement", "event_id"),
+					resource.TestCheckResourceAttrPair("matrix_room_pinned_events.test", "event_ids.1", "matrix_room_event.rules", "event_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomPinnedEventsResourceConfig(eventIDs string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_event" "rules" {
+  room_id    = matrix_room.test.room_id
+  event_type = "m.room.message"
+  content    = jsonencode({ msgtype = "m.text", body = "Be nice." })
+}
+
+resource "matrix_room_event" "announcement" {
+  room_id    = matrix_room.test.room_id
+  event_type = "m.room.message"
+  content    = jsonencode({ msgtype = "m.text", body = "Welcome!" })
+}
+
+resource "matrix_room_pinned_events" "test" {
+  room_id   = matrix_room.test.room_id
+  event_ids = %s
+}
+`, eventIDs)
+}
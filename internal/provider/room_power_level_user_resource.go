@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomPowerLevelUserResource{}
+var _ resource.ResourceWithImportState = &RoomPowerLevelUserResource{}
+
+func NewRoomPowerLevelUserResource() resource.Resource {
+	return &RoomPowerLevelUserResource{}
+}
+
+// RoomPowerLevelUserResource defines the resource implementation.
+type RoomPowerLevelUserResource struct {
+	client *gomatrix.Client
+}
+
+// RoomPowerLevelUserResourceModel describes the resource data model.
+type RoomPowerLevelUserResourceModel struct {
+	RoomID types.String `tfsdk:"room_id"`
+	UserID types.String `tfsdk:"user_id"`
+	Level  types.Int64  `tfsdk:"level"`
+	Id     types.String `tfsdk:"id"`
+}
+
+func (r *RoomPowerLevelUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_power_level_user"
+}
+
+func (r *RoomPowerLevelUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the power level of a single user in a room. The levels of all other users and the " +
+			"rest of the `m.room.power_levels` event are kept, so bots and other admins can change them without Terraform " +
+			"reverting it. Destroying the resource resets the user to the default level of the room, " +
+			"except for the provider user, which keeps its level.\n\n" +
+			"Do not combine with `matrix_room_power_levels` for the same room, which manages all users at once. " +
+			"The provider user must be allowed to change the power levels, usually by having enough power in the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"level": schema.Int64Attribute{
+				MarkdownDescription: "The power level of the user, e.g. `50` for moderators and `100` for admins.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/user_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomPowerLevelUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setLevel sets the power level of the user, or removes the user from the
+// users map if level is nil.
+func (r *RoomPowerLevelUserResource) setLevel(data RoomPowerLevelUserResourceModel, level *int64) error {
+	return updateRoomPowerLevels(r.client, data.RoomID.ValueString(), func(content map[string]any) {
+		users, _ := content["users"].(map[string]any)
+		if users == nil {
+			users = map[string]any{}
+		}
+
+		if level == nil {
+			delete(users, data.UserID.ValueString())
+		} else {
+			users[data.UserID.ValueString()] = *level
+		}
+		content["users"] = users
+	})
+}
+
+// checkLockout adds an error if the provider user would lower its own level
+// below the one needed to change the power levels again.
+func (r *RoomPowerLevelUserResource) checkLockout(data RoomPowerLevelUserResourceModel, diags *diag.Diagnostics) {
+	if data.UserID.ValueString() != r.client.UserID {
+		return
+	}
+
+	var levels roomPowerLevels
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.power_levels", "", &levels)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	required := levels.stateEventLevel("m.room.power_levels")
+	if data.Level.ValueInt64() < required {
+		diags.AddAttributeError(
+			path.Root("level"),
+			"Provider User Would Lose Power",
+			fmt.Sprintf("With level %d %s could no longer change the power levels of %s, which needs level %d "+
+				"and cannot be undone.", data.Level.ValueInt64(), r.client.UserID, data.RoomID.ValueString(), required),
+		)
+	}
+}
+
+func (r *RoomPowerLevelUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomPowerLevelUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkLockout(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setLevel(data, data.Level.ValueInt64Pointer())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.UserID.ValueString())
+
+	tflog.Trace(ctx, "set user power level", map[string]any{"id": data.Id.ValueString(), "level": data.Level.ValueInt64()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPowerLevelUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomPowerLevelUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var levels roomPowerLevels
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.power_levels", "", &levels)
+	if err != nil && !isNotFound(err) && matrixErrCode(err) != "M_FORBIDDEN" {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	level, ok := levels.Users[data.UserID.ValueString()]
+	if !ok {
+		tflog.Warn(ctx, "user no longer has a power level, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Level = types.Int64Value(level)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPowerLevelUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomPowerLevelUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkLockout(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setLevel(data, data.Level.ValueInt64Pointer())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPowerLevelUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomPowerLevelUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Removing the provider user would reset it to users_default.
+	if data.UserID.ValueString() == r.client.UserID {
+		tflog.Warn(ctx, "keeping the power level of the provider user", map[string]any{"id": data.Id.ValueString()})
+		return
+	}
+
+	err := r.setLevel(data, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.power_levels state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomPowerLevelUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "user_id")
+}
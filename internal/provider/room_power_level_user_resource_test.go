@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRoomPowerLevelUserResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_first_user_id", testAccCreateUser(t, "tf-acc-power-level-1"))
+			t.Setenv("TF_VAR_second_user_id", testAccCreateUser(t, "tf-acc-power-level-2"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomPowerLevelUserResourceConfig(50),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_power_level_user.first", "level", "50"),
+					resource.TestCheckResourceAttr("matrix_room_power_level_user.second", "level", "100"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_power_level_user.first",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing only changes the level of the first user
+			{
+				Config: testAccRoomPowerLevelUserResourceConfig(75),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_power_level_user.first", "level", "75"),
+					testAccCheckRoomPowerLevel(t, "matrix_room_power_level_user.second", 100),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckRoomPowerLevel checks the power level of the user of the
+// resource in the room.
+func testAccCheckRoomPowerLevel(t *testing.T, resourceName string, expected int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		var levels roomPowerLevels
+		err := testAccClient(t).StateEvent(rs.Primary.Attributes["room_id"], "m.room.power_levels", "", &levels)
+		if err != nil {
+			return err
+		}
+
+		userID := rs.Primary.Attributes["user_id"]
+		if level := levels.userLevel(userID); level != expected {
+			return fmt.Errorf("expected power level %d for %s, got %d", expected, userID, level)
+		}
+
+		return nil
+	}
+}
+
+func testAccRoomPowerLevelUserResourceConfig(level int) string {
+	return fmt.Sprintf(`
+variable "first_user_id" {}
+variable "second_user_id" {}
+
+resource "matrix_room" "test" {}
+
+resource "matrix_room_power_level_user" "first" {
+  room_id = matrix_room.test.room_id
+  user_id = var.first_user_id
+  level   = %d
+}
+
+resource "matrix_room_power_level_user" "second" {
+  room_id = matrix_room.test.room_id
+  user_id = var.second_user_id
+  level   = 100
+}
+`, level)
+}
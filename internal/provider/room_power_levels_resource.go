@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomPowerLevelsResource{}
+var _ resource.ResourceWithImportState = &RoomPowerLevelsResource{}
+
+func NewRoomPowerLevelsResource() resource.Resource {
+	return &RoomPowerLevelsResource{}
+}
+
+// RoomPowerLevelsResource defines the resource implementation.
+type RoomPowerLevelsResource struct {
+	client *gomatrix.Client
+}
+
+// RoomPowerLevelsResourceModel describes the resource data model.
+type RoomPowerLevelsResourceModel struct {
+	RoomID        types.String           `tfsdk:"room_id"`
+	Users         map[string]types.Int64 `tfsdk:"users"`
+	UsersDefault  types.Int64            `tfsdk:"users_default"`
+	Events        map[string]types.Int64 `tfsdk:"events"`
+	EventsDefault types.Int64            `tfsdk:"events_default"`
+	StateDefault  types.Int64            `tfsdk:"state_default"`
+	Ban           types.Int64            `tfsdk:"ban"`
+	Kick          types.Int64            `tfsdk:"kick"`
+	Redact        types.Int64            `tfsdk:"redact"`
+	Invite        types.Int64            `tfsdk:"invite"`
+	Id            types.String           `tfsdk:"id"`
+}
+
+// roomPowerLevelsContent is the part of the m.room.power_levels content
+// managed by the resource. Fields missing from the event fall back to the
+// defaults of the specification.
+type roomPowerLevelsContent struct {
+	Users         map[string]int64 `json:"users"`
+	UsersDefault  *int64           `json:"users_default"`
+	Events        map[string]int64 `json:"events"`
+	EventsDefault *int64           `json:"events_default"`
+	StateDefault  *int64           `json:"state_default"`
+	Ban           *int64           `json:"ban"`
+	Kick          *int64           `json:"kick"`
+	Redact        *int64           `json:"redact"`
+	Invite        *int64           `json:"invite"`
+}
+
+// int64Or returns the value or the fallback if the value is missing.
+func int64Or(value *int64, fallback int64) types.Int64 {
+	if value == nil {
+		return types.Int64Value(fallback)
+	}
+
+	return types.Int64Value(*value)
+}
+
+// powerLevelAttribute is a single level with the default of the
+// specification.
+func powerLevelAttribute(description string, defaultLevel int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: fmt.Sprintf("%s Defaults to `%d`.", description, defaultLevel),
+		Optional:            true,
+		Computed:            true,
+		Default:             int64default.StaticInt64(defaultLevel),
+	}
+}
+
+func (r *RoomPowerLevelsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_power_levels"
+}
+
+func (r *RoomPowerLevelsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.power_levels` state event of a room, which decides what each user may do. " +
+			"All levels are managed at once, levels changed outside of Terraform show up as drift. " +
+			"Other fields of the event, e.g. `notifications`, are kept as they are. " +
+			"Power levels cannot be deleted, destroying the resource leaves them unchanged.\n\n" +
+			"The provider user must be allowed to change the power levels and keeps enough power to change them again, " +
+			"so it has to be part of `users`. Use `matrix_room_power_level_user` instead to only manage the levels of some users.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"users": schema.MapAttribute{
+				MarkdownDescription: "The power levels of users, keyed by user ID. Users not listed have `users_default`.",
+				Required:            true,
+				ElementType:         types.Int64Type,
+			},
+			"users_default": powerLevelAttribute("The power level of users not listed in `users`.", 0),
+			"events": schema.MapAttribute{
+				MarkdownDescription: "The power levels required to send events, keyed by event type. " +
+					"Event types not listed need `events_default` or `state_default`.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+			},
+			"events_default": powerLevelAttribute("The power level required to send message events not listed in `events`.", 0),
+			"state_default":  powerLevelAttribute("The power level required to send state events not listed in `events`.", 50),
+			"ban":            powerLevelAttribute("The power level required to ban users.", 50),
+			"kick":           powerLevelAttribute("The power level required to kick users.", 50),
+			"redact":         powerLevelAttribute("The power level required to redact events of other users.", 50),
+			"invite":         powerLevelAttribute("The power level required to invite users.", 0),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomPowerLevelsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// roomPowerLevelsFromModel converts the model into the levels used to decide
+// what users may do.
+func roomPowerLevelsFromModel(data RoomPowerLevelsResourceModel) roomPowerLevels {
+	levels := roomPowerLevels{
+		Users:        make(map[string]int64, len(data.Users)),
+		UsersDefault: data.UsersDefault.ValueInt64(),
+		Events:       make(map[string]int64, len(data.Events)),
+		StateDefault: data.StateDefault.ValueInt64Pointer(),
+	}
+	for userID, level := range data.Users {
+		levels.Users[userID] = level.ValueInt64()
+	}
+	for eventType, level := range data.Events {
+		levels.Events[eventType] = level.ValueInt64()
+	}
+
+	return levels
+}
+
+// powerLevelsMutex serializes updateRoomPowerLevels, as Terraform applies
+// resources changing the same event in parallel.
+var powerLevelsMutex sync.Mutex
+
+// updateRoomPowerLevels reads the current m.room.power_levels content, lets
+// update change it and sends it back, so fields the caller does not manage
+// are kept.
+func updateRoomPowerLevels(client *gomatrix.Client, roomID string, update func(content map[string]any)) error {
+	powerLevelsMutex.Lock()
+	defer powerLevelsMutex.Unlock()
+
+	content := map[string]any{}
+	err := client.StateEvent(roomID, "m.room.power_levels", "", &content)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	update(content)
+
+	_, err = client.SendStateEvent(roomID, "m.room.power_levels", "", content)
+	return err
+}
+
+// send overlays the configured levels on the current m.room.power_levels
+// content.
+func (r *RoomPowerLevelsResource) send(data RoomPowerLevelsResourceModel) error {
+	levels := roomPowerLevelsFromModel(data)
+
+	return updateRoomPowerLevels(r.client, data.RoomID.ValueString(), func(content map[string]any) {
+		content["users"] = levels.Users
+		content["users_default"] = levels.UsersDefault
+		content["events"] = levels.Events
+		content["events_default"] = data.EventsDefault.ValueInt64()
+		content["state_default"] = data.StateDefault.ValueInt64()
+		content["ban"] = data.Ban.ValueInt64()
+		content["kick"] = data.Kick.ValueInt64()
+		content["redact"] = data.Redact.ValueInt64()
+		content["invite"] = data.Invite.ValueInt64()
+	})
+}
+
+// checkLockout adds an error if the levels would leave the provider user
+// unable to change the power levels again, which cannot be undone.
+func (r *RoomPowerLevelsResource) checkLockout(data RoomPowerLevelsResourceModel, diags *diag.Diagnostics) {
+	levels := roomPowerLevelsFromModel(data)
+	if levels.userLevel(r.client.UserID) >= levels.stateEventLevel("m.room.power_levels") {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("users"),
+		"Provider User Would Lose Power",
+		fmt.Sprintf("With these power levels %s could no longer change the power levels of %s, which cannot be undone. "+
+			"Give %s at least the level required for m.room.power_levels events in users.",
+			r.client.UserID, data.RoomID.ValueString(), r.client.UserID),
+	)
+}
+
+func (r *RoomPowerLevelsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomPowerLevelsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkLockout(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set power levels", map[string]any{"room_id": data.RoomID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPowerLevelsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomPowerLevelsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var contentJSON json.RawMessage
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.power_levels", "", &contentJSON)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "power levels no longer exist, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	var content roomPowerLevelsContent
+	err = json.Unmarshal(contentJSON, &content)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	data.Users = make(map[string]types.Int64, len(content.Users))
+	for userID, level := range content.Users {
+		data.Users[userID] = types.Int64Value(level)
+	}
+
+	// Keep an unset events map instead of turning it into an empty one.
+	if len(content.Events) > 0 || data.Events != nil {
+		data.Events = make(map[string]types.Int64, len(content.Events))
+		for eventType, level := range content.Events {
+			data.Events[eventType] = types.Int64Value(level)
+		}
+	}
+
+	data.UsersDefault = int64Or(content.UsersDefault, 0)
+	data.EventsDefault = int64Or(content.EventsDefault, 0)
+	data.StateDefault = int64Or(content.StateDefault, 50)
+	data.Ban = int64Or(content.Ban, 50)
+	data.Kick = int64Or(content.Kick, 50)
+	data.Redact = int64Or(content.Redact, 50)
+	data.Invite = int64Or(content.Invite, 0)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPowerLevelsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomPowerLevelsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkLockout(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.power_levels state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomPowerLevelsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Every room needs power levels, only forget the resource.
+}
+
+func (r *RoomPowerLevelsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomPowerLevelsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_admin_id", os.Getenv("MATRIX_DEFAULT_USERID"))
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-power-levels"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Levels which lock out the provider user are refused
+			{
+				Config:      testAccRoomPowerLevelsResourceConfig(10, 50),
+				ExpectError: regexp.MustCompile(`Provider User Would Lose Power`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomPowerLevelsResourceConfig(100, 50),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_power_levels.test", "users.%", "2"),
+					resource.TestCheckResourceAttr("matrix_room_power_levels.test", "events.m.room.name", "50"),
+					resource.TestCheckResourceAttr("matrix_room_power_levels.test", "kick", "50"),
+					resource.TestCheckResourceAttr("matrix_room_power_levels.test", "invite", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_power_levels.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing changes a single level
+			{
+				Config: testAccRoomPowerLevelsResourceConfig(100, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_power_levels.test", "events.m.room.name", "10"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomPowerLevelsResourceConfig(adminLevel int, nameLevel int) string {
+	return fmt.Sprintf(`
+variable "admin_id" {}
+variable "user_id" {}
+
+resource "matrix_room" "test" {}
+
+resource "matrix_room_power_levels" "test" {
+  room_id = matrix_room.test.room_id
+
+  users = {
+    (var.admin_id) = %d
+    (var.user_id)  = 50
+  }
+
+  events = {
+    "m.room.name"         = %d
+    "m.room.power_levels" = 100
+  }
+}
+`, adminLevel, nameLevel)
+}
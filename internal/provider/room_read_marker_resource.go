@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomReadMarkerResource{}
+var _ resource.ResourceWithImportState = &RoomReadMarkerResource{}
+
+func NewRoomReadMarkerResource() resource.Resource {
+	return &RoomReadMarkerResource{}
+}
+
+// RoomReadMarkerResource defines the resource implementation.
+type RoomReadMarkerResource struct {
+	client *gomatrix.Client
+}
+
+// RoomReadMarkerResourceModel describes the resource data model.
+type RoomReadMarkerResourceModel struct {
+	RoomID    types.String `tfsdk:"room_id"`
+	FullyRead types.String `tfsdk:"fully_read"`
+	Read      types.String `tfsdk:"read"`
+	Id        types.String `tfsdk:"id"`
+}
+
+// readMarkersRequest is the request body of the read markers endpoint.
+type readMarkersRequest struct {
+	FullyRead string `json:"m.fully_read"`
+	Read      string `json:"m.read,omitempty"`
+}
+
+// fullyReadContent is the content of the m.fully_read room account data.
+type fullyReadContent struct {
+	EventID string `json:"event_id"`
+}
+
+func (r *RoomReadMarkerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_read_marker"
+}
+
+func (r *RoomReadMarkerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Moves the read markers of the provider user in a room, e.g. to reset the position of a bot. " +
+			"Destroying the resource does nothing on the homeserver.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"fully_read": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event the fully read marker should point to.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixEventID(),
+				},
+			},
+			"read": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event to send a public read receipt for. " +
+					"Receipts only move forward, so this is not checked for drift.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.MatrixEventID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomReadMarkerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setReadMarkers moves the read markers of the provider user.
+func (r *RoomReadMarkerResource) setReadMarkers(data RoomReadMarkerResourceModel) error {
+	reqBody := readMarkersRequest{
+		FullyRead: data.FullyRead.ValueString(),
+		Read:      data.Read.ValueString(),
+	}
+
+	return r.client.MakeRequest("POST", r.client.BuildURL("rooms", data.RoomID.ValueString(), "read_markers"), reqBody, nil)
+}
+
+func (r *RoomReadMarkerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomReadMarkerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setReadMarkers(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set read markers, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set read markers", map[string]any{"room_id": data.RoomID.ValueString(), "fully_read": data.FullyRead.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomReadMarkerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomReadMarkerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fullyRead fullyReadContent
+	url := r.client.BuildURL("user", r.client.UserID, "rooms", data.RoomID.ValueString(), "account_data", "m.fully_read")
+	err := r.client.MakeRequest("GET", url, nil, &fullyRead)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "fully read marker is gone, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read fully read marker, got error: %s", err))
+		return
+	}
+
+	data.FullyRead = types.StringValue(fullyRead.EventID)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomReadMarkerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomReadMarkerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setReadMarkers(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update read markers, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomReadMarkerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Read markers cannot be unset, only forget the resource.
+}
+
+func (r *RoomReadMarkerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomReadMarkerResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_first_event_id", testAccSendMessage(t, roomID, "first"))
+			t.Setenv("TF_VAR_second_event_id", testAccSendMessage(t, roomID, "second"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomReadMarkerResourceConfig(`"not-an-event"`),
+				ExpectError: regexp.MustCompile(`value must be a valid Matrix event ID`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomReadMarkerResourceConfig("var.first_event_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room_read_marker.test", "id", "matrix_room_read_marker.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_room_read_marker.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"read"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomReadMarkerResourceConfig("var.second_event_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room_read_marker.test", "fully_read"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomReadMarkerResourceConfig(eventID string) string {
+	return `
+variable "room_id" {}
+variable "first_event_id" {}
+variable "second_event_id" {}
+
+resource "matrix_room_read_marker" "test" {
+  room_id    = var.room_id
+  fully_read = ` + eventID + `
+  read       = ` + eventID + `
+}
+`
+}
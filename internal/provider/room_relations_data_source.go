@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoomRelationsDataSource{}
+
+func NewRoomRelationsDataSource() datasource.DataSource {
+	return &RoomRelationsDataSource{}
+}
+
+// RoomRelationsDataSource defines the data source implementation.
+type RoomRelationsDataSource struct {
+	client *gomatrix.Client
+}
+
+// RoomRelationsDataSourceModel describes the data source data model.
+type RoomRelationsDataSourceModel struct {
+	RoomID    types.String            `tfsdk:"room_id"`
+	EventID   types.String            `tfsdk:"event_id"`
+	RelType   types.String            `tfsdk:"rel_type"`
+	EventType types.String            `tfsdk:"event_type"`
+	Limit     types.Int64             `tfsdk:"limit"`
+	From      types.String            `tfsdk:"from"`
+	Events    []RoomRelatedEventModel `tfsdk:"events"`
+	NextBatch types.String            `tfsdk:"next_batch"`
+	PrevBatch types.String            `tfsdk:"prev_batch"`
+	Id        types.String            `tfsdk:"id"`
+}
+
+// RoomRelatedEventModel describes a single event relating to another one.
+type RoomRelatedEventModel struct {
+	EventID        types.String `tfsdk:"event_id"`
+	Type           types.String `tfsdk:"type"`
+	RelType        types.String `tfsdk:"rel_type"`
+	Sender         types.String `tfsdk:"sender"`
+	ContentJSON    types.String `tfsdk:"content_json"`
+	OriginServerTs types.Int64  `tfsdk:"origin_server_ts"`
+}
+
+// relationTypes are the relation types defined by the specification.
+// Reactions are annotations.
+var relationTypes = []string{"m.annotation", "m.reference", "m.replace", "m.thread"}
+
+// defaultRoomRelationsLimit is the number of related events read if no limit
+// is configured.
+const defaultRoomRelationsLimit = 100
+
+func (d *RoomRelationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_relations"
+}
+
+func (d *RoomRelationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the events relating to an event, e.g. the reactions to a poll or the replies in a thread, newest first.\n\n" +
+			"The provider user must be able to see the room history, usually by being joined to the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event the relations point to.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixEventID(),
+				},
+			},
+			"rel_type": schema.StringAttribute{
+				MarkdownDescription: "Only list relations of this type, one of `m.annotation` (e.g. reactions), `m.reference`, " +
+					"`m.replace` or `m.thread`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(relationTypes...),
+				},
+			},
+			"event_type": schema.StringAttribute{
+				MarkdownDescription: "Only list related events of this type, e.g. `m.reaction`. Requires `rel_type`.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of related events to return. Pages are read until the limit "+
+					"is reached. Defaults to %d.", defaultRoomRelationsLimit),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "The token to start listing from, usually the `next_batch` of a previous read.",
+				Optional:            true,
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "The related events, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"event_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the event.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the event.",
+							Computed:            true,
+						},
+						"rel_type": schema.StringAttribute{
+							MarkdownDescription: "The relation type of the event.",
+							Computed:            true,
+						},
+						"sender": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who sent the event.",
+							Computed:            true,
+						},
+						"content_json": schema.StringAttribute{
+							MarkdownDescription: "The content of the event as canonical JSON.",
+							Computed:            true,
+						},
+						"origin_server_ts": schema.Int64Attribute{
+							MarkdownDescription: "When the event was sent, in milliseconds since the epoch.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"next_batch": schema.StringAttribute{
+				MarkdownDescription: "The `from` token to read older related events with. Null if there are no more events.",
+				Computed:            true,
+			},
+			"prev_batch": schema.StringAttribute{
+				MarkdownDescription: "The token of the first page that was read, null if reading started at the newest event.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoomRelationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *RoomRelationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoomRelationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The event type is a path segment after the relation type.
+	if !data.EventType.IsNull() && data.RelType.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("event_type"),
+			"Missing Relation Type",
+			"Filtering related events by event_type requires rel_type to be set as well.",
+		)
+		return
+	}
+
+	urlPath := []string{"rooms", data.RoomID.ValueString(), "relations", data.EventID.ValueString()}
+	if !data.RelType.IsNull() {
+		urlPath = append(urlPath, data.RelType.ValueString())
+	}
+	if !data.EventType.IsNull() {
+		urlPath = append(urlPath, data.EventType.ValueString())
+	}
+	relationsURL := clientV1URL(d.client, urlPath...)
+
+	limit := int64(defaultRoomRelationsLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	data.Events = make([]RoomRelatedEventModel, 0)
+	data.PrevBatch = data.From
+	from := data.From.ValueStringPointer()
+
+	for {
+		query := url.Values{}
+		query.Set("dir", "b")
+		query.Set("limit", strconv.FormatInt(limit-int64(len(data.Events)), 10))
+		if from != nil {
+			query.Set("from", *from)
+		}
+
+		var relationsResp struct {
+			Chunk     []roomTimelineEvent `json:"chunk"`
+			NextBatch *string             `json:"next_batch"`
+		}
+		err := d.client.MakeRequest("GET", relationsURL+"?"+query.Encode(), nil, &relationsResp)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event relations, got error: %s", err))
+			return
+		}
+
+		for _, event := range relationsResp.Chunk {
+			var relation struct {
+				RelatesTo struct {
+					RelType *string `json:"rel_type"`
+				} `json:"m.relates_to"`
+			}
+			// Content without a relation leaves rel_type null.
+			_ = json.Unmarshal(event.Content, &relation)
+
+			content := []byte("{}")
+			if len(event.Content) > 0 {
+				content, err = canonicalJSON(event.Content)
+				if err != nil {
+					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode content of %s, got error: %s", event.EventID, err))
+					return
+				}
+			}
+
+			data.Events = append(data.Events, RoomRelatedEventModel{
+				EventID:        types.StringValue(event.EventID),
+				Type:           types.StringValue(event.Type),
+				RelType:        types.StringPointerValue(relation.RelatesTo.RelType),
+				Sender:         types.StringValue(event.Sender),
+				ContentJSON:    types.StringValue(string(content)),
+				OriginServerTs: types.Int64Value(event.OriginServerTs),
+			})
+		}
+
+		from = relationsResp.NextBatch
+		if from == nil || len(relationsResp.Chunk) == 0 || int64(len(data.Events)) >= limit {
+			break
+		}
+	}
+
+	data.NextBatch = types.StringPointerValue(from)
+	data.Id = data.EventID
+
+	tflog.Trace(ctx, "read event relations", map[string]any{"event_id": data.EventID.ValueString(), "count": len(data.Events)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
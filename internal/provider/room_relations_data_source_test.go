@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomRelationsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			client := testAccClient(t)
+			roomID := testAccCreateRoom(t)
+			eventID := testAccSendMessage(t, roomID, "poll")
+
+			_, err := client.SendMessageEvent(roomID, "m.reaction", map[string]any{
+				"m.relates_to": map[string]any{
+					"rel_type": "m.annotation",
+					"event_id": eventID,
+					"key":      "👍",
+				},
+			})
+			if err != nil {
+				t.Fatalf("unable to send test reaction: %s", err)
+			}
+
+			_, err = client.SendMessageEvent(roomID, "m.room.message", map[string]any{
+				"msgtype": "m.text",
+				"body":    "reply",
+				"m.relates_to": map[string]any{
+					"rel_type": "m.thread",
+					"event_id": eventID,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unable to send test thread reply: %s", err)
+			}
+
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", eventID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomRelationsDataSourceConfig(`event_type = "m.reaction"`),
+				ExpectError: regexp.MustCompile(`Missing Relation Type`),
+			},
+			// Read testing
+			{
+				Config: testAccRoomRelationsDataSourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_room_relations.test", "events.#", "2"),
+					resource.TestCheckResourceAttr("data.matrix_room_relations.test", "events.0.rel_type", "m.thread"),
+					resource.TestCheckResourceAttr("data.matrix_room_relations.test", "events.1.rel_type", "m.annotation"),
+					resource.TestCheckNoResourceAttr("data.matrix_room_relations.test", "next_batch"),
+				),
+			},
+			// Filtered read testing
+			{
+				Config: testAccRoomRelationsDataSourceConfig(`
+  rel_type   = "m.annotation"
+  event_type = "m.reaction"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_room_relations.test", "events.#", "1"),
+					resource.TestCheckResourceAttr("data.matrix_room_relations.test", "events.0.type", "m.reaction"),
+				),
+			},
+			// Paging testing
+			{
+				Config: testAccRoomRelationsDataSourceConfig(`limit = 1`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_room_relations.test", "events.#", "1"),
+					resource.TestCheckResourceAttrSet("data.matrix_room_relations.test", "next_batch"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRoomRelationsDataSourceConfig(filter string) string {
+	return `
+variable "room_id" {}
+variable "event_id" {}
+
+data "matrix_room_relations" "test" {
+  room_id  = var.room_id
+  event_id = var.event_id
+  ` + filter + `
+}
+`
+}
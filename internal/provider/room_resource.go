@@ -0,0 +1,927 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomResource{}
+var _ resource.ResourceWithImportState = &RoomResource{}
+var _ resource.ResourceWithModifyPlan = &RoomResource{}
+var _ resource.ResourceWithValidateConfig = &RoomResource{}
+
+func NewRoomResource() resource.Resource {
+	return &RoomResource{}
+}
+
+// RoomResource defines the resource implementation.
+type RoomResource struct {
+	client         *gomatrix.Client
+	preventDestroy bool
+}
+
+// RoomResourceModel describes the resource data model.
+type RoomResourceModel struct {
+	Name                types.String          `tfsdk:"name"`
+	Topic               types.String          `tfsdk:"topic"`
+	Visibility          types.String          `tfsdk:"visibility"`
+	Preset              types.String          `tfsdk:"preset"`
+	Invite              []types.String        `tfsdk:"invite"`
+	RoomVersion         types.String          `tfsdk:"room_version"`
+	CreationContentJSON jsontypes.Normalized  `tfsdk:"creation_content_json"`
+	Federate            types.Bool            `tfsdk:"federate"`
+	InitialState        []RoomStateEventModel `tfsdk:"initial_state"`
+	OnDestroy           types.String          `tfsdk:"on_destroy"`
+	RoomID              types.String          `tfsdk:"room_id"`
+	Id                  types.String          `tfsdk:"id"`
+}
+
+// RoomStateEventModel describes a state event of a room.
+type RoomStateEventModel struct {
+	Type        types.String         `tfsdk:"type"`
+	StateKey    types.String         `tfsdk:"state_key"`
+	ContentJSON jsontypes.Normalized `tfsdk:"content_json"`
+}
+
+// createRoomRequest extends the gomatrix createRoom request with the fields
+// it does not know about.
+type createRoomRequest struct {
+	gomatrix.ReqCreateRoom
+	RoomVersion               string                 `json:"room_version,omitempty"`
+	PowerLevelContentOverride map[string]interface{} `json:"power_level_content_override,omitempty"`
+}
+
+// roomCreateContent is the content of the m.room.create state event.
+type roomCreateContent struct {
+	RoomVersion string `json:"room_version"`
+	Federate    *bool  `json:"m.federate"`
+}
+
+// roomCreateServerFields are the m.room.create content fields the homeserver
+// fills in itself or which have their own attribute, so they are not part of
+// creation_content_json.
+var roomCreateServerFields = []string{"creator", "room_version", "m.federate"}
+
+// customCreationContent returns the m.room.create content without the fields
+// set by the homeserver or other attributes.
+func customCreationContent(content json.RawMessage) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(content, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range roomCreateServerFields {
+		delete(fields, field)
+	}
+
+	return json.Marshal(fields)
+}
+
+// getRoomStateField returns a string field of a state event with an empty
+// state key, null if the event does not exist or the field is empty.
+func getRoomStateField(client *gomatrix.Client, roomID string, eventType string, field string) (types.String, error) {
+	var content map[string]any
+	err := client.StateEvent(roomID, eventType, "", &content)
+	if err != nil {
+		if isNotFound(err) {
+			return types.StringNull(), nil
+		}
+
+		return types.StringNull(), err
+	}
+
+	value, _ := content[field].(string)
+	if value == "" {
+		return types.StringNull(), nil
+	}
+
+	return types.StringValue(value), nil
+}
+
+// setRoomStateField sends a state event with an empty state key that only
+// holds the given field. A null value sends empty content, which removes
+// e.g. the name or topic of the room.
+func setRoomStateField(client *gomatrix.Client, roomID string, eventType string, field string, value types.String) error {
+	content := map[string]string{}
+	if !value.IsNull() {
+		content[field] = value.ValueString()
+	}
+
+	_, err := client.SendStateEvent(roomID, eventType, "", content)
+	return err
+}
+
+// leaveAndForgetRoom makes the provider user leave and forget a room, the
+// closest the client-server API has to deleting it.
+func leaveAndForgetRoom(client *gomatrix.Client, roomID string) error {
+	_, err := client.LeaveRoom(roomID)
+	if err != nil {
+		return fmt.Errorf("unable to leave room: %w", err)
+	}
+
+	_, err = client.ForgetRoom(roomID)
+	if err != nil {
+		return fmt.Errorf("unable to forget room: %w", err)
+	}
+
+	return nil
+}
+
+// roomDestroyModes are the values of on_destroy of matrix_room and
+// matrix_space.
+var roomDestroyModes = []string{"leave", "kick", "tombstone", "delete"}
+
+// roomDeletePollInterval is the time between two checks whether a room
+// deletion has finished.
+const roomDeletePollInterval = 2 * time.Second
+
+// onDestroyAttribute returns the schema of on_destroy, noun is "room" or
+// "space".
+func onDestroyAttribute(noun string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("What destroying the resource does to the %[1]s. "+
+			"`leave` (the default) makes the provider user leave and forget the %[1]s, everyone else stays in it. "+
+			"`kick` kicks all joined members before leaving. "+
+			"`tombstone` sends an `m.room.tombstone` event without replacement %[1]s, so clients show the %[1]s as closed, before leaving. "+
+			"`delete` kicks all local members and purges the %[1]s from the homeserver database using the Synapse admin API, "+
+			"which needs a server admin.", noun),
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString("leave"),
+		Validators: []validator.String{
+			stringvalidator.OneOf(roomDestroyModes...),
+		},
+	}
+}
+
+// destroyRoom gets rid of a room according to its on_destroy mode.
+func destroyRoom(ctx context.Context, client *gomatrix.Client, roomID string, mode string) error {
+	switch mode {
+	case "kick":
+		err := kickAllMembers(ctx, client, roomID)
+		if err != nil {
+			return err
+		}
+	case "tombstone":
+		_, err := client.SendStateEvent(roomID, "m.room.tombstone", "", map[string]string{
+			"body": "This room has been closed.",
+		})
+		if err != nil {
+			return fmt.Errorf("unable to send m.room.tombstone state event: %w", err)
+		}
+	case "delete":
+		// The homeserver removes every local member, including the
+		// provider user, so there is nothing left to leave.
+		_, err := deleteSynapseRoom(ctx, client, roomID, synapseRoomDeleteRequest{Purge: true})
+		return err
+	}
+
+	return leaveAndForgetRoom(client, roomID)
+}
+
+// kickAllMembers kicks every joined member of a room except the provider
+// user.
+func kickAllMembers(ctx context.Context, client *gomatrix.Client, roomID string) error {
+	members, err := client.JoinedMembers(roomID)
+	if err != nil {
+		return fmt.Errorf("unable to list joined members: %w", err)
+	}
+
+	for userID := range members.Joined {
+		if userID == client.UserID {
+			continue
+		}
+
+		_, err := client.KickUser(roomID, &gomatrix.ReqKickUser{UserID: userID, Reason: "The room has been closed."})
+		if err != nil {
+			return fmt.Errorf("unable to kick %s: %w", userID, err)
+		}
+
+		tflog.Debug(ctx, "kicked room member", map[string]any{"room_id": roomID, "user_id": userID})
+	}
+
+	return nil
+}
+
+// synapseRoomDeleteStatus is the response of the Synapse room deletion
+// status admin API.
+type synapseRoomDeleteStatus struct {
+	DeleteID     string `json:"delete_id"`
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	ShutdownRoom struct {
+		KickedUsers []string `json:"kicked_users"`
+		NewRoomID   *string  `json:"new_room_id"`
+	} `json:"shutdown_room"`
+}
+
+// synapseRoomDeleteRequest is the request body of the Synapse room deletion
+// admin API. The message is only sent if NewRoomUserID is set, as it is
+// posted to the room the members are moved to.
+type synapseRoomDeleteRequest struct {
+	Block         bool   `json:"block"`
+	Purge         bool   `json:"purge"`
+	NewRoomUserID string `json:"new_room_user_id,omitempty"`
+	RoomName      string `json:"room_name,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// deleteSynapseRoom deletes a room using the Synapse admin API and waits for
+// the deletion to finish. It returns the context error if ctx is cancelled
+// first, e.g. because Terraform was interrupted.
+func deleteSynapseRoom(ctx context.Context, client *gomatrix.Client, roomID string, request synapseRoomDeleteRequest) (*synapseRoomDeleteStatus, error) {
+	deleteID, err := startSynapseRoomDelete(client, roomID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return waitForSynapseRoomDelete(ctx, client, roomID, deleteID)
+}
+
+// startSynapseRoomDelete schedules the deletion of a room using the Synapse
+// admin API and returns its delete ID, without waiting for it to finish.
+func startSynapseRoomDelete(client *gomatrix.Client, roomID string, request synapseRoomDeleteRequest) (string, error) {
+	var deletion struct {
+		DeleteID string `json:"delete_id"`
+	}
+	err := client.MakeRequest("DELETE", synapseAdminURL(client, "v2", "rooms", roomID), request, &deletion)
+	if err != nil {
+		return "", fmt.Errorf("unable to delete room: %w", err)
+	}
+
+	return deletion.DeleteID, nil
+}
+
+// waitForSynapseRoomDelete polls a room deletion until it finishes. A failed
+// deletion returns its final status along with the error. Otherwise errors
+// mean the deletion may still be running on the homeserver, so they name the
+// delete ID to look it up again.
+func waitForSynapseRoomDelete(ctx context.Context, client *gomatrix.Client, roomID string, deleteID string) (*synapseRoomDeleteStatus, error) {
+	ticker := time.NewTicker(roomDeletePollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := getSynapseRoomDeleteStatus(client, deleteID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query status of room deletion %s: %w", deleteID, err)
+		}
+
+		switch status.Status {
+		case "complete":
+			return status, nil
+		case "failed":
+			return status, fmt.Errorf("room deletion %s failed: %s", deleteID, status.Error)
+		}
+
+		tflog.Debug(ctx, "waiting for room deletion to finish", map[string]any{"room_id": roomID, "delete_id": deleteID, "status": status.Status})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for room deletion %s: %w", deleteID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// getSynapseRoomDeleteStatus queries the status of a room deletion.
+func getSynapseRoomDeleteStatus(client *gomatrix.Client, deleteID string) (*synapseRoomDeleteStatus, error) {
+	var status synapseRoomDeleteStatus
+	err := client.MakeRequest("GET", synapseAdminURL(client, "v2", "rooms", "delete_status", deleteID), nil, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Synapse omits the ID when queried by it.
+	status.DeleteID = deleteID
+
+	return &status, nil
+}
+
+func (r *RoomResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room"
+}
+
+func (r *RoomResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a room owned by the provider user.\n\n" +
+			"Rooms cannot be deleted through the client-server API, so by default destroying this resource makes the provider user " +
+			"leave and forget the room. The room keeps existing for everyone else in it. Use `on_destroy` to kick all members, " +
+			"close the room with a tombstone or delete it through the Synapse admin API instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the room.",
+				Optional:            true,
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "The topic of the room.",
+				Optional:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Either `public` to list the room in the public room directory or `private` to hide it. " +
+					"The directory listing is left alone if unset, e.g. to manage it with `matrix_room_directory_listing` instead.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "private"),
+				},
+			},
+			"preset": schema.StringAttribute{
+				MarkdownDescription: "The preset the homeserver sets up the initial state of the room with, one of " +
+					"`private_chat`, `trusted_private_chat` or `public_chat`. Defaults to `public_chat` for public " +
+					"and `private_chat` for all other rooms. The preset only applies when the room is created, changing it creates a new room.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("private_chat", "trusted_private_chat", "public_chat"),
+				},
+			},
+			"invite": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the users to invite to the room. Users added later are invited on the next apply, " +
+					"removing a user does not revoke their invite.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixUserID()),
+				},
+			},
+			"room_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the room, e.g. `10`. Defaults to the `default_room_version` " +
+					"of the homeserver as reported by its capabilities, pin it to keep new rooms on a known version. " +
+					"Versions the homeserver does not support are rejected before the room is created. " +
+					"The version of an existing room cannot be changed, changing it creates a new room.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_content_json": schema.StringAttribute{
+				MarkdownDescription: "Extra content of the `m.room.create` event as JSON object, e.g. " +
+					"`jsonencode({ type = \"m.space\" })` to create a space or a `predecessor` to link an upgraded room. " +
+					"The `m.room.create` event cannot be changed, changing this creates a new room.",
+				CustomType: jsontypes.NormalizedType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"federate": schema.BoolAttribute{
+				MarkdownDescription: "Whether users of other homeservers can join the room. Defaults to `true`. " +
+					"Federation cannot be changed after the room was created, changing this creates a new room.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"initial_state": schema.ListNestedAttribute{
+				MarkdownDescription: "State events to set when the room is created, e.g. `m.room.encryption` " +
+					"or `m.room.join_rules`, so they apply from the very first event. " +
+					"Changes to this list after creation are sent as individual state events. " +
+					"Removing an entry stops managing the state event but leaves its current content in the room.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the state event, e.g. `m.room.encryption`.",
+							Required:            true,
+						},
+						"state_key": schema.StringAttribute{
+							MarkdownDescription: "The state key of the state event. Defaults to an empty string.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+						},
+						"content_json": schema.StringAttribute{
+							MarkdownDescription: "The content of the state event as JSON object, e.g. from `jsonencode`.",
+							CustomType:          jsontypes.NormalizedType{},
+							Required:            true,
+							Validators: []validator.String{
+								validators.JSONObject(),
+							},
+						},
+					},
+				},
+			},
+			"on_destroy": onDestroyAttribute("room"),
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+	r.preventDestroy = providerData.PreventDestroyRooms
+}
+
+func (r *RoomResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateInitialState(ctx, req, resp)
+	validateCreationContent(ctx, req, resp)
+}
+
+// validateInitialState rejects initial_state entries the homeserver would
+// silently override, so the configuration matches the created room.
+func validateInitialState(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var initialState []RoomStateEventModel
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("initial_state"), &initialState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[[2]string]bool, len(initialState))
+	for i, event := range initialState {
+		if event.Type.IsUnknown() || event.StateKey.IsUnknown() {
+			continue
+		}
+
+		eventPath := path.Root("initial_state").AtListIndex(i)
+		eventType := event.Type.ValueString()
+
+		switch eventType {
+		case "m.room.create":
+			resp.Diagnostics.AddAttributeError(eventPath.AtName("type"), "Invalid Initial State Event",
+				"m.room.create is sent by the homeserver, use creation_content_json, room_version and federate instead.")
+			continue
+		case "m.room.member":
+			resp.Diagnostics.AddAttributeError(eventPath.AtName("type"), "Invalid Initial State Event",
+				"Memberships cannot be part of initial_state, use invite or matrix_room_membership instead.")
+			continue
+		}
+
+		// The state key defaults to an empty string.
+		key := [2]string{eventType, event.StateKey.ValueString()}
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(eventPath, "Duplicate Initial State Event",
+				fmt.Sprintf("initial_state contains %s with state key %q more than once, only the last one would be applied.", key[0], key[1]))
+		}
+		seen[key] = true
+	}
+}
+
+// validateCreationContent rejects creation_content_json fields that have
+// their own attribute or are set by the homeserver.
+func validateCreationContent(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var creationContentJSON jsontypes.Normalized
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("creation_content_json"), &creationContentJSON)...)
+
+	if resp.Diagnostics.HasError() || creationContentJSON.IsNull() || creationContentJSON.IsUnknown() {
+		return
+	}
+
+	// Invalid JSON is reported by the attribute validators.
+	var creationContent map[string]json.RawMessage
+	if json.Unmarshal([]byte(creationContentJSON.ValueString()), &creationContent) != nil {
+		return
+	}
+
+	// These fields have their own attribute, or are set by the homeserver.
+	for _, field := range roomCreateServerFields {
+		if _, ok := creationContent[field]; !ok {
+			continue
+		}
+
+		var detail string
+		switch field {
+		case "m.federate":
+			detail = "Set federation with the federate attribute instead of m.federate in creation_content_json."
+		case "room_version":
+			detail = "Set the version with the room_version attribute instead of in creation_content_json."
+		default:
+			detail = fmt.Sprintf("%s is set by the homeserver and cannot be part of creation_content_json.", field)
+		}
+
+		resp.Diagnostics.AddAttributeError(path.Root("creation_content_json"), "Invalid Creation Content", detail)
+	}
+}
+
+func (r *RoomResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to warn about on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan RoomResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RoomVersion.IsUnknown() && !plan.RoomVersion.Equal(state.RoomVersion) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("room_version"),
+			"Room Will Be Replaced",
+			fmt.Sprintf("The version of %s cannot be changed from %s to %s. A new, empty room will be created and "+
+				"the members and history of the old room stay behind.",
+				state.RoomID.ValueString(), state.RoomVersion.ValueString(), plan.RoomVersion.ValueString()),
+		)
+	}
+
+	if !plan.CreationContentJSON.IsUnknown() && !plan.CreationContentJSON.Equal(state.CreationContentJSON) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("creation_content_json"),
+			"Room Will Be Replaced",
+			fmt.Sprintf("The creation content of %s cannot be changed. A new, empty room will be created and "+
+				"the members and history of the old room stay behind.", state.RoomID.ValueString()),
+		)
+	}
+
+	if !plan.Preset.Equal(state.Preset) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("preset"),
+			"Room Will Be Replaced",
+			fmt.Sprintf("The preset of %s only applies when the room is created. A new, empty room will be created and "+
+				"the members and history of the old room stay behind.", state.RoomID.ValueString()),
+		)
+	}
+
+	if !plan.Federate.IsUnknown() && !plan.Federate.Equal(state.Federate) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("federate"),
+			"Room Will Be Replaced",
+			fmt.Sprintf("Federation of %s cannot be changed. A new, empty room will be created and "+
+				"the members and history of the old room stay behind.", state.RoomID.ValueString()),
+		)
+	}
+}
+
+func (r *RoomResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilities, err := getCapabilities(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read available room versions, got error: %s", err))
+		return
+	}
+
+	// Check a pinned version up front, the homeserver only answers with
+	// M_UNSUPPORTED_ROOM_VERSION.
+	if data.RoomVersion.IsUnknown() {
+		data.RoomVersion = types.StringValue(capabilities.Capabilities.RoomVersions.Default)
+	} else if message := capabilities.unsupportedRoomVersion(data.RoomVersion.ValueString()); message != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("room_version"), "Unsupported Room Version", message)
+		return
+	}
+
+	reqBody := createRoomRequest{
+		ReqCreateRoom: gomatrix.ReqCreateRoom{
+			Name:       data.Name.ValueString(),
+			Topic:      data.Topic.ValueString(),
+			Visibility: data.Visibility.ValueString(),
+			Preset:     data.Preset.ValueString(),
+		},
+		RoomVersion: data.RoomVersion.ValueString(),
+	}
+
+	for _, userID := range data.Invite {
+		reqBody.Invite = append(reqBody.Invite, userID.ValueString())
+	}
+
+	if !data.CreationContentJSON.IsNull() {
+		err := json.Unmarshal([]byte(data.CreationContentJSON.ValueString()), &reqBody.CreationContent)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Creation Content", fmt.Sprintf("Unable to decode creation_content_json, got error: %s", err))
+			return
+		}
+	}
+
+	// Rooms are federated unless m.federate says otherwise.
+	if !data.Federate.ValueBool() {
+		if reqBody.CreationContent == nil {
+			reqBody.CreationContent = map[string]interface{}{}
+		}
+		reqBody.CreationContent["m.federate"] = false
+	}
+
+	for _, stateEvent := range data.InitialState {
+		var content map[string]any
+		err := json.Unmarshal([]byte(stateEvent.ContentJSON.ValueString()), &content)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Initial State", fmt.Sprintf("Unable to decode content_json of %s, got error: %s", stateEvent.Type.ValueString(), err))
+			return
+		}
+
+		stateKey := stateEvent.StateKey.ValueString()
+		reqBody.InitialState = append(reqBody.InitialState, gomatrix.Event{
+			Type:     stateEvent.Type.ValueString(),
+			StateKey: &stateKey,
+			Content:  content,
+		})
+	}
+
+	var room gomatrix.RespCreateRoom
+	err = r.client.MakeRequest("POST", r.client.BuildURL("createRoom"), reqBody, &room)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create room, got error: %s", err))
+		return
+	}
+
+	data.RoomID = types.StringValue(room.RoomID)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "created room", map[string]any{"room_id": room.RoomID, "room_version": data.RoomVersion.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var createJSON json.RawMessage
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.create", "", &createJSON)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the room, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room, got error: %s", err))
+		return
+	}
+
+	var create roomCreateContent
+	err = json.Unmarshal(createJSON, &create)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse m.room.create event, got error: %s", err))
+		return
+	}
+
+	// Rooms created before room versions existed have no room_version.
+	if create.RoomVersion == "" {
+		create.RoomVersion = "1"
+	}
+	data.RoomVersion = types.StringValue(create.RoomVersion)
+
+	federate := create.Federate == nil || *create.Federate
+	if !data.Federate.IsNull() && data.Federate.ValueBool() != federate {
+		tflog.Warn(ctx, "room federation differs from the configuration", map[string]any{"room_id": data.RoomID.ValueString(), "federate": federate})
+	}
+	data.Federate = types.BoolValue(federate)
+
+	data.Name, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.name state event, got error: %s", err))
+		return
+	}
+
+	data.Topic, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.topic", "topic")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.topic state event, got error: %s", err))
+		return
+	}
+
+	// The directory listing is only tracked if it is managed by this resource.
+	if !data.Visibility.IsNull() {
+		var visibility struct {
+			Visibility string `json:"visibility"`
+		}
+		err = r.client.MakeRequest("GET", r.client.BuildURL("directory", "list", "room", data.RoomID.ValueString()), nil, &visibility)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room directory visibility, got error: %s", err))
+			return
+		}
+		data.Visibility = types.StringValue(visibility.Visibility)
+	}
+
+	creationContent, err := customCreationContent(createJSON)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse m.room.create event, got error: %s", err))
+		return
+	}
+
+	// Semantic equality keeps the configured formatting unless the content
+	// really differs, which can only be fixed by replacing the room. A room
+	// without extra creation content matches an unset creation_content_json.
+	if !data.CreationContentJSON.IsNull() || !jsonEqual(creationContent, []byte("{}")) {
+		data.CreationContentJSON = jsontypes.NewNormalizedValue(string(creationContent))
+	}
+
+	for i, stateEvent := range data.InitialState {
+		var content json.RawMessage
+		err := r.client.StateEvent(data.RoomID.ValueString(), stateEvent.Type.ValueString(), stateEvent.StateKey.ValueString(), &content)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", stateEvent.Type.ValueString(), err))
+			return
+		}
+
+		data.InitialState[i].ContentJSON = jsontypes.NewNormalizedValue(string(content))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	if !data.Name.Equal(state.Name) {
+		err := setRoomStateField(r.client, roomID, "m.room.name", "name", data.Name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.name state event, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Topic.Equal(state.Topic) {
+		err := setRoomStateField(r.client, roomID, "m.room.topic", "topic", data.Topic)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.topic state event, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Visibility.IsNull() && !data.Visibility.Equal(state.Visibility) {
+		err := r.client.MakeRequest("PUT", r.client.BuildURL("directory", "list", "room", roomID), map[string]string{
+			"visibility": data.Visibility.ValueString(),
+		}, nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set room directory visibility, got error: %s", err))
+			return
+		}
+	}
+
+	invited := make(map[string]bool, len(state.Invite))
+	for _, userID := range state.Invite {
+		invited[userID.ValueString()] = true
+	}
+
+	for _, userID := range data.Invite {
+		if invited[userID.ValueString()] {
+			continue
+		}
+
+		_, err := r.client.InviteUser(roomID, &gomatrix.ReqInviteUser{UserID: userID.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite %s, got error: %s", userID.ValueString(), err))
+			return
+		}
+	}
+
+	// initial_state only goes to createRoom once, afterwards each changed
+	// entry is sent as a state event on its own.
+	previous := make(map[string]string, len(state.InitialState))
+	for _, stateEvent := range state.InitialState {
+		previous[stateEvent.Type.ValueString()+"\x00"+stateEvent.StateKey.ValueString()] = stateEvent.ContentJSON.ValueString()
+	}
+
+	for _, stateEvent := range data.InitialState {
+		key := stateEvent.Type.ValueString() + "\x00" + stateEvent.StateKey.ValueString()
+		content, known := previous[key]
+		delete(previous, key)
+
+		if known && jsonEqual([]byte(content), []byte(stateEvent.ContentJSON.ValueString())) {
+			continue
+		}
+
+		_, err := r.client.SendStateEvent(data.RoomID.ValueString(), stateEvent.Type.ValueString(), stateEvent.StateKey.ValueString(), json.RawMessage(stateEvent.ContentJSON.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", stateEvent.Type.ValueString(), err))
+			return
+		}
+
+		tflog.Trace(ctx, "sent state event", map[string]any{"room_id": data.RoomID.ValueString(), "type": stateEvent.Type.ValueString()})
+	}
+
+	for _, stateEvent := range state.InitialState {
+		if _, removed := previous[stateEvent.Type.ValueString()+"\x00"+stateEvent.StateKey.ValueString()]; removed {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("initial_state"),
+				"State Event No Longer Managed",
+				fmt.Sprintf("The %s state event with state key %q was removed from initial_state. "+
+					"State events cannot be deleted, so its current content stays in the room.",
+					stateEvent.Type.ValueString(), stateEvent.StateKey.ValueString()),
+			)
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.preventDestroy {
+		resp.Diagnostics.AddError(
+			"Room Destruction Prevented",
+			fmt.Sprintf("The provider has prevent_destroy_rooms enabled, so the room %s cannot be destroyed. "+
+				"Disable prevent_destroy_rooms in the provider configuration or remove the room from the state "+
+				"with terraform state rm to stop managing it.", data.RoomID.ValueString()),
+		)
+		return
+	}
+
+	err := destroyRoom(ctx, r.client, data.RoomID.ValueString(), data.OnDestroy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete room, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+
+	// on_destroy only exists in Terraform, assume the default.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), "leave")...)
+}
@@ -0,0 +1,443 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRoomResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room.test", "room_version", "data.matrix_server_capabilities.test", "default_room_version"),
+					resource.TestCheckResourceAttrSet("matrix_room.test", "room_id"),
+					resource.TestCheckResourceAttrPair("matrix_room.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Leaving room_version unset keeps the existing room
+			{
+				Config: testAccRoomResourceConfigDefaultVersion,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room.test", "room_version", "data.matrix_server_capabilities.test", "default_room_version"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccRoomResourceConfig = `
+data "matrix_server_capabilities" "test" {}
+
+resource "matrix_room" "test" {
+  room_version = data.matrix_server_capabilities.test.default_room_version
+}
+`
+
+const testAccRoomResourceConfigDefaultVersion = `
+data "matrix_server_capabilities" "test" {}
+
+resource "matrix_room" "test" {}
+`
+
+func TestAccRoomResource_roomVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unsupported versions fail before creating the room
+			{
+				Config:      testAccRoomResourceConfigRoomVersion("999"),
+				ExpectError: regexp.MustCompile("Unsupported Room Version"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomResourceConfigRoomVersion("9"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "room_version", "9"),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.create", "room_version", "9"),
+				),
+			},
+			// Changing the version replaces the room
+			{
+				Config: testAccRoomResourceConfigRoomVersion("10"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_room.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "room_version", "10"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomResourceConfigRoomVersion(roomVersion string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  room_version = %q
+}
+`, roomVersion)
+}
+
+func TestAccRoomResource_nameTopic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_first_user_id", testAccCreateUser(t, "tf-acc-room-invite-1"))
+			t.Setenv("TF_VAR_second_user_id", testAccCreateUser(t, "tf-acc-room-invite-2"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with name, topic and invites
+			{
+				Config: testAccRoomResourceConfigNameTopic("Lobby", "Say hi", "var.first_user_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "name", "Lobby"),
+					resource.TestCheckResourceAttr("matrix_room.test", "topic", "Say hi"),
+					resource.TestCheckResourceAttr("matrix_room.test", "visibility", "private"),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.name", "name", "Lobby"),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.join_rules", "join_rule", "public"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_room.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"visibility", "preset", "invite"},
+			},
+			// Update testing changes the name and topic in place
+			{
+				Config: testAccRoomResourceConfigNameTopic("Entrance", "Say hello", "var.first_user_id, var.second_user_id"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_room.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "name", "Entrance"),
+					resource.TestCheckResourceAttr("matrix_room.test", "invite.#", "2"),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.topic", "topic", "Say hello"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomResourceConfigNameTopic(name string, topic string, invite string) string {
+	return fmt.Sprintf(`
+variable "first_user_id" {}
+variable "second_user_id" {}
+
+resource "matrix_room" "test" {
+  name       = %q
+  topic      = %q
+  visibility = "private"
+  preset     = "public_chat"
+  invite     = [%s]
+}
+`, name, topic, invite)
+}
+
+func TestAccRoomResource_initialState(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The same state event twice is rejected at plan time
+			{
+				Config: `
+resource "matrix_room" "test" {
+  initial_state = [
+    {
+      type         = "m.room.join_rules"
+      content_json = jsonencode({ join_rule = "invite" })
+    },
+    {
+      type         = "m.room.join_rules"
+      content_json = jsonencode({ join_rule = "knock" })
+    },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Duplicate Initial State Event"),
+			},
+			// Create with initial state
+			{
+				Config: testAccRoomResourceConfigInitialState("invite"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "initial_state.#", "2"),
+					resource.TestCheckResourceAttr("matrix_room.test", "initial_state.0.state_key", ""),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.encryption", "algorithm", "m.megolm.v1.aes-sha2"),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.join_rules", "join_rule", "invite"),
+				),
+			},
+			// Update testing sends the changed state event
+			{
+				Config: testAccRoomResourceConfigInitialState("knock"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.join_rules", "join_rule", "knock"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckRoomStateEvent asserts a top-level string field of a state
+// event with an empty state key in the room of the given resource.
+func testAccCheckRoomStateEvent(t *testing.T, resourceName string, eventType string, field string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		var content map[string]any
+		err := testAccClient(t).StateEvent(rs.Primary.Attributes["room_id"], eventType, "", &content)
+		if err != nil {
+			return err
+		}
+
+		if content[field] != expected {
+			return fmt.Errorf("expected %s of %s to be %q, got %v", field, eventType, expected, content[field])
+		}
+
+		return nil
+	}
+}
+
+func testAccRoomResourceConfigInitialState(joinRule string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  initial_state = [
+    {
+      type         = "m.room.encryption"
+      content_json = jsonencode({ algorithm = "m.megolm.v1.aes-sha2" })
+    },
+    {
+      type         = "m.room.join_rules"
+      content_json = jsonencode({ join_rule = %[1]q })
+    },
+  ]
+}
+`, joinRule)
+}
+
+func TestAccRoomResource_creationContent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Fields with their own attribute are rejected at plan time
+			{
+				Config: `
+resource "matrix_room" "test" {
+  creation_content_json = jsonencode({ "m.federate" = false })
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Creation Content"),
+			},
+			// Create a space
+			{
+				Config: testAccRoomResourceConfigCreationContent,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.create", "type", "m.space"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccRoomResourceConfigCreationContent = `
+resource "matrix_room" "test" {
+  creation_content_json = jsonencode({ type = "m.space" })
+}
+`
+
+func TestAccRoomResource_federate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a room without federation
+			{
+				Config: testAccRoomResourceConfigFederate(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "federate", "false"),
+					resource.TestCheckResourceAttr("matrix_room.test", "creation_content_json", `{"type":"m.space"}`),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Enabling federation replaces the room
+			{
+				Config: testAccRoomResourceConfigFederate(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_room.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "federate", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomResourceConfigFederate(federate bool) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  federate              = %t
+  creation_content_json = jsonencode({ type = "m.space" })
+}
+`, federate)
+}
+
+func TestAccRoomResource_preventDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccRoomResourceConfigPreventDestroy(true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room.test", "room_id"),
+				),
+			},
+			// Destroying the room fails while the safeguard is active
+			{
+				Config:      testAccRoomResourceConfigPreventDestroy(true, false),
+				ExpectError: regexp.MustCompile(`Room Destruction Prevented`),
+			},
+			// Destroying the room works again once it is disabled
+			{
+				Config: testAccRoomResourceConfigPreventDestroy(false, false),
+			},
+		},
+	})
+}
+
+func testAccRoomResourceConfigPreventDestroy(preventDestroy bool, room bool) string {
+	config := fmt.Sprintf(`
+provider "matrix" {
+  prevent_destroy_rooms = %t
+}
+`, preventDestroy)
+
+	if room {
+		config += `
+resource "matrix_room" "test" {}
+`
+	}
+
+	return config
+}
+
+func TestAccRoomResource_onDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRoomDeleted(t),
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccRoomResourceConfigOnDestroy("leave"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "on_destroy", "leave"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing only changes the state
+			{
+				Config: testAccRoomResourceConfigOnDestroy("delete"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_room.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room.test", "on_destroy", "delete"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomResourceConfigOnDestroy(onDestroy string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  on_destroy = %[1]q
+}
+`, onDestroy)
+}
+
+// testAccCheckRoomDeleted asserts that the homeserver no longer knows the
+// rooms of all destroyed matrix_room resources.
+func testAccCheckRoomDeleted(t *testing.T) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "matrix_room" {
+				continue
+			}
+
+			err := client.MakeRequest("GET", synapseAdminURL(client, "v1", "rooms", rs.Primary.Attributes["room_id"]), nil, nil)
+			if err == nil {
+				return fmt.Errorf("room %s still exists", rs.Primary.Attributes["room_id"])
+			}
+			if !isNotFound(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomRetentionResource{}
+var _ resource.ResourceWithImportState = &RoomRetentionResource{}
+var _ resource.ResourceWithValidateConfig = &RoomRetentionResource{}
+
+func NewRoomRetentionResource() resource.Resource {
+	return &RoomRetentionResource{}
+}
+
+// RoomRetentionResource defines the resource implementation.
+type RoomRetentionResource struct {
+	client *gomatrix.Client
+}
+
+// RoomRetentionResourceModel describes the resource data model.
+type RoomRetentionResourceModel struct {
+	RoomID      types.String `tfsdk:"room_id"`
+	MaxLifetime types.Int64  `tfsdk:"max_lifetime"`
+	MinLifetime types.Int64  `tfsdk:"min_lifetime"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// roomRetentionContent is the content of the m.room.retention state event.
+// Empty content removes the policy.
+type roomRetentionContent struct {
+	MaxLifetime *int64 `json:"max_lifetime,omitempty"`
+	MinLifetime *int64 `json:"min_lifetime,omitempty"`
+}
+
+func (r *RoomRetentionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_retention"
+}
+
+func (r *RoomRetentionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.retention` state event, the message retention policy of a room. " +
+			"Homeservers purge messages older than `max_lifetime` and keep them for at least `min_lifetime`. " +
+			"Destroying the resource removes the policy, so the default policy of the homeserver applies again.\n\n" +
+			"Synapse only enforces the policy if `retention` is enabled in its configuration, and may limit the " +
+			"allowed lifetimes with `allowed_lifetime_min` and `allowed_lifetime_max`. " +
+			"The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"max_lifetime": schema.Int64Attribute{
+				MarkdownDescription: "How long messages are kept at most, in milliseconds.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_lifetime": schema.Int64Attribute{
+				MarkdownDescription: "How long messages are kept at least, in milliseconds.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomRetentionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomRetentionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoomRetentionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Empty content is how the policy is removed, so it cannot be managed.
+	if data.MaxLifetime.IsNull() && data.MinLifetime.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing Retention Lifetime",
+			"At least one of max_lifetime and min_lifetime must be set.",
+		)
+		return
+	}
+
+	if !data.MaxLifetime.IsNull() && !data.MaxLifetime.IsUnknown() && !data.MinLifetime.IsNull() && !data.MinLifetime.IsUnknown() &&
+		data.MinLifetime.ValueInt64() > data.MaxLifetime.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_lifetime"),
+			"Invalid Retention Lifetime",
+			fmt.Sprintf("min_lifetime (%d) must not be larger than max_lifetime (%d).", data.MinLifetime.ValueInt64(), data.MaxLifetime.ValueInt64()),
+		)
+	}
+}
+
+// send sends the m.room.retention state event.
+func (r *RoomRetentionResource) send(data RoomRetentionResourceModel) error {
+	content := roomRetentionContent{
+		MaxLifetime: data.MaxLifetime.ValueInt64Pointer(),
+		MinLifetime: data.MinLifetime.ValueInt64Pointer(),
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.retention", "", content)
+	return err
+}
+
+func (r *RoomRetentionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomRetentionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.retention state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set retention policy", map[string]any{"room_id": data.RoomID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomRetentionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomRetentionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content roomRetentionContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.retention", "", &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "retention policy no longer exists, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.retention state event, got error: %s", err))
+		return
+	}
+
+	if content.MaxLifetime == nil && content.MinLifetime == nil {
+		tflog.Warn(ctx, "retention policy was removed, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.MaxLifetime = types.Int64PointerValue(content.MaxLifetime)
+	data.MinLifetime = types.Int64PointerValue(content.MinLifetime)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomRetentionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomRetentionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.retention state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomRetentionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomRetentionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted, empty content removes the policy.
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.retention", "", roomRetentionContent{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove m.room.retention state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomRetentionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomRetentionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomRetentionResourceConfig("max_lifetime = 2592000000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_retention.test", "max_lifetime", "2592000000"),
+					resource.TestCheckNoResourceAttr("matrix_room_retention.test", "min_lifetime"),
+					resource.TestCheckResourceAttrPair("matrix_room_retention.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_retention.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomRetentionResourceConfig("max_lifetime = 2592000000\n  min_lifetime = 86400000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_retention.test", "min_lifetime", "86400000"),
+				),
+			},
+			// The minimum lifetime cannot exceed the maximum
+			{
+				Config:      testAccRoomRetentionResourceConfig("max_lifetime = 86400000\n  min_lifetime = 2592000000"),
+				ExpectError: regexp.MustCompile("Invalid Retention Lifetime"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomRetentionResourceConfig(lifetimes string) string {
+	return `
+resource "matrix_room" "test" {}
+
+resource "matrix_room_retention" "test" {
+  room_id      = matrix_room.test.room_id
+  ` + lifetimes + `
+}
+`
+}
@@ -0,0 +1,350 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomServerACLResource{}
+var _ resource.ResourceWithImportState = &RoomServerACLResource{}
+var _ resource.ResourceWithModifyPlan = &RoomServerACLResource{}
+
+func NewRoomServerACLResource() resource.Resource {
+	return &RoomServerACLResource{}
+}
+
+// RoomServerACLResource defines the resource implementation.
+type RoomServerACLResource struct {
+	client *gomatrix.Client
+}
+
+// RoomServerACLResourceModel describes the resource data model.
+type RoomServerACLResourceModel struct {
+	RoomID          types.String   `tfsdk:"room_id"`
+	Allow           []types.String `tfsdk:"allow"`
+	Deny            []types.String `tfsdk:"deny"`
+	AllowIPLiterals types.Bool     `tfsdk:"allow_ip_literals"`
+	Id              types.String   `tfsdk:"id"`
+}
+
+// roomServerACLContent is the content of the m.room.server_acl state event.
+type roomServerACLContent struct {
+	Allow           []string `json:"allow"`
+	Deny            []string `json:"deny"`
+	AllowIPLiterals bool     `json:"allow_ip_literals"`
+}
+
+// content returns the state event content of the model. Patterns are sorted,
+// so the state event does not change between applies.
+func (m RoomServerACLResourceModel) content() roomServerACLContent {
+	content := roomServerACLContent{
+		Allow:           make([]string, 0, len(m.Allow)),
+		Deny:            make([]string, 0, len(m.Deny)),
+		AllowIPLiterals: m.AllowIPLiterals.ValueBool(),
+	}
+	for _, pattern := range m.Allow {
+		content.Allow = append(content.Allow, pattern.ValueString())
+	}
+	for _, pattern := range m.Deny {
+		content.Deny = append(content.Deny, pattern.ValueString())
+	}
+	sort.Strings(content.Allow)
+	sort.Strings(content.Deny)
+
+	return content
+}
+
+// serverACLPatternRegexp turns a server ACL glob, where `*` matches any
+// characters and `?` a single one, into a regular expression. Like Synapse,
+// server names are compared case-insensitively.
+func serverACLPatternRegexp(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, `.*`)
+	expr = strings.ReplaceAll(expr, `\?`, `.`)
+
+	return regexp.MustCompile(`(?i)^` + expr + `$`)
+}
+
+// allows reports whether the ACL lets a server participate in the room.
+// Ports are not part of the comparison.
+func (c roomServerACLContent) allows(serverName string) bool {
+	host := serverName
+	if splitHost, _, err := net.SplitHostPort(serverName); err == nil {
+		host = splitHost
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	if !c.AllowIPLiterals && net.ParseIP(host) != nil {
+		return false
+	}
+
+	for _, pattern := range c.Deny {
+		if serverACLPatternRegexp(pattern).MatchString(host) {
+			return false
+		}
+	}
+
+	for _, pattern := range c.Allow {
+		if serverACLPatternRegexp(pattern).MatchString(host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RoomServerACLResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_server_acl"
+}
+
+func (r *RoomServerACLResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `m.room.server_acl` state event, which decides which homeservers can participate in a room. " +
+			"Patterns may use `*` for any number of characters and `?` for a single character, and are compared without ports. " +
+			"Changes made in a client show up as drift and are reverted by the next apply. " +
+			"State events cannot be deleted, destroying the resource allows every server again.\n\n" +
+			"An ACL denying the homeserver of the provider user is rejected at plan time, as it would lock the homeserver " +
+			"out of the room. The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"allow": schema.SetAttribute{
+				MarkdownDescription: "Patterns of the servers allowed in the room. Defaults to `[\"*\"]`, " +
+					"so only the servers in `deny` are excluded. Servers matching no pattern are denied.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue("*"),
+				})),
+			},
+			"deny": schema.SetAttribute{
+				MarkdownDescription: "Patterns of the servers denied in the room, e.g. `*.evil.example`. " +
+					"Denying takes precedence over allowing.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allow_ip_literals": schema.BoolAttribute{
+				MarkdownDescription: "Whether servers named by an IP address instead of a domain are allowed. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomServerACLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomServerACLResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	// The room ID is unknown if the room is created in the same apply, which
+	// does not matter for the check.
+	var plan RoomServerACLResourceModel
+	var allow, deny types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow"), &allow)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("deny"), &deny)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_ip_literals"), &plan.AllowIPLiterals)...)
+
+	if resp.Diagnostics.HasError() || allow.IsUnknown() || deny.IsUnknown() || plan.AllowIPLiterals.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(allow.ElementsAs(ctx, &plan.Allow, false)...)
+	resp.Diagnostics.Append(deny.ElementsAs(ctx, &plan.Deny, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, pattern := range append(plan.Allow, plan.Deny...) {
+		if pattern.IsUnknown() {
+			return
+		}
+	}
+
+	parts := strings.SplitN(r.client.UserID, ":", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	if !plan.content().allows(parts[1]) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deny"),
+			"Provider Server Would Be Denied",
+			fmt.Sprintf("The server ACL would deny %s, the homeserver of the provider user, which would lock it out of the room. "+
+				"Allow the homeserver explicitly or narrow down the patterns.", parts[1]),
+		)
+	}
+}
+
+func (r *RoomServerACLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomServerACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.server_acl", "", data.content())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.server_acl state event, got error: %s", err))
+		return
+	}
+
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "set server acl", map[string]any{"room_id": data.RoomID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomServerACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomServerACLResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// IP literals are allowed unless the state event says otherwise.
+	content := roomServerACLContent{AllowIPLiterals: true}
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.server_acl", "", &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "server acl no longer exists, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.server_acl state event, got error: %s", err))
+		return
+	}
+
+	data.Allow = make([]types.String, 0, len(content.Allow))
+	for _, pattern := range content.Allow {
+		data.Allow = append(data.Allow, types.StringValue(pattern))
+	}
+
+	// Keep an empty set as configured instead of turning it into null.
+	if len(content.Deny) > 0 || data.Deny != nil {
+		data.Deny = make([]types.String, 0, len(content.Deny))
+		for _, pattern := range content.Deny {
+			data.Deny = append(data.Deny, types.StringValue(pattern))
+		}
+	}
+
+	data.AllowIPLiterals = types.BoolValue(content.AllowIPLiterals)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomServerACLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomServerACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.server_acl", "", data.content())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.server_acl state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomServerACLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomServerACLResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted. Empty content would deny every
+	// server, so explicitly allow all of them again.
+	content := roomServerACLContent{Allow: []string{"*"}, Deny: []string{}, AllowIPLiterals: true}
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.server_acl", "", content)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset m.room.server_acl state event, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomServerACLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRoomServerACLAllows(t *testing.T) {
+	tests := map[string]struct {
+		acl      roomServerACLContent
+		server   string
+		expected bool
+	}{
+		"allow all": {
+			acl:      roomServerACLContent{Allow: []string{"*"}, AllowIPLiterals: true},
+			server:   "example.com",
+			expected: true,
+		},
+		"no allow": {
+			acl:      roomServerACLContent{AllowIPLiterals: true},
+			server:   "example.com",
+			expected: false,
+		},
+		"denied by wildcard": {
+			acl:      roomServerACLContent{Allow: []string{"*"}, Deny: []string{"*.evil.example"}, AllowIPLiterals: true},
+			server:   "matrix.evil.example",
+			expected: false,
+		},
+		"deny ignores port": {
+			acl:      roomServerACLContent{Allow: []string{"*"}, Deny: []string{"evil.example"}, AllowIPLiterals: true},
+			server:   "evil.example:8448",
+			expected: false,
+		},
+		"single character wildcard": {
+			acl:      roomServerACLContent{Allow: []string{"matrix?.example.com"}, AllowIPLiterals: true},
+			server:   "matrix2.example.com",
+			expected: true,
+		},
+		"case insensitive": {
+			acl:      roomServerACLContent{Allow: []string{"Example.COM"}, AllowIPLiterals: true},
+			server:   "example.com",
+			expected: true,
+		},
+		"dots are literal": {
+			acl:      roomServerACLContent{Allow: []string{"example.com"}, AllowIPLiterals: true},
+			server:   "exampleXcom",
+			expected: false,
+		},
+		"ip literal denied": {
+			acl:      roomServerACLContent{Allow: []string{"*"}},
+			server:   "[::1]:8448",
+			expected: false,
+		},
+		"ip literal allowed": {
+			acl:      roomServerACLContent{Allow: []string{"*"}, AllowIPLiterals: true},
+			server:   "127.0.0.1",
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.acl.allows(test.server)
+			if got != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccRoomServerACLResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomServerACLResourceConfig(`["*.evil.example"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_server_acl.test", "allow.#", "1"),
+					resource.TestCheckTypeSetElemAttr("matrix_room_server_acl.test", "allow.*", "*"),
+					resource.TestCheckTypeSetElemAttr("matrix_room_server_acl.test", "deny.*", "*.evil.example"),
+					resource.TestCheckResourceAttr("matrix_room_server_acl.test", "allow_ip_literals", "true"),
+					resource.TestCheckResourceAttrPair("matrix_room_server_acl.test", "id", "matrix_room.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_server_acl.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomServerACLResourceConfig(`["*.evil.example", "spam.example"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_server_acl.test", "deny.#", "2"),
+				),
+			},
+			// Denying the homeserver of the provider user is rejected
+			{
+				Config:      testAccRoomServerACLResourceConfig(`["*"]`),
+				ExpectError: regexp.MustCompile("Provider Server Would Be Denied"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomServerACLResourceConfig(deny string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_server_acl" "test" {
+  room_id = matrix_room.test.room_id
+  deny    = %s
+}
+`, deny)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomStateEventResource{}
+var _ resource.ResourceWithImportState = &RoomStateEventResource{}
+
+func NewRoomStateEventResource() resource.Resource {
+	return &RoomStateEventResource{}
+}
+
+// RoomStateEventResource defines the resource implementation.
+type RoomStateEventResource struct {
+	client *gomatrix.Client
+}
+
+// RoomStateEventResourceModel describes the resource data model.
+type RoomStateEventResourceModel struct {
+	RoomID   types.String         `tfsdk:"room_id"`
+	Type     types.String         `tfsdk:"type"`
+	StateKey types.String         `tfsdk:"state_key"`
+	Content  jsontypes.Normalized `tfsdk:"content"`
+	Id       types.String         `tfsdk:"id"`
+}
+
+func (r *RoomStateEventResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_state_event"
+}
+
+func (r *RoomStateEventResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages any state event of a room by its type and state key, e.g. for state events of MSCs " +
+			"the provider has no resource for. Changes made outside of Terraform show up as drift. " +
+			"State events cannot be deleted, destroying the resource sends the event with empty content.\n\n" +
+			"Unlike `matrix_room_event`, which tracks a single sent event, this resource tracks whatever the current " +
+			"state event is. The provider user must be allowed to send the state event.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the state event, e.g. `org.example.settings`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"state_key": schema.StringAttribute{
+				MarkdownDescription: "The state key of the state event. Defaults to an empty string.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the state event as JSON object, e.g. from `jsonencode`. " +
+					"Differences in key order or whitespace are ignored.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/type/state_key`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomStateEventResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomStateEventResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomStateEventResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.Type.ValueString(), data.StateKey.ValueString(), json.RawMessage(data.Content.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", data.Type.ValueString(), err))
+		return
+	}
+
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.Type.ValueString() + "/" + data.StateKey.ValueString())
+
+	tflog.Trace(ctx, "sent state event", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomStateEventResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomStateEventResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content json.RawMessage
+	err := r.client.StateEvent(data.RoomID.ValueString(), data.Type.ValueString(), data.StateKey.ValueString(), &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "state event no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", data.Type.ValueString(), err))
+		return
+	}
+
+	// Empty content is how state events are removed, unless it is what was
+	// configured.
+	if jsonEqual(content, []byte("{}")) && !jsonEqual([]byte(data.Content.ValueString()), []byte("{}")) {
+		tflog.Warn(ctx, "state event was cleared, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Semantic equality keeps the configured formatting unless the content
+	// really changed.
+	data.Content = jsontypes.NewNormalizedValue(string(content))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomStateEventResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomStateEventResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.Type.ValueString(), data.StateKey.ValueString(), json.RawMessage(data.Content.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send %s state event, got error: %s", data.Type.ValueString(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomStateEventResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomStateEventResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.Type.ValueString(), data.StateKey.ValueString(), struct{}{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear %s state event, got error: %s", data.Type.ValueString(), err))
+		return
+	}
+}
+
+func (r *RoomStateEventResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The state key is often empty, which importCompositeID does not allow.
+	if strings.HasSuffix(req.ID, "/") && strings.Count(req.ID, "/") == 2 {
+		parts := importCompositeID(ctx, resource.ImportStateRequest{ID: strings.TrimSuffix(req.ID, "/")}, resp, "room_id", "type")
+		if parts != nil {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("state_key"), "")...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+		}
+		return
+	}
+
+	importCompositeID(ctx, req, resp, "room_id", "type", "state_key")
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomStateEventResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoomStateEventResourceConfig("blue"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_state_event.test", "state_key", ""),
+					testAccCheckRoomStateEvent(t, "matrix_room_state_event.test", "org.example.settings", "color", "blue"),
+				),
+			},
+			// ImportState testing with an empty state key
+			{
+				ResourceName:      "matrix_room_state_event.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing sends the new content
+			{
+				Config: testAccRoomStateEventResourceConfig("green"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoomStateEvent(t, "matrix_room_state_event.test", "org.example.settings", "color", "green"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomStateEventResourceConfig(color string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {}
+
+resource "matrix_room_state_event" "test" {
+  room_id = matrix_room.test.room_id
+  type    = "org.example.settings"
+  content = jsonencode({ color = %q })
+}
+`, color)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoomStateSnapshotDataSource{}
+
+func NewRoomStateSnapshotDataSource() datasource.DataSource {
+	return &RoomStateSnapshotDataSource{}
+}
+
+// RoomStateSnapshotDataSource defines the data source implementation.
+type RoomStateSnapshotDataSource struct {
+	client *gomatrix.Client
+}
+
+// RoomStateSnapshotDataSourceModel describes the data source data model.
+type RoomStateSnapshotDataSourceModel struct {
+	RoomID     types.String                  `tfsdk:"room_id"`
+	TypeFilter []types.String                `tfsdk:"type_filter"`
+	Events     []RoomStateSnapshotEventModel `tfsdk:"events"`
+	StateHash  types.String                  `tfsdk:"state_hash"`
+	Id         types.String                  `tfsdk:"id"`
+}
+
+// RoomStateSnapshotEventModel describes a single state event.
+type RoomStateSnapshotEventModel struct {
+	Type           types.String `tfsdk:"type"`
+	StateKey       types.String `tfsdk:"state_key"`
+	ContentJSON    types.String `tfsdk:"content_json"`
+	Sender         types.String `tfsdk:"sender"`
+	OriginServerTs types.Int64  `tfsdk:"origin_server_ts"`
+	EventID        types.String `tfsdk:"event_id"`
+}
+
+// roomStateEvent is a state event as returned by the client-server API.
+type roomStateEvent struct {
+	Type           string          `json:"type"`
+	StateKey       string          `json:"state_key"`
+	Content        json.RawMessage `json:"content"`
+	Sender         string          `json:"sender"`
+	OriginServerTs int64           `json:"origin_server_ts"`
+	EventID        string          `json:"event_id"`
+}
+
+// sortRoomStateEvents sorts state events by type and state key, the order
+// the state hash is computed in.
+func sortRoomStateEvents(events []roomStateEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Type != events[j].Type {
+			return events[i].Type < events[j].Type
+		}
+		return events[i].StateKey < events[j].StateKey
+	})
+}
+
+// roomStateHash returns the hex encoded SHA-256 hash of the canonical JSON of
+// the sorted state events.
+func roomStateHash(events []roomStateEvent) (string, error) {
+	sorted := make([]roomStateEvent, len(events))
+	copy(sorted, events)
+	sortRoomStateEvents(sorted)
+
+	encoded, err := json.Marshal(sorted)
+	if err != nil {
+		return "", err
+	}
+
+	canonical, err := canonicalJSON(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(canonical)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (d *RoomStateSnapshotDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_state_snapshot"
+}
+
+func (d *RoomStateSnapshotDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads all current state events of a room, e.g. to audit or migrate rooms. " +
+			"The `state_hash` changes whenever any of the returned events changes.\n\n" +
+			"The provider user must be able to see the room state, usually by being joined to the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"type_filter": schema.ListAttribute{
+				MarkdownDescription: "Only return state events of these types. Returns all state events if unset.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "The state events, sorted by type and state key.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the event.",
+							Computed:            true,
+						},
+						"state_key": schema.StringAttribute{
+							MarkdownDescription: "The state key of the event.",
+							Computed:            true,
+						},
+						"content_json": schema.StringAttribute{
+							MarkdownDescription: "The content of the event as canonical JSON.",
+							Computed:            true,
+						},
+						"sender": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who sent the event.",
+							Computed:            true,
+						},
+						"origin_server_ts": schema.Int64Attribute{
+							MarkdownDescription: "When the event was sent, in milliseconds since the epoch.",
+							Computed:            true,
+						},
+						"event_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the event.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"state_hash": schema.StringAttribute{
+				MarkdownDescription: "The hex encoded SHA-256 hash of the canonical JSON of `events`.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoomStateSnapshotDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *RoomStateSnapshotDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoomStateSnapshotDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state []roomStateEvent
+	err := d.client.MakeRequest("GET", d.client.BuildURL("rooms", data.RoomID.ValueString(), "state"), nil, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room state, got error: %s", err))
+		return
+	}
+
+	if data.TypeFilter != nil {
+		wanted := make(map[string]bool, len(data.TypeFilter))
+		for _, eventType := range data.TypeFilter {
+			wanted[eventType.ValueString()] = true
+		}
+
+		filtered := make([]roomStateEvent, 0, len(state))
+		for _, event := range state {
+			if wanted[event.Type] {
+				filtered = append(filtered, event)
+			}
+		}
+		state = filtered
+	}
+
+	sortRoomStateEvents(state)
+
+	data.Events = make([]RoomStateSnapshotEventModel, 0, len(state))
+	for _, event := range state {
+		content, err := canonicalJSON(event.Content)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode content of %s, got error: %s", event.EventID, err))
+			return
+		}
+
+		data.Events = append(data.Events, RoomStateSnapshotEventModel{
+			Type:           types.StringValue(event.Type),
+			StateKey:       types.StringValue(event.StateKey),
+			ContentJSON:    types.StringValue(string(content)),
+			Sender:         types.StringValue(event.Sender),
+			OriginServerTs: types.Int64Value(event.OriginServerTs),
+			EventID:        types.StringValue(event.EventID),
+		})
+	}
+
+	hash, err := roomStateHash(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to hash room state, got error: %s", err))
+		return
+	}
+
+	data.StateHash = types.StringValue(hash)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "read room state snapshot", map[string]any{"room_id": data.RoomID.ValueString(), "count": len(data.Events)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRoomStateHash(t *testing.T) {
+	t.Parallel()
+
+	name := roomStateEvent{Type: "m.room.name", Content: json.RawMessage(`{"name":"Lobby"}`), EventID: "$a"}
+	topic := roomStateEvent{Type: "m.room.topic", Content: json.RawMessage(`{"topic":"Hi"}`), EventID: "$b"}
+
+	hash := func(events ...roomStateEvent) string {
+		t.Helper()
+
+		result, err := roomStateHash(events)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return result
+	}
+
+	if hash(name, topic) != hash(topic, name) {
+		t.Fatal("expected the hash to not depend on the event order")
+	}
+
+	reformatted := name
+	reformatted.Content = json.RawMessage("{ \"name\": \"Lobby\" }")
+	if hash(name, topic) != hash(reformatted, topic) {
+		t.Fatal("expected the hash to not depend on the content formatting")
+	}
+
+	renamed := name
+	renamed.Content = json.RawMessage(`{"name":"Hall"}`)
+	if hash(name, topic) == hash(renamed, topic) {
+		t.Fatal("expected the hash to change with the content")
+	}
+}
+
+func TestAccRoomStateSnapshotDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccRoomStateSnapshotDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.matrix_room_state_snapshot.test", "id", "data.matrix_room_state_snapshot.test", "room_id"),
+					resource.TestCheckResourceAttr("data.matrix_room_state_snapshot.test", "events.#", "2"),
+					resource.TestCheckResourceAttr("data.matrix_room_state_snapshot.test", "events.0.type", "m.room.create"),
+					resource.TestCheckResourceAttr("data.matrix_room_state_snapshot.test", "events.1.type", "m.room.join_rules"),
+					resource.TestCheckResourceAttr("data.matrix_room_state_snapshot.test", "events.1.content_json", `{"join_rule":"invite"}`),
+					resource.TestMatchResourceAttr("data.matrix_room_state_snapshot.test", "state_hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccRoomStateSnapshotDataSourceConfig = `
+variable "room_id" {}
+
+data "matrix_room_state_snapshot" "test" {
+  room_id     = var.room_id
+  type_filter = ["m.room.join_rules", "m.room.create"]
+}
+`
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomTagResource{}
+var _ resource.ResourceWithImportState = &RoomTagResource{}
+
+// roomTagRegexp rejects tags in the reserved m. namespace that the
+// specification does not define.
+var roomTagRegexp = regexp.MustCompile(`^(m\.(favourite|lowpriority|server_notice)$|[^m]|m$|m[^.])`)
+
+func NewRoomTagResource() resource.Resource {
+	return &RoomTagResource{}
+}
+
+// RoomTagResource defines the resource implementation.
+type RoomTagResource struct {
+	client *gomatrix.Client
+}
+
+// RoomTagResourceModel describes the resource data model.
+type RoomTagResourceModel struct {
+	UserID types.String  `tfsdk:"user_id"`
+	RoomID types.String  `tfsdk:"room_id"`
+	Tag    types.String  `tfsdk:"tag"`
+	Order  types.Float64 `tfsdk:"order"`
+	Id     types.String  `tfsdk:"id"`
+}
+
+// roomTagContent is the content of a single tag in m.tag.
+type roomTagContent struct {
+	Order *float64 `json:"order,omitempty"`
+}
+
+// tagURL builds the URL of a tag of a room.
+func (r *RoomTagResource) tagURL(data RoomTagResourceModel) string {
+	return r.client.BuildURL("user", data.UserID.ValueString(), "rooms", data.RoomID.ValueString(), "tags", data.Tag.ValueString())
+}
+
+func (r *RoomTagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_tag"
+}
+
+func (r *RoomTagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tags a room for the provider user, e.g. as favourite or low priority, which clients use to " +
+			"sort the room list. Tags are private to the provider user. Destroying the resource removes the tag.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user owning the tag. Users can only access their own tags, " +
+					"so this defaults to and must be the provider user.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to tag.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "The tag, `m.favourite`, `m.lowpriority` or a custom tag, which should start with `u.`, " +
+					"e.g. `u.work`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(roomTagRegexp, "value must be m.favourite, m.lowpriority, m.server_notice or a tag outside the m. namespace"),
+				},
+			},
+			"order": schema.Float64Attribute{
+				MarkdownDescription: "The position of the room among the rooms with the same tag, between `0` and `1`. " +
+					"Rooms with lower values come first.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/room_id/tag`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomTagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *RoomTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomTagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsUnknown() {
+		data.UserID = types.StringValue(r.client.UserID)
+	}
+
+	err := r.client.MakeRequest("PUT", r.tagURL(data), roomTagContent{Order: data.Order.ValueFloat64Pointer()}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to tag room, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + data.RoomID.ValueString() + "/" + data.Tag.ValueString())
+
+	tflog.Trace(ctx, "tagged room", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomTagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags struct {
+		Tags map[string]roomTagContent `json:"tags"`
+	}
+	err := r.client.MakeRequest("GET", r.client.BuildURL("user", data.UserID.ValueString(), "rooms", data.RoomID.ValueString(), "tags"), nil, &tags)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room tags, got error: %s", err))
+		return
+	}
+
+	tag, ok := tags.Tags[data.Tag.ValueString()]
+	if !ok {
+		tflog.Warn(ctx, "room tag no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Order = types.Float64PointerValue(tag.Order)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomTagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("PUT", r.tagURL(data), roomTagContent{Order: data.Order.ValueFloat64Pointer()}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to tag room, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomTagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("DELETE", r.tagURL(data), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove room tag, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoomTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id", "room_id", "tag")
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoomTagResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccRoomTagResourceConfig("m.important", "0.5"),
+				ExpectError: regexp.MustCompile(`m.favourite, m.lowpriority`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomTagResourceConfig("m.favourite", "0.5"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_room_tag.test", "user_id"),
+					resource.TestCheckResourceAttr("matrix_room_tag.test", "order", "0.5"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_tag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoomTagResourceConfig("m.favourite", "0.25"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_tag.test", "order", "0.25"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomTagResourceConfig(tag string, order string) string {
+	return fmt.Sprintf(`
+variable "room_id" {}
+
+resource "matrix_room_tag" "test" {
+  room_id = var.room_id
+  tag     = %q
+  order   = %s
+}
+`, tag, order)
+}
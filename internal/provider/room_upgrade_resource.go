@@ -0,0 +1,447 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoomUpgradeResource{}
+var _ resource.ResourceWithImportState = &RoomUpgradeResource{}
+
+func NewRoomUpgradeResource() resource.Resource {
+	return &RoomUpgradeResource{}
+}
+
+// RoomUpgradeResource defines the resource implementation.
+type RoomUpgradeResource struct {
+	client *gomatrix.Client
+}
+
+// RoomUpgradeResourceModel describes the resource data model.
+type RoomUpgradeResourceModel struct {
+	RoomID            types.String   `tfsdk:"room_id"`
+	NewVersion        types.String   `tfsdk:"new_version"`
+	Aliases           []types.String `tfsdk:"aliases"`
+	SpaceIDs          []types.String `tfsdk:"space_ids"`
+	ReplacementRoomID types.String   `tfsdk:"replacement_room_id"`
+	Id                types.String   `tfsdk:"id"`
+}
+
+// roomPowerLevels is the part of the m.room.power_levels content needed to
+// tell whether a user may send a state event. A room without the event
+// allows state events from everyone at level 0.
+type roomPowerLevels struct {
+	Users        map[string]int64 `json:"users"`
+	UsersDefault int64            `json:"users_default"`
+	Events       map[string]int64 `json:"events"`
+	StateDefault *int64           `json:"state_default"`
+}
+
+// missingRoomPowerLevels returns the power levels of a room without an
+// m.room.power_levels event.
+func missingRoomPowerLevels() roomPowerLevels {
+	stateDefault := int64(0)
+	return roomPowerLevels{StateDefault: &stateDefault}
+}
+
+// stateEventLevel returns the power level required to send a state event of
+// the given type.
+func (p roomPowerLevels) stateEventLevel(eventType string) int64 {
+	if level, ok := p.Events[eventType]; ok {
+		return level
+	}
+	if p.StateDefault != nil {
+		return *p.StateDefault
+	}
+
+	// The specification default if m.room.power_levels exists.
+	return 50
+}
+
+// userLevel returns the power level of a user.
+func (p roomPowerLevels) userLevel(userID string) int64 {
+	if level, ok := p.Users[userID]; ok {
+		return level
+	}
+
+	return p.UsersDefault
+}
+
+func (r *RoomUpgradeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_upgrade"
+}
+
+func (r *RoomUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Upgrades a room to a new room version. The homeserver creates a replacement room and " +
+			"sends an `m.room.tombstone` event pointing to it in the old room. " +
+			"Upgrades cannot be reverted, destroying the resource does nothing on the homeserver.\n\n" +
+			"`aliases` and `space_ids` are moved to the replacement room after the upgrade, and again whenever entries " +
+			"are added. Resources managing them, e.g. `matrix_room_alias` or `matrix_space_child`, should use " +
+			"`replacement_room_id` afterwards, otherwise they point back to the old room on the next apply.\n\n" +
+			"The provider user must be allowed to send `m.room.tombstone` events in the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to upgrade.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"new_version": schema.StringAttribute{
+				MarkdownDescription: "The room version to upgrade to. Must be one of the `available_room_versions` of the homeserver.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aliases": schema.SetAttribute{
+				MarkdownDescription: "Room aliases to point to the replacement room if they point to the upgraded room. " +
+					"Synapse already moves local aliases itself, this covers aliases it does not.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixRoomAlias()),
+				},
+			},
+			"space_ids": schema.SetAttribute{
+				MarkdownDescription: "Spaces in which the replacement room takes the place of the upgraded room, " +
+					"keeping the `via`, `order` and `suggested` of the `m.space.child` event.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixRoomID()),
+				},
+			},
+			"replacement_room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room replacing the upgraded one.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the upgraded room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// aliasMoved reports whether an alias no longer needs to be moved, because it
+// points to the replacement room or a room other than the upgraded one.
+func (r *RoomUpgradeResource) aliasMoved(data RoomUpgradeResourceModel, alias string) (bool, error) {
+	existing, err := getRoomAlias(r.client, alias)
+	if err != nil {
+		return false, fmt.Errorf("unable to read room alias %s: %w", alias, err)
+	}
+
+	return existing != nil && existing.RoomID != data.RoomID.ValueString(), nil
+}
+
+// moveAliases points the aliases which were not moved yet, e.g. by Synapse,
+// to the replacement room.
+func (r *RoomUpgradeResource) moveAliases(data RoomUpgradeResourceModel) error {
+	for _, alias := range data.Aliases {
+		moved, err := r.aliasMoved(data, alias.ValueString())
+		if err != nil {
+			return err
+		}
+		if moved {
+			continue
+		}
+
+		err = r.client.MakeRequest("DELETE", r.client.BuildURL("directory", "room", alias.ValueString()), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("unable to delete room alias %s: %w", alias.ValueString(), err)
+		}
+
+		err = r.client.MakeRequest("PUT", r.client.BuildURL("directory", "room", alias.ValueString()), map[string]string{
+			"room_id": data.ReplacementRoomID.ValueString(),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("unable to create room alias %s: %w", alias.ValueString(), err)
+		}
+	}
+
+	return nil
+}
+
+// spaceChild returns the m.space.child content of the upgraded room in a
+// space, which has no via once the room was moved or removed.
+func (r *RoomUpgradeResource) spaceChild(data RoomUpgradeResourceModel, spaceID string) (spaceChildContent, error) {
+	var child spaceChildContent
+	err := r.client.StateEvent(spaceID, "m.space.child", data.RoomID.ValueString(), &child)
+	if err != nil && !isNotFound(err) {
+		return child, fmt.Errorf("unable to read m.space.child state event in %s: %w", spaceID, err)
+	}
+
+	return child, nil
+}
+
+// moveSpaceChildren replaces the upgraded room with the replacement room in
+// the spaces, keeping the content of the m.space.child event.
+func (r *RoomUpgradeResource) moveSpaceChildren(data RoomUpgradeResourceModel) error {
+	for _, spaceID := range data.SpaceIDs {
+		child, err := r.spaceChild(data, spaceID.ValueString())
+		if err != nil {
+			return err
+		}
+		if len(child.Via) == 0 {
+			continue
+		}
+
+		_, err = r.client.SendStateEvent(spaceID.ValueString(), "m.space.child", data.ReplacementRoomID.ValueString(), child)
+		if err != nil {
+			return fmt.Errorf("unable to add replacement room to %s: %w", spaceID.ValueString(), err)
+		}
+
+		_, err = r.client.SendStateEvent(spaceID.ValueString(), "m.space.child", data.RoomID.ValueString(), spaceChildContent{})
+		if err != nil {
+			return fmt.Errorf("unable to remove upgraded room from %s: %w", spaceID.ValueString(), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RoomUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomUpgradeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	capabilities, err := getCapabilities(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read available room versions, got error: %s", err))
+		return
+	}
+
+	if message := capabilities.unsupportedRoomVersion(data.NewVersion.ValueString()); message != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("new_version"), "Unsupported Room Version", message)
+		return
+	}
+
+	// Check the power level up front, the error of the upgrade endpoint does
+	// not tell what is missing.
+	var powerLevels roomPowerLevels
+	err = r.client.StateEvent(roomID, "m.room.power_levels", "", &powerLevels)
+	if err != nil {
+		if !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room power levels, got error: %s", err))
+			return
+		}
+
+		powerLevels = missingRoomPowerLevels()
+	}
+
+	userLevel := powerLevels.userLevel(r.client.UserID)
+	requiredLevel := powerLevels.stateEventLevel("m.room.tombstone")
+	if userLevel < requiredLevel {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("room_id"),
+			"Insufficient Power Level",
+			fmt.Sprintf("Upgrading %s needs power level %d to send m.room.tombstone, but %s only has power level %d.",
+				roomID, requiredLevel, r.client.UserID, userLevel),
+		)
+		return
+	}
+
+	var upgradeResp struct {
+		ReplacementRoom string `json:"replacement_room"`
+	}
+	err = r.client.MakeRequest("POST", r.client.BuildURL("rooms", roomID, "upgrade"), map[string]string{
+		"new_version": data.NewVersion.ValueString(),
+	}, &upgradeResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upgrade room, got error: %s", err))
+		return
+	}
+
+	data.ReplacementRoomID = types.StringValue(upgradeResp.ReplacementRoom)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "upgraded room", map[string]any{"room_id": roomID, "replacement_room_id": upgradeResp.ReplacementRoom})
+
+	// The upgrade cannot be undone, so a failed move must not taint the
+	// resource. Read notices what was not moved and the next apply retries.
+	err = r.moveAliases(data)
+	if err == nil {
+		err = r.moveSpaceChildren(data)
+	}
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Room Upgraded Partially",
+			fmt.Sprintf("The room was upgraded to %s, but moving its aliases and spaces failed and is retried on the next apply: %s",
+				upgradeResp.ReplacementRoom, err),
+		)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomUpgradeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tombstone struct {
+		ReplacementRoom string `json:"replacement_room"`
+	}
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.tombstone", "", &tombstone)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "room has no tombstone, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.tombstone state event, got error: %s", err))
+		return
+	}
+
+	if tombstone.ReplacementRoom != data.ReplacementRoomID.ValueString() {
+		tflog.Warn(ctx, "room tombstone points to another replacement room", map[string]any{
+			"room_id":             data.RoomID.ValueString(),
+			"replacement_room_id": tombstone.ReplacementRoom,
+		})
+	}
+	data.ReplacementRoomID = types.StringValue(tombstone.ReplacementRoom)
+
+	// After import, the version is only known from the replacement room.
+	if data.NewVersion.IsNull() {
+		var create roomCreateContent
+		err := r.client.StateEvent(tombstone.ReplacementRoom, "m.room.create", "", &create)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read version of the replacement room, got error: %s", err))
+			return
+		}
+
+		data.NewVersion = types.StringValue(create.RoomVersion)
+	}
+
+	// Aliases and spaces which were not moved show up as drift, so the next
+	// apply moves them. Empty sets stay as configured instead of null.
+	if data.Aliases != nil {
+		aliases := make([]types.String, 0, len(data.Aliases))
+		for _, alias := range data.Aliases {
+			moved, err := r.aliasMoved(data, alias.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check moved room aliases, got error: %s", err))
+				return
+			}
+			if moved {
+				aliases = append(aliases, alias)
+			}
+		}
+		data.Aliases = aliases
+	}
+
+	if data.SpaceIDs != nil {
+		spaceIDs := make([]types.String, 0, len(data.SpaceIDs))
+		for _, spaceID := range data.SpaceIDs {
+			child, err := r.spaceChild(data, spaceID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check moved spaces, got error: %s", err))
+				return
+			}
+			if len(child.Via) == 0 {
+				spaceIDs = append(spaceIDs, spaceID)
+			}
+		}
+		data.SpaceIDs = spaceIDs
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomUpgradeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Moving skips what was already moved, so only new entries change.
+	err := r.moveAliases(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to move room aliases to the replacement room, got error: %s", err))
+		return
+	}
+
+	err = r.moveSpaceChildren(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to move the room in its spaces, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Room upgrades cannot be reverted, only forget the resource.
+}
+
+func (r *RoomUpgradeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+}
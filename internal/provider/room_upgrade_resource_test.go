@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestRoomPowerLevels(t *testing.T) {
+	stateDefault := int64(75)
+	powerLevels := roomPowerLevels{
+		Users:        map[string]int64{"@admin:example.com": 100},
+		UsersDefault: 10,
+		Events:       map[string]int64{"m.room.tombstone": 100},
+		StateDefault: &stateDefault,
+	}
+
+	if level := powerLevels.userLevel("@admin:example.com"); level != 100 {
+		t.Errorf("expected admin level 100, got %d", level)
+	}
+	if level := powerLevels.userLevel("@someone:example.com"); level != 10 {
+		t.Errorf("expected default user level 10, got %d", level)
+	}
+	if level := powerLevels.stateEventLevel("m.room.tombstone"); level != 100 {
+		t.Errorf("expected tombstone level 100, got %d", level)
+	}
+	if level := powerLevels.stateEventLevel("m.room.topic"); level != 75 {
+		t.Errorf("expected state default 75, got %d", level)
+	}
+	if level := (roomPowerLevels{}).stateEventLevel("m.room.topic"); level != 50 {
+		t.Errorf("expected specification state default 50, got %d", level)
+	}
+	if level := missingRoomPowerLevels().stateEventLevel("m.room.tombstone"); level != 0 {
+		t.Errorf("expected level 0 without m.room.power_levels, got %d", level)
+	}
+}
+
+func TestAccRoomUpgradeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unsupported versions fail before upgrading
+			{
+				Config:      testAccRoomUpgradeResourceConfig("999"),
+				ExpectError: regexp.MustCompile("Unsupported Room Version"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoomUpgradeResourceConfig("10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_room_upgrade.test", "room_id", "matrix_room.test", "room_id"),
+					resource.TestCheckResourceAttr("matrix_room_upgrade.test", "new_version", "10"),
+					resource.TestCheckResourceAttrSet("matrix_room_upgrade.test", "replacement_room_id"),
+					testAccCheckRoomStateEvent(t, "matrix_room.test", "m.room.tombstone", "body", "This room has been replaced"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_room_upgrade.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoomUpgradeResourceConfig(newVersion string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  room_version = "9"
+}
+
+resource "matrix_room_upgrade" "test" {
+  room_id     = matrix_room.test.room_id
+  new_version = %q
+}
+`, newVersion)
+}
+
+func TestAccRoomUpgradeResource_moveSpaceChildren(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			// The space and its child are created outside of Terraform, as
+			// resources managing them would point back to the old room.
+			client := testAccClient(t)
+			var room, space gomatrix.RespCreateRoom
+			err := client.MakeRequest("POST", client.BuildURL("createRoom"), createRoomRequest{RoomVersion: "9"}, &room)
+			if err != nil {
+				t.Fatalf("unable to create test room: %s", err)
+			}
+			err = client.MakeRequest("POST", client.BuildURL("createRoom"), createRoomRequest{
+				ReqCreateRoom: gomatrix.ReqCreateRoom{CreationContent: map[string]interface{}{"type": spaceRoomType}},
+			}, &space)
+			if err != nil {
+				t.Fatalf("unable to create test space: %s", err)
+			}
+			_, err = client.SendStateEvent(space.RoomID, "m.space.child", room.RoomID, spaceChildContent{
+				Via:       []string{testAccServerName()},
+				Suggested: true,
+			})
+			if err != nil {
+				t.Fatalf("unable to add test room to space: %s", err)
+			}
+
+			t.Setenv("TF_VAR_room_id", room.RoomID)
+			t.Setenv("TF_VAR_space_id", space.RoomID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+variable "room_id" {}
+variable "space_id" {}
+
+resource "matrix_room_upgrade" "test" {
+  room_id     = var.room_id
+  new_version = "10"
+  space_ids   = [var.space_id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_room_upgrade.test", "space_ids.#", "1"),
+					testAccCheckSpaceChildMoved(t, "matrix_room_upgrade.test"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSpaceChildMoved checks that the replacement room took the place
+// of the upgraded room in the spaces of a matrix_room_upgrade.
+func testAccCheckSpaceChildMoved(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		client := testAccClient(t)
+		spaceID := rs.Primary.Attributes["space_ids.0"]
+
+		var replacement, upgraded spaceChildContent
+		err := client.StateEvent(spaceID, "m.space.child", rs.Primary.Attributes["replacement_room_id"], &replacement)
+		if err != nil {
+			return err
+		}
+		err = client.StateEvent(spaceID, "m.space.child", rs.Primary.Attributes["room_id"], &upgraded)
+		if err != nil {
+			return err
+		}
+
+		if len(replacement.Via) == 0 || !replacement.Suggested {
+			return fmt.Errorf("expected the replacement room to keep via and suggested, got %+v", replacement)
+		}
+		if len(upgraded.Via) != 0 {
+			return fmt.Errorf("expected the upgraded room to be removed from %s, got %+v", spaceID, upgraded)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerCapabilitiesDataSource{}
+
+func NewServerCapabilitiesDataSource() datasource.DataSource {
+	return &ServerCapabilitiesDataSource{}
+}
+
+// ServerCapabilitiesDataSource defines the data source implementation.
+type ServerCapabilitiesDataSource struct {
+	client *gomatrix.Client
+}
+
+// ServerCapabilitiesDataSourceModel describes the data source data model.
+type ServerCapabilitiesDataSourceModel struct {
+	DefaultRoomVersion    types.String            `tfsdk:"default_room_version"`
+	AvailableRoomVersions map[string]types.String `tfsdk:"available_room_versions"`
+	ChangePassword        types.Bool              `tfsdk:"change_password"`
+	SetDisplayname        types.Bool              `tfsdk:"set_displayname"`
+	SetAvatarURL          types.Bool              `tfsdk:"set_avatar_url"`
+	ThreepidChanges       types.Bool              `tfsdk:"threepid_changes"`
+	Id                    types.String            `tfsdk:"id"`
+}
+
+// matrixCapability is a boolean capability. The specification says clients
+// should assume it is enabled if the homeserver does not report it.
+type matrixCapability struct {
+	Enabled bool `json:"enabled"`
+}
+
+// matrixCapabilities is the response of the client-server API capabilities
+// endpoint.
+type matrixCapabilities struct {
+	Capabilities struct {
+		RoomVersions struct {
+			Default   string            `json:"default"`
+			Available map[string]string `json:"available"`
+		} `json:"m.room_versions"`
+		ChangePassword  *matrixCapability `json:"m.change_password"`
+		SetDisplayname  *matrixCapability `json:"m.set_displayname"`
+		SetAvatarURL    *matrixCapability `json:"m.set_avatar_url"`
+		ThreepidChanges *matrixCapability `json:"m.3pid_changes"`
+	} `json:"capabilities"`
+}
+
+// getCapabilities queries the capabilities of the homeserver for the
+// provider user.
+func getCapabilities(client *gomatrix.Client) (*matrixCapabilities, error) {
+	var capabilities matrixCapabilities
+	err := client.MakeRequest("GET", client.BuildURL("capabilities"), nil, &capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &capabilities, nil
+}
+
+// unsupportedRoomVersion returns a message naming the available room versions
+// if the homeserver does not support the given one, an empty string otherwise.
+func (c *matrixCapabilities) unsupportedRoomVersion(version string) string {
+	available := c.Capabilities.RoomVersions.Available
+	if _, ok := available[version]; ok {
+		return ""
+	}
+
+	versions := make([]string, 0, len(available))
+	for version := range available {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	return fmt.Sprintf("The homeserver does not support room version %q. Available versions: %s.", version, strings.Join(versions, ", "))
+}
+
+// capabilityEnabled applies the specification default to a boolean capability.
+func capabilityEnabled(capability *matrixCapability) types.Bool {
+	return types.BoolValue(capability == nil || capability.Enabled)
+}
+
+func (d *ServerCapabilitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_capabilities"
+}
+
+func (d *ServerCapabilitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the capabilities of the homeserver for the provider user, e.g. the supported room versions.",
+
+		Attributes: map[string]schema.Attribute{
+			"default_room_version": schema.StringAttribute{
+				MarkdownDescription: "The room version the homeserver uses for new rooms.",
+				Computed:            true,
+			},
+			"available_room_versions": schema.MapAttribute{
+				MarkdownDescription: "The room versions the homeserver supports, mapped to their stability (`stable` or `unstable`).",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"change_password": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can change their password.",
+				Computed:            true,
+			},
+			"set_displayname": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can change their display name.",
+				Computed:            true,
+			},
+			"set_avatar_url": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can change their avatar.",
+				Computed:            true,
+			},
+			"threepid_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can add, remove or change third-party identifiers.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ServerCapabilitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *ServerCapabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerCapabilitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilities, err := getCapabilities(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server capabilities, got error: %s", err))
+		return
+	}
+
+	data.DefaultRoomVersion = types.StringValue(capabilities.Capabilities.RoomVersions.Default)
+	data.AvailableRoomVersions = make(map[string]types.String, len(capabilities.Capabilities.RoomVersions.Available))
+	for version, stability := range capabilities.Capabilities.RoomVersions.Available {
+		data.AvailableRoomVersions[version] = types.StringValue(stability)
+	}
+	data.ChangePassword = capabilityEnabled(capabilities.Capabilities.ChangePassword)
+	data.SetDisplayname = capabilityEnabled(capabilities.Capabilities.SetDisplayname)
+	data.SetAvatarURL = capabilityEnabled(capabilities.Capabilities.SetAvatarURL)
+	data.ThreepidChanges = capabilityEnabled(capabilities.Capabilities.ThreepidChanges)
+	data.Id = types.StringValue("capabilities")
+
+	tflog.Trace(ctx, "read server capabilities", map[string]any{"default_room_version": data.DefaultRoomVersion.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServerCapabilitiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccServerCapabilitiesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.matrix_server_capabilities.test", "default_room_version"),
+					resource.TestCheckResourceAttr("data.matrix_server_capabilities.test", "available_room_versions.10", "stable"),
+					resource.TestCheckResourceAttrSet("data.matrix_server_capabilities.test", "change_password"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerCapabilitiesDataSourceConfig = `
+data "matrix_server_capabilities" "test" {}
+`
@@ -0,0 +1,364 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SpaceChildResource{}
+var _ resource.ResourceWithImportState = &SpaceChildResource{}
+var _ resource.ResourceWithValidateConfig = &SpaceChildResource{}
+
+func NewSpaceChildResource() resource.Resource {
+	return &SpaceChildResource{}
+}
+
+// SpaceChildResource defines the resource implementation.
+type SpaceChildResource struct {
+	client *gomatrix.Client
+}
+
+// SpaceChildResourceModel describes the resource data model.
+type SpaceChildResourceModel struct {
+	SpaceID   types.String   `tfsdk:"space_id"`
+	RoomID    types.String   `tfsdk:"room_id"`
+	Via       []types.String `tfsdk:"via"`
+	Order     types.String   `tfsdk:"order"`
+	Suggested types.Bool     `tfsdk:"suggested"`
+	Parent    types.Bool     `tfsdk:"parent"`
+	Canonical types.Bool     `tfsdk:"canonical"`
+	Id        types.String   `tfsdk:"id"`
+}
+
+// spaceChildContent is the content of the m.space.child state event. Content
+// without via removes the room from the space.
+type spaceChildContent struct {
+	Via       []string `json:"via,omitempty"`
+	Order     string   `json:"order,omitempty"`
+	Suggested bool     `json:"suggested,omitempty"`
+}
+
+// spaceParentContent is the content of the m.space.parent state event.
+type spaceParentContent struct {
+	Via       []string `json:"via,omitempty"`
+	Canonical bool     `json:"canonical,omitempty"`
+}
+
+// spaceChildOrderRegexp follows the order restrictions of the specification,
+// at most 50 printable ASCII characters.
+var spaceChildOrderRegexp = regexp.MustCompile(`^[\x20-\x7E]{1,50}$`)
+
+func (r *SpaceChildResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_child"
+}
+
+func (r *SpaceChildResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a room to a space with an `m.space.child` state event in the space and, optionally, " +
+			"links the room back to the space with an `m.space.parent` state event in the room. " +
+			"Destroying the resource removes the room from the space.\n\n" +
+			"The provider user must be allowed to send the state events, usually by having enough power in the space and the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The room ID of the space.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to add to the space, which may be a space itself.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"via": schema.SetAttribute{
+				MarkdownDescription: "The servers to try to join the room through, usually the server of the room creator.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixServerName()),
+				},
+			},
+			"order": schema.StringAttribute{
+				MarkdownDescription: "Clients sort the children of a space by this string, at most 50 printable ASCII characters. " +
+					"Children without an order come last.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(spaceChildOrderRegexp, "value must be at most 50 printable ASCII characters"),
+				},
+			},
+			"suggested": schema.BoolAttribute{
+				MarkdownDescription: "Whether clients suggest joining the room to members of the space. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"parent": schema.BoolAttribute{
+				MarkdownDescription: "Whether to also send an `m.space.parent` state event in the room pointing to the space. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"canonical": schema.BoolAttribute{
+				MarkdownDescription: "Whether the space is the main parent of the room. Requires `parent`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `space_id/room_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SpaceChildResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SpaceChildResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SpaceChildResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the m.space.parent event can mark the space as canonical.
+	if data.Canonical.ValueBool() && !data.Parent.IsUnknown() && !data.Parent.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("canonical"),
+			"Missing Parent Event",
+			"canonical requires parent to be true, the space can only be marked as canonical parent in the m.space.parent state event.",
+		)
+	}
+}
+
+// spaceChildVia returns the configured via servers sorted, so the state
+// events do not change between applies.
+func spaceChildVia(data SpaceChildResourceModel) []string {
+	via := make([]string, 0, len(data.Via))
+	for _, server := range data.Via {
+		via = append(via, server.ValueString())
+	}
+	sort.Strings(via)
+
+	return via
+}
+
+func (r *SpaceChildResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SpaceChildResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.sendChild(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.space.child state event, got error: %s", err))
+		return
+	}
+
+	if data.Parent.ValueBool() {
+		err = r.sendParent(data)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.space.parent state event, got error: %s", err))
+			return
+		}
+	}
+
+	data.Id = types.StringValue(data.SpaceID.ValueString() + "/" + data.RoomID.ValueString())
+
+	tflog.Trace(ctx, "added room to space", map[string]any{"space_id": data.SpaceID.ValueString(), "room_id": data.RoomID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceChildResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SpaceChildResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var child spaceChildContent
+	err := r.client.StateEvent(data.SpaceID.ValueString(), "m.space.child", data.RoomID.ValueString(), &child)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.space.child state event, got error: %s", err))
+		return
+	}
+
+	// Children without via are not part of the space.
+	if len(child.Via) == 0 {
+		tflog.Warn(ctx, "room is no longer a child of the space, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Via = make([]types.String, 0, len(child.Via))
+	for _, server := range child.Via {
+		data.Via = append(data.Via, types.StringValue(server))
+	}
+	data.Order = types.StringNull()
+	if child.Order != "" {
+		data.Order = types.StringValue(child.Order)
+	}
+	data.Suggested = types.BoolValue(child.Suggested)
+
+	var parent spaceParentContent
+	err = r.client.StateEvent(data.RoomID.ValueString(), "m.space.parent", data.SpaceID.ValueString(), &parent)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.space.parent state event, got error: %s", err))
+		return
+	}
+	data.Parent = types.BoolValue(len(parent.Via) > 0)
+	data.Canonical = types.BoolValue(len(parent.Via) > 0 && parent.Canonical)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceChildResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SpaceChildResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.sendChild(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.space.child state event, got error: %s", err))
+		return
+	}
+
+	if data.Parent.ValueBool() {
+		err = r.sendParent(data)
+	} else if state.Parent.ValueBool() {
+		err = r.removeParent(data)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.space.parent state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceChildResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SpaceChildResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// State events cannot be deleted, empty content takes the room out of
+	// the space.
+	_, err := r.client.SendStateEvent(data.SpaceID.ValueString(), "m.space.child", data.RoomID.ValueString(), spaceChildContent{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove m.space.child state event, got error: %s", err))
+		return
+	}
+
+	if data.Parent.ValueBool() {
+		err = r.removeParent(data)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove m.space.parent state event, got error: %s", err))
+			return
+		}
+	}
+}
+
+func (r *SpaceChildResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "space_id", "room_id")
+}
+
+// sendChild sends the m.space.child state event in the space.
+func (r *SpaceChildResource) sendChild(data SpaceChildResourceModel) error {
+	_, err := r.client.SendStateEvent(data.SpaceID.ValueString(), "m.space.child", data.RoomID.ValueString(), spaceChildContent{
+		Via:       spaceChildVia(data),
+		Order:     data.Order.ValueString(),
+		Suggested: data.Suggested.ValueBool(),
+	})
+	return err
+}
+
+// sendParent sends the m.space.parent state event in the room.
+func (r *SpaceChildResource) sendParent(data SpaceChildResourceModel) error {
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.space.parent", data.SpaceID.ValueString(), spaceParentContent{
+		Via:       spaceChildVia(data),
+		Canonical: data.Canonical.ValueBool(),
+	})
+	return err
+}
+
+// removeParent empties the m.space.parent state event in the room.
+func (r *SpaceChildResource) removeParent(data SpaceChildResourceModel) error {
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), "m.space.parent", data.SpaceID.ValueString(), spaceParentContent{})
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSpaceChildResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_server_name", testAccServerName())
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A canonical parent needs the m.space.parent event
+			{
+				Config:      testAccSpaceChildResourceConfig(`"a"`, false, true),
+				ExpectError: regexp.MustCompile(`Missing Parent Event`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSpaceChildResourceConfig(`"a"`, false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_space_child.test", "via.#", "1"),
+					resource.TestCheckResourceAttr("matrix_space_child.test", "order", "a"),
+					resource.TestCheckResourceAttr("matrix_space_child.test", "suggested", "true"),
+					resource.TestCheckResourceAttr("matrix_space_child.test", "parent", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_space_child.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing links the room back to the space
+			{
+				Config: testAccSpaceChildResourceConfig("null", true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("matrix_space_child.test", "order"),
+					resource.TestCheckResourceAttr("matrix_space_child.test", "parent", "true"),
+					resource.TestCheckResourceAttr("matrix_space_child.test", "canonical", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSpaceChildResourceConfig(order string, parent bool, canonical bool) string {
+	return fmt.Sprintf(`
+variable "server_name" {}
+
+resource "matrix_space" "test" {
+  name = "Space"
+}
+
+resource "matrix_room" "test" {}
+
+resource "matrix_space_child" "test" {
+  space_id  = matrix_space.test.room_id
+  room_id   = matrix_room.test.room_id
+  via       = [var.server_name]
+  order     = %s
+  suggested = true
+  parent    = %t
+  canonical = %t
+}
+`, order, parent, canonical)
+}
@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SpaceResource{}
+var _ resource.ResourceWithImportState = &SpaceResource{}
+
+func NewSpaceResource() resource.Resource {
+	return &SpaceResource{}
+}
+
+// SpaceResource defines the resource implementation.
+type SpaceResource struct {
+	client         *gomatrix.Client
+	preventDestroy bool
+}
+
+// SpaceResourceModel describes the resource data model.
+type SpaceResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Topic       types.String `tfsdk:"topic"`
+	AvatarURL   types.String `tfsdk:"avatar_url"`
+	RoomVersion types.String `tfsdk:"room_version"`
+	OnDestroy   types.String `tfsdk:"on_destroy"`
+	RoomID      types.String `tfsdk:"room_id"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// spaceRoomType is the m.room.create type of spaces.
+const spaceRoomType = "m.space"
+
+func (r *SpaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (r *SpaceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a space owned by the provider user, a room of type `m.space` that groups other rooms.\n\n" +
+			"Like `matrix_room`, destroying this resource makes the provider user leave and forget the space by default. " +
+			"The space keeps existing for everyone else in it unless `on_destroy` says otherwise.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the space.",
+				Optional:            true,
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "The topic of the space.",
+				Optional:            true,
+			},
+			"avatar_url": schema.StringAttribute{
+				MarkdownDescription: "The `mxc://` URI of the avatar of the space.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.MxcURI(),
+				},
+			},
+			"room_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the space room, e.g. `10`. Defaults to the `default_room_version` " +
+					"of the homeserver as reported by its capabilities. Changing it creates a new space.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": onDestroyAttribute("space"),
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the space room, e.g. to add child rooms to the space.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the space room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SpaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+	r.preventDestroy = providerData.PreventDestroyRooms
+}
+
+func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SpaceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilities, err := getCapabilities(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read available room versions, got error: %s", err))
+		return
+	}
+
+	// Check a pinned version up front, the homeserver only answers with
+	// M_UNSUPPORTED_ROOM_VERSION.
+	if data.RoomVersion.IsUnknown() {
+		data.RoomVersion = types.StringValue(capabilities.Capabilities.RoomVersions.Default)
+	} else if message := capabilities.unsupportedRoomVersion(data.RoomVersion.ValueString()); message != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("room_version"), "Unsupported Room Version", message)
+		return
+	}
+
+	reqBody := createRoomRequest{
+		ReqCreateRoom: gomatrix.ReqCreateRoom{
+			Name:            data.Name.ValueString(),
+			Topic:           data.Topic.ValueString(),
+			CreationContent: map[string]interface{}{"type": spaceRoomType},
+		},
+		RoomVersion: data.RoomVersion.ValueString(),
+	}
+
+	if !data.AvatarURL.IsNull() {
+		stateKey := ""
+		reqBody.InitialState = append(reqBody.InitialState, gomatrix.Event{
+			Type:     "m.room.avatar",
+			StateKey: &stateKey,
+			Content:  map[string]interface{}{"url": data.AvatarURL.ValueString()},
+		})
+	}
+
+	var room gomatrix.RespCreateRoom
+	err = r.client.MakeRequest("POST", r.client.BuildURL("createRoom"), reqBody, &room)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create space, got error: %s", err))
+		return
+	}
+
+	data.RoomID = types.StringValue(room.RoomID)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "created space", map[string]any{"room_id": room.RoomID, "room_version": data.RoomVersion.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SpaceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var createJSON json.RawMessage
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.create", "", &createJSON)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "provider user is no longer in the space, removing from state", map[string]any{"room_id": data.RoomID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space, got error: %s", err))
+		return
+	}
+
+	var create struct {
+		roomCreateContent
+		Type string `json:"type"`
+	}
+	err = json.Unmarshal(createJSON, &create)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse m.room.create event, got error: %s", err))
+		return
+	}
+
+	// The type cannot change, so this only happens on import.
+	if create.Type != spaceRoomType {
+		resp.Diagnostics.AddError(
+			"Not a Space",
+			fmt.Sprintf("The room %s is not a space. Manage it with matrix_room instead.", data.RoomID.ValueString()),
+		)
+		return
+	}
+
+	// Rooms created before room versions existed have no room_version.
+	if create.RoomVersion == "" {
+		create.RoomVersion = "1"
+	}
+	data.RoomVersion = types.StringValue(create.RoomVersion)
+
+	data.Name, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.name state event, got error: %s", err))
+		return
+	}
+
+	data.Topic, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.topic", "topic")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.topic state event, got error: %s", err))
+		return
+	}
+
+	data.AvatarURL, err = getRoomStateField(r.client, data.RoomID.ValueString(), "m.room.avatar", "url")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read m.room.avatar state event, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SpaceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	if !data.Name.Equal(state.Name) {
+		err := setRoomStateField(r.client, roomID, "m.room.name", "name", data.Name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.name state event, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Topic.Equal(state.Topic) {
+		err := setRoomStateField(r.client, roomID, "m.room.topic", "topic", data.Topic)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.topic state event, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.AvatarURL.Equal(state.AvatarURL) {
+		err := setRoomStateField(r.client, roomID, "m.room.avatar", "url", data.AvatarURL)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send m.room.avatar state event, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SpaceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.preventDestroy {
+		resp.Diagnostics.AddError(
+			"Room Destruction Prevented",
+			fmt.Sprintf("The provider has prevent_destroy_rooms enabled, so the space %s cannot be destroyed. "+
+				"Disable prevent_destroy_rooms in the provider configuration or remove the space from the state "+
+				"with terraform state rm to stop managing it.", data.RoomID.ValueString()),
+		)
+		return
+	}
+
+	err := destroyRoom(ctx, r.client, data.RoomID.ValueString(), data.OnDestroy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
+		return
+	}
+}
+
+func (r *SpaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id")
+
+	// on_destroy only exists in Terraform, assume the default.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), "leave")...)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccSpaceResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSpaceResourceConfig("Engineering", `"All engineering rooms"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_space.test", "name", "Engineering"),
+					resource.TestCheckResourceAttr("matrix_space.test", "topic", "All engineering rooms"),
+					resource.TestCheckResourceAttrSet("matrix_space.test", "room_version"),
+					resource.TestCheckResourceAttrPair("matrix_space.test", "id", "matrix_space.test", "room_id"),
+					testAccCheckRoomStateEvent(t, "matrix_space.test", "m.room.create", "type", "m.space"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_space.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update testing renames the space and removes the topic in place
+			{
+				Config: testAccSpaceResourceConfig("Platform", "null"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("matrix_space.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_space.test", "name", "Platform"),
+					resource.TestCheckNoResourceAttr("matrix_space.test", "topic"),
+					testAccCheckRoomStateEvent(t, "matrix_space.test", "m.room.name", "name", "Platform"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSpaceResourceConfig(name string, topic string) string {
+	return fmt.Sprintf(`
+resource "matrix_space" "test" {
+  name  = %q
+  topic = %s
+}
+`, name, topic)
+}
@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseAccountValidityResource{}
+var _ resource.ResourceWithImportState = &SynapseAccountValidityResource{}
+var _ resource.ResourceWithModifyPlan = &SynapseAccountValidityResource{}
+
+func NewSynapseAccountValidityResource() resource.Resource {
+	return &SynapseAccountValidityResource{}
+}
+
+// SynapseAccountValidityResource defines the resource implementation.
+type SynapseAccountValidityResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseAccountValidityResourceModel describes the resource data model.
+type SynapseAccountValidityResourceModel struct {
+	UserID              types.String `tfsdk:"user_id"`
+	ExpirationTs        types.Int64  `tfsdk:"expiration_ts"`
+	EnableRenewalEmails types.Bool   `tfsdk:"enable_renewal_emails"`
+	Id                  types.String `tfsdk:"id"`
+}
+
+// accountValidityNeverExpiresTs is the expiration timestamp set on destroy,
+// 9999-12-31T23:59:59Z. The admin API cannot remove an expiration date.
+const accountValidityNeverExpiresTs int64 = 253402300799000
+
+// accountValidityWarningPeriod is how close to now an expiration date must be
+// to warn about it at plan time.
+const accountValidityWarningPeriod = 24 * time.Hour
+
+func (r *SynapseAccountValidityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_account_validity"
+}
+
+func (r *SynapseAccountValidityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets when a local account expires using the Synapse admin API. " +
+			"Expired accounts cannot use the homeserver until they are renewed. " +
+			"Destroying the resource moves the expiration date to the year 9999, as it cannot be removed.\n\n" +
+			"The homeserver must have `account_validity` enabled in its configuration. " +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"expiration_ts": schema.Int64Attribute{
+				MarkdownDescription: "When the account expires, in milliseconds since the epoch. " +
+					"New values must be in the future, values within the next 24 hours emit a warning.",
+				Required: true,
+			},
+			"enable_renewal_emails": schema.BoolAttribute{
+				MarkdownDescription: "Whether Synapse emails the user a renewal link before the account expires. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseAccountValidityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseAccountValidityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SynapseAccountValidityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.ExpirationTs.IsUnknown() {
+		return
+	}
+
+	// Only check dates that are about to be sent, an expiration date that
+	// has passed since it was applied is not a mistake.
+	if !req.State.Raw.IsNull() {
+		var state SynapseAccountValidityResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() || plan.ExpirationTs.Equal(state.ExpirationTs) {
+			return
+		}
+	}
+
+	expiration := time.UnixMilli(plan.ExpirationTs.ValueInt64())
+	now := time.Now()
+
+	if !expiration.After(now) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expiration_ts"),
+			"Expiration In The Past",
+			fmt.Sprintf("The expiration date %s has already passed.", expiration.UTC().Format(time.RFC3339)),
+		)
+		return
+	}
+
+	if expiration.Before(now.Add(accountValidityWarningPeriod)) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expiration_ts"),
+			"Account Expires Soon",
+			fmt.Sprintf("The account %s expires within 24 hours, at %s.", plan.UserID.ValueString(), expiration.UTC().Format(time.RFC3339)),
+		)
+	}
+}
+
+// setValidity sets the expiration date of an account.
+func (r *SynapseAccountValidityResource) setValidity(userID string, expirationTs int64, enableRenewalEmails bool) error {
+	return r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "account_validity", "validity"), map[string]any{
+		"user_id":               userID,
+		"expiration_ts":         expirationTs,
+		"enable_renewal_emails": enableRenewalEmails,
+	}, nil)
+}
+
+func (r *SynapseAccountValidityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseAccountValidityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setValidity(data.UserID.ValueString(), data.ExpirationTs.ValueInt64(), data.EnableRenewalEmails.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set account validity, got error: %s", err))
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "set account validity", map[string]any{"user_id": data.UserID.ValueString(), "expiration_ts": data.ExpirationTs.ValueInt64()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseAccountValidityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseAccountValidityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var user struct {
+		ExpirationTs *int64 `json:"expiration_ts"`
+	}
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v2", "users", data.UserID.ValueString()), nil, &user)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "user no longer exists, removing from state", map[string]any{"user_id": data.UserID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	// Only some Synapse versions return the expiration date, otherwise the
+	// last applied one is kept.
+	if user.ExpirationTs != nil {
+		data.ExpirationTs = types.Int64Value(*user.ExpirationTs)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseAccountValidityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseAccountValidityResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setValidity(data.UserID.ValueString(), data.ExpirationTs.ValueInt64(), data.EnableRenewalEmails.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set account validity, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseAccountValidityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseAccountValidityResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setValidity(data.UserID.ValueString(), accountValidityNeverExpiresTs, false)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset account validity, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseAccountValidityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "user_id") == nil {
+		return
+	}
+
+	// Renewal emails cannot be read back, assume the default.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("enable_renewal_emails"), true)...)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseAccountValidityResource(t *testing.T) {
+	inAWeek := time.Now().Add(7 * 24 * time.Hour).UnixMilli()
+	inAMonth := time.Now().Add(30 * 24 * time.Hour).UnixMilli()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-account-validity"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseAccountValidityResourceConfig(inAWeek),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_account_validity.test", "expiration_ts", fmt.Sprint(inAWeek)),
+					resource.TestCheckResourceAttr("matrix_synapse_account_validity.test", "enable_renewal_emails", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_account_validity.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Synapse does not return the expiration date of a user.
+				ImportStateVerifyIgnore: []string{"expiration_ts"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseAccountValidityResourceConfig(inAMonth),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_account_validity.test", "expiration_ts", fmt.Sprint(inAMonth)),
+				),
+			},
+			// Plan time validation
+			{
+				Config:      testAccSynapseAccountValidityResourceConfig(time.Now().Add(-time.Hour).UnixMilli()),
+				ExpectError: regexp.MustCompile("Expiration In The Past"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapseAccountValidityResourceConfig(expirationTs int64) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_account_validity" "test" {
+  user_id       = var.user_id
+  expiration_ts = %d
+}
+`, expirationTs)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseBackgroundUpdateStatusDataSource{}
+
+func NewSynapseBackgroundUpdateStatusDataSource() datasource.DataSource {
+	return &SynapseBackgroundUpdateStatusDataSource{}
+}
+
+// SynapseBackgroundUpdateStatusDataSource defines the data source implementation.
+type SynapseBackgroundUpdateStatusDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseBackgroundUpdateStatusDataSourceModel describes the data source data model.
+type SynapseBackgroundUpdateStatusDataSourceModel struct {
+	Enabled     types.Bool                     `tfsdk:"enabled"`
+	AllComplete types.Bool                     `tfsdk:"all_complete"`
+	Updates     []SynapseBackgroundUpdateModel `tfsdk:"updates"`
+	Id          types.String                   `tfsdk:"id"`
+}
+
+// SynapseBackgroundUpdateModel describes a single running background update.
+type SynapseBackgroundUpdateModel struct {
+	Database          types.String  `tfsdk:"database"`
+	Name              types.String  `tfsdk:"name"`
+	AverageItemsPerMs types.Float64 `tfsdk:"average_items_per_ms"`
+	TotalItemCount    types.Int64   `tfsdk:"total_item_count"`
+	TotalDurationMs   types.Float64 `tfsdk:"total_duration_ms"`
+}
+
+// synapseBackgroundUpdateStatus is the response of the Synapse background
+// update status admin API. Current updates are keyed by database name.
+type synapseBackgroundUpdateStatus struct {
+	Enabled        bool `json:"enabled"`
+	CurrentUpdates map[string]struct {
+		Name              string  `json:"name"`
+		AverageItemsPerMs float64 `json:"average_items_per_ms"`
+		TotalItemCount    int64   `json:"total_item_count"`
+		TotalDurationMs   float64 `json:"total_duration_ms"`
+	} `json:"current_updates"`
+}
+
+func (d *SynapseBackgroundUpdateStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_background_update_status"
+}
+
+func (d *SynapseBackgroundUpdateStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the status of the Synapse background database updates, e.g. to wait for them to finish after an upgrade.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether background updates are enabled.",
+				Computed:            true,
+			},
+			"all_complete": schema.BoolAttribute{
+				MarkdownDescription: "Whether there are no background updates running.",
+				Computed:            true,
+			},
+			"updates": schema.ListNestedAttribute{
+				MarkdownDescription: "The currently running background updates, one per database.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"database": schema.StringAttribute{
+							MarkdownDescription: "The database the update runs on.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the update.",
+							Computed:            true,
+						},
+						"average_items_per_ms": schema.Float64Attribute{
+							MarkdownDescription: "The average number of items processed per millisecond.",
+							Computed:            true,
+						},
+						"total_item_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of items processed so far.",
+							Computed:            true,
+						},
+						"total_duration_ms": schema.Float64Attribute{
+							MarkdownDescription: "How long the update has been running, in milliseconds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseBackgroundUpdateStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseBackgroundUpdateStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseBackgroundUpdateStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var status synapseBackgroundUpdateStatus
+	err := d.client.MakeRequest("GET", synapseAdminURL(d.client, "v1", "background_updates", "status"), nil, &status)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read background update status, got error: %s", err))
+		return
+	}
+
+	// Sort by database so the list order is stable between reads.
+	databases := make([]string, 0, len(status.CurrentUpdates))
+	for database := range status.CurrentUpdates {
+		databases = append(databases, database)
+	}
+	sort.Strings(databases)
+
+	data.Updates = make([]SynapseBackgroundUpdateModel, 0, len(databases))
+	for _, database := range databases {
+		update := status.CurrentUpdates[database]
+		data.Updates = append(data.Updates, SynapseBackgroundUpdateModel{
+			Database:          types.StringValue(database),
+			Name:              types.StringValue(update.Name),
+			AverageItemsPerMs: types.Float64Value(update.AverageItemsPerMs),
+			TotalItemCount:    types.Int64Value(update.TotalItemCount),
+			TotalDurationMs:   types.Float64Value(update.TotalDurationMs),
+		})
+	}
+
+	data.Enabled = types.BoolValue(status.Enabled)
+	data.AllComplete = types.BoolValue(len(data.Updates) == 0)
+	data.Id = types.StringValue("background_updates")
+
+	tflog.Trace(ctx, "read background update status", map[string]any{"running": len(data.Updates)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseBackgroundUpdateStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "matrix_synapse_background_update_status" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_synapse_background_update_status.test", "id", "background_updates"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_background_update_status.test", "enabled"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_background_update_status.test", "all_complete"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseDeleteEventReportResource{}
+var _ resource.ResourceWithImportState = &SynapseDeleteEventReportResource{}
+
+func NewSynapseDeleteEventReportResource() resource.Resource {
+	return &SynapseDeleteEventReportResource{}
+}
+
+// SynapseDeleteEventReportResource defines the resource implementation.
+type SynapseDeleteEventReportResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseDeleteEventReportResourceModel describes the resource data model.
+type SynapseDeleteEventReportResourceModel struct {
+	ReportID types.Int64  `tfsdk:"report_id"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (r *SynapseDeleteEventReportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_delete_event_report"
+}
+
+func (r *SynapseDeleteEventReportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes an event report using the Synapse admin API, e.g. one listed by the " +
+			"`matrix_synapse_room_report` data source. Creating the resource deletes the report. " +
+			"Destroying the resource does nothing on the homeserver.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"report_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the event report to delete.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the event report",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseDeleteEventReportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseDeleteEventReportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseDeleteEventReportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportID := strconv.FormatInt(data.ReportID.ValueInt64(), 10)
+	err := r.client.MakeRequest("DELETE", synapseAdminURL(r.client, "v1", "event_reports", reportID), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete event report, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(reportID)
+
+	tflog.Trace(ctx, "deleted event report", map[string]any{"id": reportID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseDeleteEventReportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseDeleteEventReportResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportID := strconv.FormatInt(data.ReportID.ValueInt64(), 10)
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "event_reports", reportID), nil, nil)
+	if err != nil {
+		// The report is still gone, which is the desired state.
+		if isNotFound(err) {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event report, got error: %s", err))
+		return
+	}
+
+	tflog.Warn(ctx, "deleted event report exists again, it will be deleted on the next apply", map[string]any{"id": reportID})
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *SynapseDeleteEventReportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseDeleteEventReportResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseDeleteEventReportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deleted reports cannot be restored, only forget the resource.
+}
+
+func (r *SynapseDeleteEventReportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	reportID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: report_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("report_id"), reportID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseDeleteEventReportResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			eventID := testAccSendMessage(t, roomID, "reported by terraform acceptance tests")
+			reportID := testAccReportEvent(t, roomID, eventID)
+
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_report_id", strconv.FormatInt(reportID, 10))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseDeleteEventReportResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_synapse_delete_event_report.test", "id", "matrix_synapse_delete_event_report.test", "report_id"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_report.test", "event_reports.#", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_delete_event_report.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccSynapseDeleteEventReportResourceConfig = `
+variable "room_id" {}
+variable "report_id" {}
+
+resource "matrix_synapse_delete_event_report" "test" {
+  report_id = var.report_id
+}
+
+data "matrix_synapse_room_report" "test" {
+  room_id = var.room_id
+
+  depends_on = [matrix_synapse_delete_event_report.test]
+}
+`
@@ -0,0 +1,309 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseEmail3pidResource{}
+var _ resource.ResourceWithImportState = &SynapseEmail3pidResource{}
+var _ resource.ResourceWithValidateConfig = &SynapseEmail3pidResource{}
+
+func NewSynapseEmail3pidResource() resource.Resource {
+	return &SynapseEmail3pidResource{}
+}
+
+// msisdnRegexp matches phone numbers in international format without the
+// leading +, which is how the Matrix spec stores msisdn threepids.
+var msisdnRegexp = regexp.MustCompile(`^[1-9][0-9]{6,14}$`)
+
+// SynapseEmail3pidResource defines the resource implementation.
+type SynapseEmail3pidResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseEmail3pidResourceModel describes the resource data model.
+type SynapseEmail3pidResourceModel struct {
+	UserID  types.String `tfsdk:"user_id"`
+	Medium  types.String `tfsdk:"medium"`
+	Address types.String `tfsdk:"address"`
+	Id      types.String `tfsdk:"id"`
+}
+
+func (r *SynapseEmail3pidResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_email_3pid"
+}
+
+func (r *SynapseEmail3pidResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Associates an email address or phone number with a local user account using the Synapse admin API. " +
+			"The admin API only allows replacing the whole list of third-party identifiers of a user, " +
+			"so avoid managing the same user's addresses from outside Terraform at the same time.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"medium": schema.StringAttribute{
+				MarkdownDescription: "The medium of the third-party identifier, `email` or `msisdn`. Defaults to `email`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("email"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("email", "msisdn"),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The email address, or for `msisdn` the phone number in international format " +
+					"without the leading `+`, e.g. `441234567890`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/address`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseEmail3pidResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setThreepids replaces all third-party identifiers of the user.
+func (r *SynapseEmail3pidResource) setThreepids(userID string, threepids []synapseThreepid) error {
+	reqBody := map[string]any{"threepids": threepids}
+	return r.client.MakeRequest("PUT", synapseAdminURL(r.client, "v2", "users", userID), reqBody, nil)
+}
+
+func (r *SynapseEmail3pidResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SynapseEmail3pidResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Medium.IsUnknown() {
+		return
+	}
+
+	// The address format depends on the medium, so it cannot be checked by
+	// an attribute validator.
+	addressValidator := validators.EmailAddress()
+	if data.Medium.ValueString() == "msisdn" {
+		addressValidator = stringvalidator.RegexMatches(msisdnRegexp, "value must be a phone number in international format without the leading +")
+	}
+
+	validateResp := &validator.StringResponse{}
+	addressValidator.ValidateString(ctx, validator.StringRequest{
+		Path:        path.Root("address"),
+		ConfigValue: data.Address,
+	}, validateResp)
+	resp.Diagnostics.Append(validateResp.Diagnostics...)
+}
+
+// hasThreepid reports whether the address is in the list. Synapse lowercases
+// email addresses, so the comparison ignores case for them.
+func hasThreepid(threepids []synapseThreepid, medium string, address string) bool {
+	for _, threepid := range threepids {
+		if threepidMatches(threepid, medium, address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// threepidMatches reports whether the threepid has the medium and address.
+func threepidMatches(threepid synapseThreepid, medium string, address string) bool {
+	if threepid.Medium != medium {
+		return false
+	}
+
+	if medium == "email" {
+		return strings.EqualFold(threepid.Address, address)
+	}
+
+	return threepid.Address == address
+}
+
+func (r *SynapseEmail3pidResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseEmail3pidResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if !hasThreepid(user.Threepids, data.Medium.ValueString(), data.Address.ValueString()) {
+		threepids := append(user.Threepids, synapseThreepid{Medium: data.Medium.ValueString(), Address: data.Address.ValueString()})
+		err = r.setThreepids(data.UserID.ValueString(), threepids)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add third-party identifier, got error: %s", err))
+			return
+		}
+	}
+
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + data.Address.ValueString())
+
+	tflog.Trace(ctx, "added third-party identifier", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseEmail3pidResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseEmail3pidResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if !hasThreepid(user.Threepids, data.Medium.ValueString(), data.Address.ValueString()) {
+		tflog.Warn(ctx, "third-party identifier was removed outside of Terraform", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseEmail3pidResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseEmail3pidResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseEmail3pidResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseEmail3pidResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if !hasThreepid(user.Threepids, data.Medium.ValueString(), data.Address.ValueString()) {
+		return
+	}
+
+	threepids := make([]synapseThreepid, 0, len(user.Threepids))
+	for _, threepid := range user.Threepids {
+		if threepidMatches(threepid, data.Medium.ValueString(), data.Address.ValueString()) {
+			continue
+		}
+		threepids = append(threepids, threepid)
+	}
+
+	err = r.setThreepids(data.UserID.ValueString(), threepids)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove third-party identifier, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseEmail3pidResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id", "address")
+
+	// Phone numbers are digits only, so any address with an @ is an email.
+	var address types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("address"), &address)...)
+
+	medium := "msisdn"
+	if strings.Contains(address.ValueString(), "@") {
+		medium = "email"
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("medium"), medium)...)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSynapseEmail3pidResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-email-3pid"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSynapseEmail3pid(t, false),
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccSynapseEmail3pidResourceConfig("not-an-email"),
+				ExpectError: regexp.MustCompile(`value must be a valid email address`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSynapseEmail3pidResourceConfig("tf-acc@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_email_3pid.test", "medium", "email"),
+					resource.TestCheckResourceAttr("matrix_synapse_email_3pid.test", "address", "tf-acc@example.com"),
+					testAccCheckSynapseEmail3pid(t, true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_email_3pid.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSynapseEmail3pidResource_msisdn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-msisdn-3pid"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSynapseEmail3pid(t, false),
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccSynapseMsisdn3pidResourceConfig("+441234567890"),
+				ExpectError: regexp.MustCompile(`value must be a phone number in international format`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSynapseMsisdn3pidResourceConfig("441234567890"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_email_3pid.test", "medium", "msisdn"),
+					resource.TestCheckResourceAttr("matrix_synapse_email_3pid.test", "address", "441234567890"),
+					testAccCheckSynapseEmail3pid(t, true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_email_3pid.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSynapseEmail3pid asserts whether the address is bound to the
+// test user as reported by the admin API.
+func testAccCheckSynapseEmail3pid(t *testing.T, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "matrix_synapse_email_3pid" {
+				continue
+			}
+
+			user, err := getSynapseUser(client, rs.Primary.Attributes["user_id"])
+			if err != nil {
+				return err
+			}
+
+			if hasThreepid(user.Threepids, rs.Primary.Attributes["medium"], rs.Primary.Attributes["address"]) != expected {
+				return fmt.Errorf("expected %s bound to %s: %t", rs.Primary.Attributes["address"], user.Name, expected)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccSynapseEmail3pidResourceConfig(address string) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_email_3pid" "test" {
+  user_id = var.user_id
+  address = %[1]q
+}
+`, address)
+}
+
+func testAccSynapseMsisdn3pidResourceConfig(address string) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_email_3pid" "test" {
+  user_id = var.user_id
+  medium  = "msisdn"
+  address = %[1]q
+}
+`, address)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseFederationAllowListResource{}
+var _ resource.ResourceWithModifyPlan = &SynapseFederationAllowListResource{}
+
+func NewSynapseFederationAllowListResource() resource.Resource {
+	return &SynapseFederationAllowListResource{}
+}
+
+// SynapseFederationAllowListResource defines the resource implementation.
+// Synapse has no admin API for the federation allow list, so the resource
+// only renders the homeserver configuration and never talks to the
+// homeserver.
+type SynapseFederationAllowListResource struct{}
+
+// SynapseFederationAllowListResourceModel describes the resource data model.
+type SynapseFederationAllowListResourceModel struct {
+	Servers        []types.String `tfsdk:"servers"`
+	ConfigFragment types.String   `tfsdk:"config_fragment"`
+	Id             types.String   `tfsdk:"id"`
+}
+
+// federationAllowListConfigKey is the homeserver.yaml option holding the
+// federation allow list.
+const federationAllowListConfigKey = "federation_domain_whitelist"
+
+// federationAllowListConfigFragment renders the homeserver.yaml fragment for
+// the given servers. Servers are sorted so the fragment only changes when the
+// set does. Every server is written as a JSON string, which is valid YAML.
+func federationAllowListConfigFragment(servers []string) string {
+	sorted := append([]string(nil), servers...)
+	sort.Strings(sorted)
+
+	if len(sorted) == 0 {
+		return federationAllowListConfigKey + ": []\n"
+	}
+
+	var fragment strings.Builder
+	fragment.WriteString(federationAllowListConfigKey + ":\n")
+	for _, server := range sorted {
+		quoted, _ := json.Marshal(server)
+		fragment.WriteString("  - " + string(quoted) + "\n")
+	}
+
+	return fragment.String()
+}
+
+// configFragment renders the fragment for the servers of the model.
+func (m SynapseFederationAllowListResourceModel) configFragment() types.String {
+	servers := make([]string, 0, len(m.Servers))
+	for _, server := range m.Servers {
+		servers = append(servers, server.ValueString())
+	}
+
+	return types.StringValue(federationAllowListConfigFragment(servers))
+}
+
+func (r *SynapseFederationAllowListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_federation_allow_list"
+}
+
+func (r *SynapseFederationAllowListResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the `federation_domain_whitelist` option of `homeserver.yaml`, which restricts " +
+			"federation to the listed servers. Synapse has no admin API to change the allow list at runtime, so the " +
+			"resource does not talk to the homeserver. Write `config_fragment` into a file Synapse reads its " +
+			"configuration from, e.g. with a `local_file` resource, and restart Synapse to apply it.",
+
+		Attributes: map[string]schema.Attribute{
+			"servers": schema.SetAttribute{
+				MarkdownDescription: "The server names to federate with. An empty set disables federation entirely.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.MatrixServerName()),
+				},
+			},
+			"config_fragment": schema.StringAttribute{
+				MarkdownDescription: "The `homeserver.yaml` fragment setting `federation_domain_whitelist` to the sorted `servers`.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseFederationAllowListResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to render on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var servers types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("servers"), &servers)...)
+
+	if resp.Diagnostics.HasError() || servers.IsUnknown() {
+		return
+	}
+
+	var data SynapseFederationAllowListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, server := range data.Servers {
+		if server.IsUnknown() {
+			return
+		}
+	}
+
+	// Render the fragment at plan time, so resources writing it to disk know
+	// their content before apply.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("config_fragment"), data.configFragment())...)
+}
+
+func (r *SynapseFederationAllowListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseFederationAllowListResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ConfigFragment = data.configFragment()
+	data.Id = types.StringValue(federationAllowListConfigKey)
+
+	tflog.Trace(ctx, "rendered federation allow list", map[string]any{"servers": len(data.Servers)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseFederationAllowListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The allow list only exists in the state, there is nothing to refresh.
+}
+
+func (r *SynapseFederationAllowListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseFederationAllowListResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ConfigFragment = data.configFragment()
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseFederationAllowListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The homeserver configuration is managed outside of the provider, only
+	// forget the resource.
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFederationAllowListConfigFragment(t *testing.T) {
+	tests := map[string]struct {
+		servers  []string
+		expected string
+	}{
+		"empty": {
+			servers:  nil,
+			expected: "federation_domain_whitelist: []\n",
+		},
+		"sorted": {
+			servers:  []string{"matrix.org", "example.com:8448", "[::1]"},
+			expected: "federation_domain_whitelist:\n  - \"[::1]\"\n  - \"example.com:8448\"\n  - \"matrix.org\"\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := federationAllowListConfigFragment(test.servers)
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccSynapseFederationAllowListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseFederationAllowListResourceConfig(`["matrix.org", "example.com"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_federation_allow_list.test", "servers.#", "2"),
+					resource.TestCheckResourceAttr("matrix_synapse_federation_allow_list.test", "config_fragment",
+						"federation_domain_whitelist:\n  - \"example.com\"\n  - \"matrix.org\"\n"),
+					resource.TestCheckResourceAttr("matrix_synapse_federation_allow_list.test", "id", "federation_domain_whitelist"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseFederationAllowListResourceConfig(`[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_federation_allow_list.test", "config_fragment", "federation_domain_whitelist: []\n"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapseFederationAllowListResourceConfig(servers string) string {
+	return `
+resource "matrix_synapse_federation_allow_list" "test" {
+  servers = ` + servers + `
+}
+`
+}
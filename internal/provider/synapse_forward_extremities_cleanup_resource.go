@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseForwardExtremitiesCleanupResource{}
+var _ resource.ResourceWithImportState = &SynapseForwardExtremitiesCleanupResource{}
+
+func NewSynapseForwardExtremitiesCleanupResource() resource.Resource {
+	return &SynapseForwardExtremitiesCleanupResource{}
+}
+
+// SynapseForwardExtremitiesCleanupResource defines the resource implementation.
+type SynapseForwardExtremitiesCleanupResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseForwardExtremitiesCleanupResourceModel describes the resource data model.
+type SynapseForwardExtremitiesCleanupResourceModel struct {
+	RoomID       types.String `tfsdk:"room_id"`
+	DeletedCount types.Int64  `tfsdk:"deleted_count"`
+	Id           types.String `tfsdk:"id"`
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_forward_extremities_cleanup"
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes the excess forward extremities of a room using the Synapse admin API. " +
+			"The cleanup runs once on create; replace the resource to run it again. " +
+			"Destroying the resource does nothing on the homeserver.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to clean up.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"deleted_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of forward extremities that were deleted.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseForwardExtremitiesCleanupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deleteResp struct {
+		Deleted int64 `json:"deleted"`
+	}
+	err := r.client.MakeRequest("DELETE", synapseAdminURL(r.client, "v1", "rooms", data.RoomID.ValueString(), "forward_extremities"), nil, &deleteResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete forward extremities, got error: %s", err))
+		return
+	}
+
+	data.DeletedCount = types.Int64Value(deleteResp.Deleted)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "deleted forward extremities", map[string]any{"room_id": data.RoomID.ValueString(), "deleted": deleteResp.Deleted})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseForwardExtremitiesCleanupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	extremities, err := getForwardExtremities(r.client, data.RoomID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read forward extremities, got error: %s", err))
+		return
+	}
+
+	// A room without any extremities is unknown to the homeserver, there is
+	// nothing left to clean up.
+	if extremities.Count == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseForwardExtremitiesCleanupResourceModel
+
+	// room_id requires replacement, so there is nothing to send to the
+	// homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deleted extremities cannot be restored, only forget the resource.
+}
+
+func (r *SynapseForwardExtremitiesCleanupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "room_id") == nil {
+		return
+	}
+
+	// The number of extremities deleted back then is unknown.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deleted_count"), int64(0))...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseForwardExtremitiesCleanupResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseForwardExtremitiesCleanupResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// A fresh room only has a single extremity, which is kept.
+					resource.TestCheckResourceAttr("matrix_synapse_forward_extremities_cleanup.test", "deleted_count", "0"),
+					resource.TestCheckResourceAttrPair("matrix_synapse_forward_extremities_cleanup.test", "id", "matrix_synapse_forward_extremities_cleanup.test", "room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_forward_extremities_cleanup.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Not reported by the homeserver
+				ImportStateVerifyIgnore: []string{"deleted_count"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccSynapseForwardExtremitiesCleanupResourceConfig = `
+variable "room_id" {}
+
+resource "matrix_synapse_forward_extremities_cleanup" "test" {
+  room_id = var.room_id
+}
+`
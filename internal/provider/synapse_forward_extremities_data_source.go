@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseForwardExtremitiesDataSource{}
+
+func NewSynapseForwardExtremitiesDataSource() datasource.DataSource {
+	return &SynapseForwardExtremitiesDataSource{}
+}
+
+// SynapseForwardExtremitiesDataSource defines the data source implementation.
+type SynapseForwardExtremitiesDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseForwardExtremitiesDataSourceModel describes the data source data model.
+type SynapseForwardExtremitiesDataSourceModel struct {
+	RoomID  types.String                   `tfsdk:"room_id"`
+	Count   types.Int64                    `tfsdk:"extremity_count"`
+	Results []SynapseForwardExtremityModel `tfsdk:"results"`
+	Id      types.String                   `tfsdk:"id"`
+}
+
+// SynapseForwardExtremityModel describes a single forward extremity.
+type SynapseForwardExtremityModel struct {
+	EventID    types.String `tfsdk:"event_id"`
+	StateGroup types.Int64  `tfsdk:"state_group"`
+	Depth      types.Int64  `tfsdk:"depth"`
+	ReceivedTs types.Int64  `tfsdk:"received_ts"`
+}
+
+// synapseForwardExtremities is the response of the Synapse forward
+// extremities admin API.
+type synapseForwardExtremities struct {
+	Count   int64 `json:"count"`
+	Results []struct {
+		EventID    string `json:"event_id"`
+		StateGroup int64  `json:"state_group"`
+		Depth      int64  `json:"depth"`
+		ReceivedTs int64  `json:"received_ts"`
+	} `json:"results"`
+}
+
+// getForwardExtremities lists the forward extremities of a room.
+func getForwardExtremities(client *gomatrix.Client, roomID string) (*synapseForwardExtremities, error) {
+	var extremities synapseForwardExtremities
+	err := client.MakeRequest("GET", synapseAdminURL(client, "v1", "rooms", roomID, "forward_extremities"), nil, &extremities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extremities, nil
+}
+
+func (d *SynapseForwardExtremitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_forward_extremities"
+}
+
+func (d *SynapseForwardExtremitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the forward extremities of a room using the Synapse admin API. " +
+			"Rooms with many forward extremities are expensive for Synapse to handle.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to check.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"extremity_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of forward extremities in the room. Named `extremity_count` as `count` is reserved by Terraform.",
+				Computed:            true,
+			},
+			"results": schema.ListNestedAttribute{
+				MarkdownDescription: "The forward extremities of the room.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"event_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the extremity event.",
+							Computed:            true,
+						},
+						"state_group": schema.Int64Attribute{
+							MarkdownDescription: "The state group of the event.",
+							Computed:            true,
+						},
+						"depth": schema.Int64Attribute{
+							MarkdownDescription: "The depth of the event in the room DAG.",
+							Computed:            true,
+						},
+						"received_ts": schema.Int64Attribute{
+							MarkdownDescription: "When the event was received, in milliseconds since the epoch.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseForwardExtremitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseForwardExtremitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseForwardExtremitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	extremities, err := getForwardExtremities(d.client, data.RoomID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read forward extremities, got error: %s", err))
+		return
+	}
+
+	data.Count = types.Int64Value(extremities.Count)
+	data.Results = make([]SynapseForwardExtremityModel, 0, len(extremities.Results))
+	for _, result := range extremities.Results {
+		data.Results = append(data.Results, SynapseForwardExtremityModel{
+			EventID:    types.StringValue(result.EventID),
+			StateGroup: types.Int64Value(result.StateGroup),
+			Depth:      types.Int64Value(result.Depth),
+			ReceivedTs: types.Int64Value(result.ReceivedTs),
+		})
+	}
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "read forward extremities", map[string]any{"room_id": data.RoomID.ValueString(), "count": extremities.Count})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseForwardExtremitiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseForwardExtremitiesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_synapse_forward_extremities.test", "extremity_count", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_forward_extremities.test", "results.#", "1"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_forward_extremities.test", "results.0.event_id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseForwardExtremitiesDataSourceConfig = `
+variable "room_id" {}
+
+data "matrix_synapse_forward_extremities" "test" {
+  room_id = var.room_id
+}
+`
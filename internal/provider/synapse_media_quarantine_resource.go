@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseMediaQuarantineResource{}
+var _ resource.ResourceWithImportState = &SynapseMediaQuarantineResource{}
+
+func NewSynapseMediaQuarantineResource() resource.Resource {
+	return &SynapseMediaQuarantineResource{}
+}
+
+// SynapseMediaQuarantineResource defines the resource implementation.
+type SynapseMediaQuarantineResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseMediaQuarantineResourceModel describes the resource data model.
+type SynapseMediaQuarantineResourceModel struct {
+	ServerName    types.String `tfsdk:"server_name"`
+	MediaID       types.String `tfsdk:"media_id"`
+	QuarantinedAt types.String `tfsdk:"quarantined_at"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// synapseMediaInfo is the response of the Synapse media query admin API.
+type synapseMediaInfo struct {
+	MediaInfo struct {
+		QuarantinedBy *string `json:"quarantined_by"`
+	} `json:"media_info"`
+}
+
+func (r *SynapseMediaQuarantineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_media_quarantine"
+}
+
+func (r *SynapseMediaQuarantineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Quarantines a piece of media using the Synapse admin API. " +
+			"Quarantined media can no longer be downloaded from this homeserver. " +
+			"Destroying the resource releases the media from quarantine again.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"server_name": schema.StringAttribute{
+				MarkdownDescription: "The server name part of the `mxc://` URI, in the form `host[:port]`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixServerName(),
+				},
+			},
+			"media_id": schema.StringAttribute{
+				MarkdownDescription: "The media ID part of the `mxc://` URI.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"quarantined_at": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 timestamp of when the media was quarantined by this resource. " +
+					"Null after import, as Synapse does not report when media was quarantined.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `server_name/media_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseMediaQuarantineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseMediaQuarantineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseMediaQuarantineResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := synapseAdminURL(r.client, "v1", "media", "quarantine", data.ServerName.ValueString(), data.MediaID.ValueString())
+	err := r.client.MakeRequest("POST", url, struct{}{}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to quarantine media, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.ServerName.ValueString() + "/" + data.MediaID.ValueString())
+	data.QuarantinedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	tflog.Trace(ctx, "quarantined media", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseMediaQuarantineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseMediaQuarantineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var info synapseMediaInfo
+	url := synapseAdminURL(r.client, "v1", "media", data.ServerName.ValueString(), data.MediaID.ValueString())
+	err := r.client.MakeRequest("GET", url, nil, &info)
+	if err != nil {
+		// Older Synapse versions cannot query single media, keep the state as is.
+		if isUnrecognized(err) {
+			tflog.Debug(ctx, "media query admin API is not available, skipping quarantine check")
+			return
+		}
+
+		if isNotFound(err) {
+			tflog.Warn(ctx, "quarantined media no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read media, got error: %s", err))
+		return
+	}
+
+	if info.MediaInfo.QuarantinedBy == nil || *info.MediaInfo.QuarantinedBy == "" {
+		tflog.Warn(ctx, "media was released from quarantine outside of Terraform", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseMediaQuarantineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseMediaQuarantineResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseMediaQuarantineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseMediaQuarantineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := synapseAdminURL(r.client, "v1", "media", "unquarantine", data.ServerName.ValueString(), data.MediaID.ValueString())
+	err := r.client.MakeRequest("POST", url, struct{}{}, nil)
+	if err != nil {
+		if isUnrecognized(err) {
+			resp.Diagnostics.AddWarning(
+				"Media Left In Quarantine",
+				"This Synapse version does not support releasing media from quarantine. "+
+					"The media "+data.Id.ValueString()+" was removed from Terraform state but is still quarantined.",
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to release media from quarantine, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseMediaQuarantineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The homeserver does not report when the media was quarantined, so
+	// quarantined_at stays null.
+	importCompositeID(ctx, req, resp, "server_name", "media_id")
+}
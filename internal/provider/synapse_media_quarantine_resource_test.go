@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseMediaQuarantineResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			upload, err := testAccClient(t).UploadToContentRepo(strings.NewReader("quarantine me"), "text/plain", 13)
+			if err != nil {
+				t.Fatalf("unable to upload test media: %s", err)
+			}
+
+			mxc, err := url.Parse(upload.ContentURI)
+			if err != nil {
+				t.Fatalf("unable to parse content URI %q: %s", upload.ContentURI, err)
+			}
+
+			t.Setenv("TF_VAR_server_name", mxc.Host)
+			t.Setenv("TF_VAR_media_id", strings.TrimPrefix(mxc.Path, "/"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseMediaQuarantineResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_synapse_media_quarantine.test", "id"),
+					resource.TestCheckResourceAttrSet("matrix_synapse_media_quarantine.test", "quarantined_at"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_media_quarantine.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Not reported by the homeserver
+				ImportStateVerifyIgnore: []string{"quarantined_at"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccSynapseMediaQuarantineResourceConfig = `
+variable "server_name" {}
+variable "media_id" {}
+
+resource "matrix_synapse_media_quarantine" "test" {
+  server_name = var.server_name
+  media_id    = var.media_id
+}
+`
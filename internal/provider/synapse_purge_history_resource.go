@@ -0,0 +1,347 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapsePurgeHistoryResource{}
+var _ resource.ResourceWithImportState = &SynapsePurgeHistoryResource{}
+var _ resource.ResourceWithConfigValidators = &SynapsePurgeHistoryResource{}
+
+// purgeHistoryPollInterval is the time between two checks whether a purge
+// has finished.
+const purgeHistoryPollInterval = 2 * time.Second
+
+func NewSynapsePurgeHistoryResource() resource.Resource {
+	return &SynapsePurgeHistoryResource{}
+}
+
+// SynapsePurgeHistoryResource defines the resource implementation.
+type SynapsePurgeHistoryResource struct {
+	client *gomatrix.Client
+}
+
+// SynapsePurgeHistoryResourceModel describes the resource data model.
+type SynapsePurgeHistoryResourceModel struct {
+	RoomID            types.String `tfsdk:"room_id"`
+	PurgeUpToEventID  types.String `tfsdk:"purge_up_to_event_id"`
+	PurgeUpToTs       types.Int64  `tfsdk:"purge_up_to_ts"`
+	DeleteLocalEvents types.Bool   `tfsdk:"delete_local_events"`
+	TimeoutMinutes    types.Int64  `tfsdk:"timeout_minutes"`
+	PurgeID           types.String `tfsdk:"purge_id"`
+	Status            types.String `tfsdk:"status"`
+	Id                types.String `tfsdk:"id"`
+}
+
+// synapsePurgeHistoryRequest is the request body of the Synapse purge history
+// admin API.
+type synapsePurgeHistoryRequest struct {
+	DeleteLocalEvents bool   `json:"delete_local_events"`
+	PurgeUpToTs       *int64 `json:"purge_up_to_ts,omitempty"`
+}
+
+// synapsePurgeHistoryStatus is the response of the Synapse purge history
+// status admin API.
+type synapsePurgeHistoryStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func (r *SynapsePurgeHistoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_purge_history"
+}
+
+func (r *SynapsePurgeHistoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Purges the history of a room before an event or point in time from the database using the Synapse admin API, " +
+			"and waits for the purge to finish. The purge runs once on create; replace the resource to run it again. " +
+			"Purged events cannot be restored, destroying the resource does nothing on the homeserver.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to purge.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"purge_up_to_event_id": schema.StringAttribute{
+				MarkdownDescription: "Purge all events before this event. Exactly one of `purge_up_to_event_id` and `purge_up_to_ts` must be set.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixEventID(),
+				},
+			},
+			"purge_up_to_ts": schema.Int64Attribute{
+				MarkdownDescription: "Purge all events before this time, in milliseconds since the Unix epoch. " +
+					"Exactly one of `purge_up_to_event_id` and `purge_up_to_ts` must be set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"delete_local_events": schema.BoolAttribute{
+				MarkdownDescription: "Also purge events sent by local users. Otherwise they are kept, as they cannot be fetched " +
+					"from other homeservers again. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_minutes": schema.Int64Attribute{
+				MarkdownDescription: "How long to wait for the purge to finish. Defaults to `10`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(10),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"purge_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the purge.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the purge, `active`, `complete` or `failed`. " +
+					"Synapse forgets finished purges after a while, the last known status is kept then.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/purge_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapsePurgeHistoryResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("purge_up_to_event_id"),
+			path.MatchRoot("purge_up_to_ts"),
+		),
+	}
+}
+
+func (r *SynapsePurgeHistoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// purgeStatus queries the status of a purge.
+func (r *SynapsePurgeHistoryResource) purgeStatus(purgeID string) (*synapsePurgeHistoryStatus, error) {
+	var status synapsePurgeHistoryStatus
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "purge_history_status", purgeID), nil, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// waitForPurge polls the status of a purge until it is no longer active or
+// the timeout is reached. It returns the context error if ctx is cancelled
+// first, e.g. because Terraform was interrupted.
+func (r *SynapsePurgeHistoryResource) waitForPurge(ctx context.Context, purgeID string, timeout time.Duration) (*synapsePurgeHistoryStatus, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(purgeHistoryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := r.purgeStatus(purgeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Status != "active" {
+			return status, nil
+		}
+
+		tflog.Debug(ctx, "waiting for purge to finish", map[string]any{"purge_id": purgeID})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return status, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *SynapsePurgeHistoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapsePurgeHistoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	urlPath := []string{"v1", "purge_history", data.RoomID.ValueString()}
+	if !data.PurgeUpToEventID.IsNull() {
+		urlPath = append(urlPath, data.PurgeUpToEventID.ValueString())
+	}
+
+	reqBody := synapsePurgeHistoryRequest{
+		DeleteLocalEvents: data.DeleteLocalEvents.ValueBool(),
+		PurgeUpToTs:       data.PurgeUpToTs.ValueInt64Pointer(),
+	}
+
+	var purge struct {
+		PurgeID string `json:"purge_id"`
+	}
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, urlPath...), reqBody, &purge)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to purge room history, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "started purge", map[string]any{"room_id": data.RoomID.ValueString(), "purge_id": purge.PurgeID})
+
+	status, err := r.waitForPurge(ctx, purge.PurgeID, time.Duration(data.TimeoutMinutes.ValueInt64())*time.Minute)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read purge status, got error: %s", err))
+		return
+	}
+
+	if status.Status == "failed" {
+		resp.Diagnostics.AddError("Purge Failed", fmt.Sprintf("Synapse failed to purge the history of %s: %s", data.RoomID.ValueString(), status.Error))
+		return
+	}
+
+	data.PurgeID = types.StringValue(purge.PurgeID)
+	data.Status = types.StringValue(status.Status)
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + purge.PurgeID)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	// Keep the running purge in the state, but taint it so the next apply
+	// checks again by starting a new purge.
+	if status.Status == "active" {
+		resp.Diagnostics.AddError(
+			"Purge Not Finished",
+			fmt.Sprintf("The purge %s of %s is still running after %d minutes. Increase timeout_minutes for large rooms.",
+				purge.PurgeID, data.RoomID.ValueString(), data.TimeoutMinutes.ValueInt64()),
+		)
+	}
+}
+
+func (r *SynapsePurgeHistoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapsePurgeHistoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := r.purgeStatus(data.PurgeID.ValueString())
+	if err != nil {
+		// Synapse only remembers purges for a while after they finished.
+		if isNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read purge status, got error: %s", err))
+		return
+	}
+
+	data.Status = types.StringValue(status.Status)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapsePurgeHistoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapsePurgeHistoryResourceModel
+
+	// Only timeout_minutes can change without replacement, and it is only
+	// used on create.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapsePurgeHistoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Purged events cannot be restored, only forget the resource.
+}
+
+func (r *SynapsePurgeHistoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "room_id", "purge_id") == nil {
+		return
+	}
+
+	// The options of the purge are not reported by Synapse, assume the
+	// defaults.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_local_events"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("timeout_minutes"), int64(10))...)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapsePurgeHistoryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", testAccSendMessage(t, roomID, "old"))
+			t.Setenv("TF_VAR_purge_up_to_ts", strconv.FormatInt(time.Now().UnixMilli(), 10))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccSynapsePurgeHistoryResourceConfig("purge_up_to_event_id = var.event_id\n  purge_up_to_ts = var.purge_up_to_ts"),
+				ExpectError: regexp.MustCompile(`Exactly one of these attributes must be configured`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSynapsePurgeHistoryResourceConfig("purge_up_to_ts = var.purge_up_to_ts"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_synapse_purge_history.test", "purge_id"),
+					resource.TestCheckResourceAttr("matrix_synapse_purge_history.test", "status", "complete"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_synapse_purge_history.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"purge_up_to_ts", "delete_local_events"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapsePurgeHistoryResourceConfig(purgeUpTo string) string {
+	return `
+variable "room_id" {}
+variable "event_id" {}
+variable "purge_up_to_ts" {}
+
+resource "matrix_synapse_purge_history" "test" {
+  room_id             = var.room_id
+  delete_local_events = true
+  ` + purgeUpTo + `
+}
+`
+}
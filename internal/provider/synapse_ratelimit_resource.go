@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseRatelimitResource{}
+var _ resource.ResourceWithImportState = &SynapseRatelimitResource{}
+
+func NewSynapseRatelimitResource() resource.Resource {
+	return &SynapseRatelimitResource{}
+}
+
+// SynapseRatelimitResource defines the resource implementation.
+type SynapseRatelimitResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRatelimitResourceModel describes the resource data model.
+type SynapseRatelimitResourceModel struct {
+	UserID            types.String `tfsdk:"user_id"`
+	MessagesPerSecond types.Int64  `tfsdk:"messages_per_second"`
+	BurstCount        types.Int64  `tfsdk:"burst_count"`
+	Id                types.String `tfsdk:"id"`
+}
+
+// synapseRatelimit is the request and response body of the Synapse
+// override_ratelimit admin API. Both fields are absent if the user has no
+// override.
+type synapseRatelimit struct {
+	MessagesPerSecond *int64 `json:"messages_per_second,omitempty"`
+	BurstCount        *int64 `json:"burst_count,omitempty"`
+}
+
+func (r *SynapseRatelimitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_ratelimit"
+}
+
+func (r *SynapseRatelimitResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Overrides the message rate limit of a local user using the Synapse admin API. " +
+			"Destroying the resource restores the default rate limits of the homeserver.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user, e.g. `@bot:example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"messages_per_second": schema.Int64Attribute{
+				MarkdownDescription: "The number of actions the user can perform per second. " +
+					"Defaults to `0`, which disables rate limiting for the user.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"burst_count": schema.Int64Attribute{
+				MarkdownDescription: "How many actions the user can perform before being limited. " +
+					"Defaults to `0`, which disables rate limiting for the user.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The user ID the override applies to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseRatelimitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setOverride creates or replaces the rate limit override of the user.
+func (r *SynapseRatelimitResource) setOverride(data SynapseRatelimitResourceModel) error {
+	reqBody := synapseRatelimit{
+		MessagesPerSecond: data.MessagesPerSecond.ValueInt64Pointer(),
+		BurstCount:        data.BurstCount.ValueInt64Pointer(),
+	}
+
+	return r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "users", data.UserID.ValueString(), "override_ratelimit"), reqBody, nil)
+}
+
+func (r *SynapseRatelimitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseRatelimitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setOverride(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to override rate limit, got error: %s", err))
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "overrode rate limit", map[string]any{"user_id": data.UserID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRatelimitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseRatelimitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var override synapseRatelimit
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "users", data.UserID.ValueString(), "override_ratelimit"), nil, &override)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read rate limit override, got error: %s", err))
+		return
+	}
+
+	if override.MessagesPerSecond == nil && override.BurstCount == nil {
+		tflog.Warn(ctx, "rate limit override was removed outside of Terraform", map[string]any{"user_id": data.UserID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.MessagesPerSecond = types.Int64PointerValue(override.MessagesPerSecond)
+	data.BurstCount = types.Int64PointerValue(override.BurstCount)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRatelimitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseRatelimitResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setOverride(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update rate limit override, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRatelimitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseRatelimitResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("DELETE", synapseAdminURL(r.client, "v1", "users", data.UserID.ValueString(), "override_ratelimit"), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove rate limit override, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseRatelimitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRatelimitResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-ratelimit"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccSynapseRatelimitResourceConfig(-1, 0),
+				ExpectError: regexp.MustCompile(`value must be at least 0`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSynapseRatelimitResourceConfig(10, 50),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_ratelimit.test", "messages_per_second", "10"),
+					resource.TestCheckResourceAttr("matrix_synapse_ratelimit.test", "burst_count", "50"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseRatelimitResourceConfig(0, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_ratelimit.test", "messages_per_second", "0"),
+					resource.TestCheckResourceAttr("matrix_synapse_ratelimit.test", "burst_count", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_ratelimit.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapseRatelimitResourceConfig(messagesPerSecond int, burstCount int) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_ratelimit" "test" {
+  user_id             = var.user_id
+  messages_per_second = %[1]d
+  burst_count         = %[2]d
+}
+`, messagesPerSecond, burstCount)
+}
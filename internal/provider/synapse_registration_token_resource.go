@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseRegistrationTokenResource{}
+var _ resource.ResourceWithImportState = &SynapseRegistrationTokenResource{}
+
+func NewSynapseRegistrationTokenResource() resource.Resource {
+	return &SynapseRegistrationTokenResource{}
+}
+
+// registrationTokenRegexp matches the tokens Synapse accepts.
+var registrationTokenRegexp = regexp.MustCompile(`^[A-Za-z0-9._~-]{1,64}$`)
+
+// SynapseRegistrationTokenResource defines the resource implementation.
+type SynapseRegistrationTokenResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRegistrationTokenResourceModel describes the resource data model.
+type SynapseRegistrationTokenResourceModel struct {
+	Token       types.String `tfsdk:"token"`
+	UsesAllowed types.Int64  `tfsdk:"uses_allowed"`
+	ExpiryTime  types.Int64  `tfsdk:"expiry_time"`
+	Pending     types.Int64  `tfsdk:"pending"`
+	Completed   types.Int64  `tfsdk:"completed"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// synapseRegistrationToken is a registration token as returned by the
+// Synapse admin API.
+type synapseRegistrationToken struct {
+	Token       string `json:"token"`
+	UsesAllowed *int64 `json:"uses_allowed"`
+	ExpiryTime  *int64 `json:"expiry_time"`
+	Pending     int64  `json:"pending"`
+	Completed   int64  `json:"completed"`
+}
+
+// body returns the limits of the token. Unset limits are sent as null, which
+// removes them.
+func (m SynapseRegistrationTokenResourceModel) body() map[string]interface{} {
+	return map[string]interface{}{
+		"uses_allowed": m.UsesAllowed.ValueInt64Pointer(),
+		"expiry_time":  m.ExpiryTime.ValueInt64Pointer(),
+	}
+}
+
+// setToken copies the token returned by the homeserver into the model.
+func (m *SynapseRegistrationTokenResourceModel) setToken(token synapseRegistrationToken) {
+	m.Token = types.StringValue(token.Token)
+	m.UsesAllowed = types.Int64PointerValue(token.UsesAllowed)
+	m.ExpiryTime = types.Int64PointerValue(token.ExpiryTime)
+	m.Pending = types.Int64Value(token.Pending)
+	m.Completed = types.Int64Value(token.Completed)
+	m.Id = m.Token
+}
+
+func (r *SynapseRegistrationTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_registration_token"
+}
+
+func (r *SynapseRegistrationTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a registration token using the Synapse admin API. Registration tokens allow " +
+			"new users to register when `registration_requires_token` is enabled on the homeserver.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The registration token. Generated by the homeserver if not set. Changing it creates a new token.",
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(registrationTokenRegexp, "value must be 1 to 64 characters from A-Z, a-z, 0-9, or ._~-"),
+				},
+			},
+			"uses_allowed": schema.Int64Attribute{
+				MarkdownDescription: "How many times the token can be used to register. Unlimited if not set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"expiry_time": schema.Int64Attribute{
+				MarkdownDescription: "When the token expires, in milliseconds since the epoch. Never expires if not set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"pending": schema.Int64Attribute{
+				MarkdownDescription: "How many registrations using the token are in progress.",
+				Computed:            true,
+			},
+			"completed": schema.Int64Attribute{
+				MarkdownDescription: "How many registrations using the token have completed.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The registration token",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseRegistrationTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseRegistrationTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseRegistrationTokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := data.body()
+	if !data.Token.IsUnknown() && !data.Token.IsNull() {
+		reqBody["token"] = data.Token.ValueString()
+	}
+
+	var token synapseRegistrationToken
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "registration_tokens", "new"), reqBody, &token)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create registration token, got error: %s", err))
+		return
+	}
+
+	data.setToken(token)
+
+	tflog.Trace(ctx, "created a registration token")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRegistrationTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseRegistrationTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var token synapseRegistrationToken
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "registration_tokens", data.Token.ValueString()), nil, &token)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "registration token was deleted outside of Terraform, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read registration token, got error: %s", err))
+		return
+	}
+
+	data.setToken(token)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRegistrationTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseRegistrationTokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var token synapseRegistrationToken
+	err := r.client.MakeRequest("PUT", synapseAdminURL(r.client, "v1", "registration_tokens", data.Token.ValueString()), data.body(), &token)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update registration token, got error: %s", err))
+		return
+	}
+
+	data.setToken(token)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRegistrationTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseRegistrationTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.MakeRequest("DELETE", synapseAdminURL(r.client, "v1", "registration_tokens", data.Token.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete registration token, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseRegistrationTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "token")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRegistrationTokenResource(t *testing.T) {
+	token := "tf-acc-" + acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseRegistrationTokenResourceConfig(token, `uses_allowed = 5`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_registration_token.test", "id", token),
+					resource.TestCheckResourceAttr("matrix_synapse_registration_token.test", "uses_allowed", "5"),
+					resource.TestCheckNoResourceAttr("matrix_synapse_registration_token.test", "expiry_time"),
+					resource.TestCheckResourceAttr("matrix_synapse_registration_token.test", "pending", "0"),
+					resource.TestCheckResourceAttr("matrix_synapse_registration_token.test", "completed", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_registration_token.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseRegistrationTokenResourceConfig(token, `expiry_time = 4102444800000`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("matrix_synapse_registration_token.test", "uses_allowed"),
+					resource.TestCheckResourceAttr("matrix_synapse_registration_token.test", "expiry_time", "4102444800000"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSynapseRegistrationTokenResource_generated(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "matrix_synapse_registration_token" "test" {
+  uses_allowed = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_synapse_registration_token.test", "token"),
+					resource.TestCheckResourceAttrPair("matrix_synapse_registration_token.test", "id", "matrix_synapse_registration_token.test", "token"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSynapseRegistrationTokenResourceConfig(token string, limits string) string {
+	return fmt.Sprintf(`
+resource "matrix_synapse_registration_token" "test" {
+  token = %[1]q
+  %[2]s
+}
+`, token, limits)
+}
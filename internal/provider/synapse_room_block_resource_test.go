@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRoomBlockResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			// Synapse allows blocking rooms it does not know about yet.
+			t.Setenv("TF_VAR_room_id", "!tf-acc-room-block:"+testAccServerName())
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      `resource "matrix_synapse_room_block" "test" { room_id = "#alias:example.com" }`,
+				ExpectError: regexp.MustCompile(`valid Matrix room ID`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSynapseRoomBlockResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_room_block.test", "block", "true"),
+					resource.TestCheckResourceAttrPair("matrix_synapse_room_block.test", "id", "matrix_synapse_room_block.test", "room_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseRoomBlockResourceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_room_block.test", "block", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_room_block.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapseRoomBlockResourceConfig(block bool) string {
+	return fmt.Sprintf(`
+variable "room_id" {}
+
+resource "matrix_synapse_room_block" "test" {
+  room_id = var.room_id
+  block   = %[1]t
+}
+`, block)
+}
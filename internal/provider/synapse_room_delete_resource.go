@@ -0,0 +1,352 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseRoomDeleteResource{}
+var _ resource.ResourceWithImportState = &SynapseRoomDeleteResource{}
+var _ resource.ResourceWithValidateConfig = &SynapseRoomDeleteResource{}
+
+func NewSynapseRoomDeleteResource() resource.Resource {
+	return &SynapseRoomDeleteResource{}
+}
+
+// SynapseRoomDeleteResource defines the resource implementation.
+type SynapseRoomDeleteResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomDeleteResourceModel describes the resource data model.
+type SynapseRoomDeleteResourceModel struct {
+	RoomID        types.String `tfsdk:"room_id"`
+	Block         types.Bool   `tfsdk:"block"`
+	Purge         types.Bool   `tfsdk:"purge"`
+	NewRoomUserID types.String `tfsdk:"new_room_user_id"`
+	RoomName      types.String `tfsdk:"room_name"`
+	Message       types.String `tfsdk:"message"`
+	DeleteID      types.String `tfsdk:"delete_id"`
+	Status        types.String `tfsdk:"status"`
+	KickedUsers   types.List   `tfsdk:"kicked_users"`
+	NewRoomID     types.String `tfsdk:"new_room_id"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// setStatus copies the deletion status into the model.
+func (m *SynapseRoomDeleteResourceModel) setStatus(ctx context.Context, status *synapseRoomDeleteStatus) error {
+	kickedUsers, diags := types.ListValueFrom(ctx, types.StringType, status.ShutdownRoom.KickedUsers)
+	if diags.HasError() {
+		return fmt.Errorf("unable to convert kicked users: %v", diags)
+	}
+
+	m.DeleteID = types.StringValue(status.DeleteID)
+	m.Status = types.StringValue(status.Status)
+	m.KickedUsers = kickedUsers
+	m.NewRoomID = types.StringPointerValue(status.ShutdownRoom.NewRoomID)
+	m.Id = types.StringValue(m.RoomID.ValueString() + "/" + status.DeleteID)
+
+	return nil
+}
+
+func (r *SynapseRoomDeleteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_delete"
+}
+
+func (r *SynapseRoomDeleteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes a room using the Synapse admin API and waits for the deletion to finish. " +
+			"All local users are removed from the room, and can optionally be moved to a new room with a message " +
+			"explaining why. The deletion runs once on create; deleted rooms cannot be restored, destroying the " +
+			"resource does nothing on the homeserver. If the apply is interrupted while waiting, the deletion keeps running " +
+			"and is tracked by its `delete_id`, a failed deletion is planned again.\n\n" +
+			"To delete a room managed by `matrix_room` or `matrix_space` when it is destroyed, set its " +
+			"`on_destroy` to `delete` instead.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to delete.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"block": schema.BoolAttribute{
+				MarkdownDescription: "Also block the room, so local users cannot join it again. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"purge": schema.BoolAttribute{
+				MarkdownDescription: "Also remove the room from the database. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_room_user_id": schema.StringAttribute{
+				MarkdownDescription: "A local user who creates a new room that all local members are moved to. " +
+					"Members are only removed from the room if unset.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"room_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the new room. Requires `new_room_user_id`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "The message posted to the new room. Requires `new_room_user_id`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"delete_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the deletion.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the deletion, e.g. `shutting_down`, `purging` or `complete`. Synapse forgets " +
+					"finished deletions after a while, the last known status is kept then.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kicked_users": schema.ListAttribute{
+				MarkdownDescription: "The local users that were removed from the room.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"new_room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room the members were moved to, if any.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/delete_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseRoomDeleteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SynapseRoomDeleteResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || !data.NewRoomUserID.IsNull() {
+		return
+	}
+
+	// Synapse silently ignores both without a new room to put them in.
+	for _, attribute := range []string{"room_name", "message"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing New Room User",
+				fmt.Sprintf("%s is only used when new_room_user_id is set.", attribute),
+			)
+		}
+	}
+}
+
+func (r *SynapseRoomDeleteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseRoomDeleteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseRoomDeleteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteID, err := startSynapseRoomDelete(r.client, data.RoomID.ValueString(), synapseRoomDeleteRequest{
+		Block:         data.Block.ValueBool(),
+		Purge:         data.Purge.ValueBool(),
+		NewRoomUserID: data.NewRoomUserID.ValueString(),
+		RoomName:      data.RoomName.ValueString(),
+		Message:       data.Message.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete room, got error: %s", err))
+		return
+	}
+
+	// Save the deletion before waiting for it, so an interrupted apply does
+	// not lose track of it and delete the room a second time.
+	err = data.setStatus(ctx, &synapseRoomDeleteStatus{DeleteID: deleteID, Status: "scheduled"})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := waitForSynapseRoomDelete(ctx, r.client, data.RoomID.ValueString(), deleteID)
+	if err != nil {
+		if status == nil {
+			// The deletion keeps running, Read picks up its status later.
+			resp.Diagnostics.AddWarning(
+				"Room Deletion Still Running",
+				fmt.Sprintf("The deletion of %s was started, but waiting for it to finish failed: %s. "+
+					"The next refresh reads its status again.", data.RoomID.ValueString(), err),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete room, got error: %s", err))
+	}
+
+	err = data.setStatus(ctx, status)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "room deletion finished", map[string]any{"room_id": data.RoomID.ValueString(), "delete_id": status.DeleteID, "status": status.Status})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomDeleteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseRoomDeleteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := getSynapseRoomDeleteStatus(r.client, data.DeleteID.ValueString())
+	if err != nil {
+		// Synapse only remembers deletions for a while after they finished.
+		if isNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room deletion status, got error: %s", err))
+		return
+	}
+
+	// A failed deletion did not delete the room, so plan it again.
+	if status.Status == "failed" {
+		tflog.Warn(ctx, "room deletion failed, removing from state", map[string]any{"id": data.Id.ValueString(), "error": status.Error})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	err = data.setStatus(ctx, status)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomDeleteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseRoomDeleteResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomDeleteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Deleted rooms cannot be restored, only forget the resource.
+}
+
+func (r *SynapseRoomDeleteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importCompositeID(ctx, req, resp, "room_id", "delete_id") == nil {
+		return
+	}
+
+	// The options of the deletion are not reported by Synapse, assume the
+	// defaults.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("block"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("purge"), true)...)
+}
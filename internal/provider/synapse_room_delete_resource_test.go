@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRoomDeleteResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_room_id", testAccCreateRoom(t))
+			t.Setenv("TF_VAR_new_room_user_id", os.Getenv("MATRIX_DEFAULT_USERID"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: `
+variable "room_id" {}
+
+resource "matrix_synapse_room_delete" "test" {
+  room_id = var.room_id
+  message = "This room has been closed."
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing New Room User`),
+			},
+			// Create and Read testing
+			{
+				Config: `
+variable "room_id" {}
+variable "new_room_user_id" {}
+
+resource "matrix_synapse_room_delete" "test" {
+  room_id          = var.room_id
+  block            = true
+  new_room_user_id = var.new_room_user_id
+  room_name        = "Closed room"
+  message          = "This room has been closed."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_room_delete.test", "status", "complete"),
+					resource.TestCheckResourceAttrSet("matrix_synapse_room_delete.test", "delete_id"),
+					resource.TestCheckResourceAttrSet("matrix_synapse_room_delete.test", "new_room_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_synapse_room_delete.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"block", "new_room_user_id", "room_name", "message"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseRoomDetailsDataSource{}
+
+func NewSynapseRoomDetailsDataSource() datasource.DataSource {
+	return &SynapseRoomDetailsDataSource{}
+}
+
+// SynapseRoomDetailsDataSource defines the data source implementation.
+type SynapseRoomDetailsDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomDetailsDataSourceModel describes the data source data model.
+type SynapseRoomDetailsDataSourceModel struct {
+	RoomID             types.String             `tfsdk:"room_id"`
+	Name               types.String             `tfsdk:"name"`
+	Topic              types.String             `tfsdk:"topic"`
+	CanonicalAlias     types.String             `tfsdk:"canonical_alias"`
+	Creator            types.String             `tfsdk:"creator"`
+	JoinedMembers      types.Int64              `tfsdk:"joined_members"`
+	JoinedLocalMembers types.Int64              `tfsdk:"joined_local_members"`
+	Version            types.String             `tfsdk:"version"`
+	Federatable        types.Bool               `tfsdk:"federatable"`
+	Public             types.Bool               `tfsdk:"public"`
+	Encryption         types.String             `tfsdk:"encryption"`
+	JoinRules          types.String             `tfsdk:"join_rules"`
+	GuestAccess        types.String             `tfsdk:"guest_access"`
+	HistoryVisibility  types.String             `tfsdk:"history_visibility"`
+	StateEvents        types.Int64              `tfsdk:"state_events"`
+	Members            []SynapseRoomMemberModel `tfsdk:"members"`
+	Id                 types.String             `tfsdk:"id"`
+}
+
+// SynapseRoomMemberModel describes the membership of a single user.
+type SynapseRoomMemberModel struct {
+	UserID      types.String `tfsdk:"user_id"`
+	Membership  types.String `tfsdk:"membership"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+func (d *SynapseRoomDetailsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_details"
+}
+
+func (d *SynapseRoomDetailsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the details of a room and the membership of every user who was ever in it using the Synapse admin API. " +
+			"The provider user does not need to be joined to the room.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the room.",
+				Computed:            true,
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "The topic of the room.",
+				Computed:            true,
+			},
+			"canonical_alias": schema.StringAttribute{
+				MarkdownDescription: "The canonical alias of the room.",
+				Computed:            true,
+			},
+			"creator": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user who created the room.",
+				Computed:            true,
+			},
+			"joined_members": schema.Int64Attribute{
+				MarkdownDescription: "The number of joined members.",
+				Computed:            true,
+			},
+			"joined_local_members": schema.Int64Attribute{
+				MarkdownDescription: "The number of joined members of this homeserver.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The version of the room.",
+				Computed:            true,
+			},
+			"federatable": schema.BoolAttribute{
+				MarkdownDescription: "Whether other homeservers can join the room.",
+				Computed:            true,
+			},
+			"public": schema.BoolAttribute{
+				MarkdownDescription: "Whether the room is listed in the public room directory.",
+				Computed:            true,
+			},
+			"encryption": schema.StringAttribute{
+				MarkdownDescription: "The encryption algorithm of the room, null if the room is not encrypted.",
+				Computed:            true,
+			},
+			"join_rules": schema.StringAttribute{
+				MarkdownDescription: "The join rule of the room.",
+				Computed:            true,
+			},
+			"guest_access": schema.StringAttribute{
+				MarkdownDescription: "The guest access of the room.",
+				Computed:            true,
+			},
+			"history_visibility": schema.StringAttribute{
+				MarkdownDescription: "The history visibility of the room.",
+				Computed:            true,
+			},
+			"state_events": schema.Int64Attribute{
+				MarkdownDescription: "The number of state events in the room.",
+				Computed:            true,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "Every user with a membership in the room, including users who left or were banned, sorted by user ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user.",
+							Computed:            true,
+						},
+						"membership": schema.StringAttribute{
+							MarkdownDescription: "The current membership of the user, one of `join`, `invite`, `knock`, `leave` or `ban`.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the user in the room.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseRoomDetailsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseRoomDetailsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseRoomDetailsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomID := data.RoomID.ValueString()
+
+	var room struct {
+		synapseRoomStats
+		Topic *string `json:"topic"`
+	}
+	err := d.client.MakeRequest("GET", synapseAdminURL(d.client, "v1", "rooms", roomID), nil, &room)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("room_id"),
+				"Room Not Found",
+				fmt.Sprintf("The homeserver does not know the room %s.", roomID),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room details, got error: %s", err))
+		return
+	}
+
+	// The members endpoint only lists joined users, the membership events of
+	// the room state include everyone who ever had a membership.
+	var state synapseRoomState
+	err = d.client.MakeRequest("GET", synapseAdminURL(d.client, "v1", "rooms", roomID, "state"), nil, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room state, got error: %s", err))
+		return
+	}
+
+	data.Members = make([]SynapseRoomMemberModel, 0)
+	for _, event := range state.State {
+		if event.Type != "m.room.member" || event.StateKey == nil {
+			continue
+		}
+
+		membership, _ := event.Content["membership"].(string)
+		member := SynapseRoomMemberModel{
+			UserID:      types.StringValue(*event.StateKey),
+			Membership:  types.StringValue(membership),
+			DisplayName: types.StringNull(),
+		}
+		if displayName, ok := event.Content["displayname"].(string); ok {
+			member.DisplayName = types.StringValue(displayName)
+		}
+		data.Members = append(data.Members, member)
+	}
+	sort.Slice(data.Members, func(i, j int) bool {
+		return data.Members[i].UserID.ValueString() < data.Members[j].UserID.ValueString()
+	})
+
+	data.Name = types.StringPointerValue(room.Name)
+	data.Topic = types.StringPointerValue(room.Topic)
+	data.CanonicalAlias = types.StringPointerValue(room.CanonicalAlias)
+	data.Creator = types.StringPointerValue(room.Creator)
+	data.JoinedMembers = types.Int64Value(room.JoinedMembers)
+	data.JoinedLocalMembers = types.Int64Value(room.JoinedLocalMembers)
+	data.Version = types.StringPointerValue(room.Version)
+	data.Federatable = types.BoolValue(room.Federatable)
+	data.Public = types.BoolValue(room.Public)
+	data.Encryption = types.StringPointerValue(room.Encryption)
+	data.JoinRules = types.StringPointerValue(room.JoinRules)
+	data.GuestAccess = types.StringPointerValue(room.GuestAccess)
+	data.HistoryVisibility = types.StringPointerValue(room.HistoryVisibility)
+	data.StateEvents = types.Int64Value(room.StateEvents)
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "read room details", map[string]any{"room_id": roomID, "members": len(data.Members)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestAccSynapseRoomDetailsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			client := testAccClient(t)
+			roomID := testAccCreateRoom(t)
+			userID := testAccCreateUser(t, "tf-acc-details-member")
+
+			_, err := client.InviteUser(roomID, &gomatrix.ReqInviteUser{UserID: userID})
+			if err != nil {
+				t.Fatalf("unable to invite test user: %s", err)
+			}
+			_, err = client.KickUser(roomID, &gomatrix.ReqKickUser{UserID: userID})
+			if err != nil {
+				t.Fatalf("unable to kick test user: %s", err)
+			}
+
+			t.Setenv("TF_VAR_room_id", roomID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown room testing
+			{
+				Config:      testAccSynapseRoomDetailsDataSourceConfig(`"!unknown:localhost"`),
+				ExpectError: regexp.MustCompile(`Room Not Found`),
+			},
+			// Read testing
+			{
+				Config: testAccSynapseRoomDetailsDataSourceConfig("var.room_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.matrix_synapse_room_details.test", "id", "data.matrix_synapse_room_details.test", "room_id"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_details.test", "joined_members", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_details.test", "federatable", "true"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_room_details.test", "creator"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_room_details.test", "version"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_details.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.matrix_synapse_room_details.test", "members.*", map[string]string{
+						"membership": "leave",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccSynapseRoomDetailsDataSourceConfig(roomID string) string {
+	return `
+variable "room_id" {}
+
+data "matrix_synapse_room_details" "test" {
+  room_id = ` + roomID + `
+}
+`
+}
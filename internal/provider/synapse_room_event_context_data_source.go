@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseRoomEventContextDataSource{}
+
+func NewSynapseRoomEventContextDataSource() datasource.DataSource {
+	return &SynapseRoomEventContextDataSource{}
+}
+
+// SynapseRoomEventContextDataSource defines the data source implementation.
+type SynapseRoomEventContextDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomEventContextDataSourceModel describes the data source data model.
+type SynapseRoomEventContextDataSourceModel struct {
+	RoomID       types.String   `tfsdk:"room_id"`
+	EventID      types.String   `tfsdk:"event_id"`
+	Limit        types.Int64    `tfsdk:"limit"`
+	EventsBefore []types.String `tfsdk:"events_before"`
+	Event        types.String   `tfsdk:"event"`
+	EventsAfter  []types.String `tfsdk:"events_after"`
+	Start        types.String   `tfsdk:"start"`
+	End          types.String   `tfsdk:"end"`
+	State        []types.String `tfsdk:"state"`
+	Id           types.String   `tfsdk:"id"`
+}
+
+// roomEventContext is the response of the client-server API event context
+// endpoint. Events are kept as raw JSON so nothing gets lost in decoding.
+type roomEventContext struct {
+	EventsBefore []json.RawMessage `json:"events_before"`
+	Event        json.RawMessage   `json:"event"`
+	EventsAfter  []json.RawMessage `json:"events_after"`
+	Start        string            `json:"start"`
+	End          string            `json:"end"`
+	State        []json.RawMessage `json:"state"`
+}
+
+func (d *SynapseRoomEventContextDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_event_context"
+}
+
+func (d *SynapseRoomEventContextDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the events surrounding an event in a room, e.g. to debug problems in the room DAG. " +
+			"All events are returned as raw JSON strings which can be decoded with `jsondecode`.\n\n" +
+			"The provider user must be able to see the event in the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room the event is in.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event to get the context of.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixEventID(),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of events to return before and after the event. " +
+					"Defaults to the homeserver default, usually `10`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"events_before": schema.ListAttribute{
+				MarkdownDescription: "The events preceding the event, in reverse chronological order, as JSON strings.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"event": schema.StringAttribute{
+				MarkdownDescription: "The requested event as JSON string.",
+				Computed:            true,
+			},
+			"events_after": schema.ListAttribute{
+				MarkdownDescription: "The events following the event, in chronological order, as JSON strings.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "A pagination token for the start of `events_before`.",
+				Computed:            true,
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: "A pagination token for the end of `events_after`.",
+				Computed:            true,
+			},
+			"state": schema.ListAttribute{
+				MarkdownDescription: "The state of the room at the last event returned, as JSON strings.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the event",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseRoomEventContextDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// rawEventsValue converts raw JSON events into a list of strings.
+func rawEventsValue(events []json.RawMessage) []types.String {
+	values := make([]types.String, 0, len(events))
+	for _, event := range events {
+		values = append(values, types.StringValue(string(event)))
+	}
+
+	return values
+}
+
+func (d *SynapseRoomEventContextDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseRoomEventContextDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := map[string]string{}
+	if !data.Limit.IsNull() {
+		query["limit"] = strconv.FormatInt(data.Limit.ValueInt64(), 10)
+	}
+
+	var eventContext roomEventContext
+	url := d.client.BuildURLWithQuery([]string{"rooms", data.RoomID.ValueString(), "context", data.EventID.ValueString()}, query)
+	err := d.client.MakeRequest("GET", url, nil, &eventContext)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event context, got error: %s", err))
+		return
+	}
+
+	data.EventsBefore = rawEventsValue(eventContext.EventsBefore)
+	data.Event = types.StringValue(string(eventContext.Event))
+	data.EventsAfter = rawEventsValue(eventContext.EventsAfter)
+	data.Start = types.StringValue(eventContext.Start)
+	data.End = types.StringValue(eventContext.End)
+	data.State = rawEventsValue(eventContext.State)
+	data.Id = data.EventID
+
+	tflog.Trace(ctx, "read event context", map[string]any{
+		"event_id": data.EventID.ValueString(),
+		"before":   len(data.EventsBefore),
+		"after":    len(data.EventsAfter),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRoomEventContextDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			testAccSendMessage(t, roomID, "before")
+			eventID := testAccSendMessage(t, roomID, "event")
+			testAccSendMessage(t, roomID, "after")
+
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", eventID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseRoomEventContextDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.matrix_synapse_room_event_context.test", "id", "data.matrix_synapse_room_event_context.test", "event_id"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_event_context.test", "events_before.#", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_event_context.test", "events_after.#", "1"),
+					resource.TestCheckOutput("body", "event"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_room_event_context.test", "start"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_room_event_context.test", "end"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseRoomEventContextDataSourceConfig = `
+variable "room_id" {}
+variable "event_id" {}
+
+data "matrix_synapse_room_event_context" "test" {
+  room_id  = var.room_id
+  event_id = var.event_id
+  limit    = 1
+}
+
+output "body" {
+  value = jsondecode(data.matrix_synapse_room_event_context.test.event).content.body
+}
+`
@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseRoomMakeAdminResource{}
+var _ resource.ResourceWithImportState = &SynapseRoomMakeAdminResource{}
+
+func NewSynapseRoomMakeAdminResource() resource.Resource {
+	return &SynapseRoomMakeAdminResource{}
+}
+
+// SynapseRoomMakeAdminResource defines the resource implementation.
+type SynapseRoomMakeAdminResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomMakeAdminResourceModel describes the resource data model.
+type SynapseRoomMakeAdminResourceModel struct {
+	RoomID types.String `tfsdk:"room_id"`
+	UserID types.String `tfsdk:"user_id"`
+	Id     types.String `tfsdk:"id"`
+}
+
+func (r *SynapseRoomMakeAdminResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_make_admin"
+}
+
+func (r *SynapseRoomMakeAdminResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a local user the highest power level (100) in a room using the Synapse admin API. " +
+			"This is meant to recover rooms whose admins have all left. The user is joined to the room if needed.\n\n" +
+			"Destroying the resource demotes the user again by editing the power levels as the provider user, " +
+			"which therefore has to be in the room with enough power to do so. " +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user to make room admin.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/user_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseRoomMakeAdminResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseRoomMakeAdminResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseRoomMakeAdminResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := synapseAdminURL(r.client, "v1", "rooms", data.RoomID.ValueString(), "make_room_admin")
+	err := r.client.MakeRequest("POST", url, map[string]string{"user_id": data.UserID.ValueString()}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to make user room admin, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.UserID.ValueString())
+
+	tflog.Trace(ctx, "made user room admin", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomMakeAdminResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseRoomMakeAdminResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	event, err := getSynapseRoomStateEvent(r.client, data.RoomID.ValueString(), "m.room.power_levels", "")
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room power levels, got error: %s", err))
+		return
+	}
+
+	var level float64
+	if event != nil {
+		users, _ := event.Content["users"].(map[string]any)
+		level, _ = users[data.UserID.ValueString()].(float64)
+	}
+
+	if level < 100 {
+		tflog.Warn(ctx, "user is no longer room admin, it will be promoted again", map[string]any{"id": data.Id.ValueString(), "power_level": level})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomMakeAdminResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseRoomMakeAdminResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomMakeAdminResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseRoomMakeAdminResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content map[string]any
+	err := r.client.StateEvent(data.RoomID.ValueString(), "m.room.power_levels", "", &content)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room power levels, got error: %s", err))
+		return
+	}
+
+	// Dropping the entry falls back to users_default, only pin the user to
+	// 0 explicitly if the room changed that default.
+	users, _ := content["users"].(map[string]any)
+	if users == nil {
+		users = map[string]any{}
+	}
+	if usersDefault, _ := content["users_default"].(float64); usersDefault == 0 {
+		delete(users, data.UserID.ValueString())
+	} else {
+		users[data.UserID.ValueString()] = 0
+	}
+	content["users"] = users
+
+	_, err = r.client.SendStateEvent(data.RoomID.ValueString(), "m.room.power_levels", "", content)
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			resp.Diagnostics.AddError(
+				"Unable to Demote Room Admin",
+				fmt.Sprintf("%s is not allowed to change the power levels of %s. "+
+					"Only the user itself or another room admin can demote %s.\n\n"+
+					"Matrix Client Error: %s", r.client.UserID, data.RoomID.ValueString(), data.UserID.ValueString(), err),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to demote room admin, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseRoomMakeAdminResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "user_id")
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSynapseRoomMakeAdminResource(t *testing.T) {
+	var roomID, userID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID = testAccCreateRoom(t)
+			userID = testAccCreateUser(t, "tf-acc-room-admin")
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_user_id", userID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			level, err := testAccPowerLevel(t, roomID, userID)
+			if err != nil {
+				return err
+			}
+			if level != 0 {
+				return fmt.Errorf("%s still has power level %v in %s", userID, level, roomID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseRoomMakeAdminResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_synapse_room_make_admin.test", "id"),
+					func(s *terraform.State) error {
+						level, err := testAccPowerLevel(t, roomID, userID)
+						if err != nil {
+							return err
+						}
+						if level != 100 {
+							return fmt.Errorf("expected power level 100 for %s, got %v", userID, level)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_room_make_admin.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccPowerLevel returns the explicit power level of the user in the room,
+// or 0 if the user has none.
+func testAccPowerLevel(t *testing.T, roomID string, userID string) (float64, error) {
+	event, err := getSynapseRoomStateEvent(testAccClient(t), roomID, "m.room.power_levels", "")
+	if err != nil || event == nil {
+		return 0, err
+	}
+
+	users, _ := event.Content["users"].(map[string]any)
+	level, _ := users[userID].(float64)
+	return level, nil
+}
+
+const testAccSynapseRoomMakeAdminResourceConfig = `
+variable "room_id" {}
+variable "user_id" {}
+
+resource "matrix_synapse_room_make_admin" "test" {
+  room_id = var.room_id
+  user_id = var.user_id
+}
+`
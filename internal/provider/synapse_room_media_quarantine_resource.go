@@ -0,0 +1,324 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseRoomMediaQuarantineResource{}
+var _ resource.ResourceWithImportState = &SynapseRoomMediaQuarantineResource{}
+
+func NewSynapseRoomMediaQuarantineResource() resource.Resource {
+	return &SynapseRoomMediaQuarantineResource{}
+}
+
+// SynapseRoomMediaQuarantineResource defines the resource implementation.
+type SynapseRoomMediaQuarantineResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomMediaQuarantineResourceModel describes the resource data model.
+type SynapseRoomMediaQuarantineResourceModel struct {
+	RoomID        types.String `tfsdk:"room_id"`
+	Media         types.List   `tfsdk:"media"`
+	QuarantinedAt types.String `tfsdk:"quarantined_at"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// synapseRoomMedia is the response of the Synapse admin API listing the
+// media referenced in a room.
+type synapseRoomMedia struct {
+	Local  []string `json:"local"`
+	Remote []string `json:"remote"`
+}
+
+// splitMXCURI splits an `mxc://` URI into the server name and media ID.
+func splitMXCURI(uri string) (string, string, bool) {
+	return strings.Cut(strings.TrimPrefix(uri, "mxc://"), "/")
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_media_quarantine"
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Quarantines all media referenced in a room using the Synapse admin API. " +
+			"The media is recorded when the resource is created, media posted to the room later is not quarantined. " +
+			"Destroying the resource releases the recorded media from quarantine one by one. " +
+			"If any recorded media is released outside of Terraform, the resource is planned for recreation, which quarantines the room again.\n\n" +
+			"Quarantining a single `mxc://` URI is deliberately not supported here, as it is identified by the media instead of the room: " +
+			"use `matrix_synapse_media_quarantine` for that.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room whose media to quarantine.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"media": schema.ListAttribute{
+				MarkdownDescription: "The `mxc://` URIs of the media referenced in the room when it was quarantined. " +
+					"On import, the media of the room that is currently quarantined.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"quarantined_at": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 timestamp of when the media was quarantined by this resource. " +
+					"Null after import, as Synapse does not report when media was quarantined.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the room",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseRoomMediaQuarantineResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Record the media first, Synapse does not report what it quarantined.
+	var roomMedia synapseRoomMedia
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "room", data.RoomID.ValueString(), "media"), nil, &roomMedia)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list room media, got error: %s", err))
+		return
+	}
+
+	var quarantined struct {
+		NumQuarantined int64 `json:"num_quarantined"`
+	}
+	err = r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "room", data.RoomID.ValueString(), "media", "quarantine"), struct{}{}, &quarantined)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to quarantine room media, got error: %s", err))
+		return
+	}
+
+	media, diags := types.ListValueFrom(ctx, types.StringType, append(roomMedia.Local, roomMedia.Remote...))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Media = media
+	data.QuarantinedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	data.Id = data.RoomID
+
+	tflog.Trace(ctx, "quarantined room media", map[string]any{"room_id": data.RoomID.ValueString(), "num_quarantined": quarantined.NumQuarantined})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseRoomMediaQuarantineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// On import nothing is recorded yet, so only the media of the room that
+	// is quarantined now is taken over.
+	imported := data.Media.IsNull()
+
+	var media []string
+	if imported {
+		var roomMedia synapseRoomMedia
+		err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "room", data.RoomID.ValueString(), "media"), nil, &roomMedia)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list room media, got error: %s", err))
+			return
+		}
+
+		media = append(roomMedia.Local, roomMedia.Remote...)
+	} else {
+		resp.Diagnostics.Append(data.Media.ElementsAs(ctx, &media, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	quarantined := make([]string, 0, len(media))
+	for _, uri := range media {
+		serverName, mediaID, ok := splitMXCURI(uri)
+		if !ok {
+			tflog.Warn(ctx, "skipping invalid mxc URI", map[string]any{"uri": uri})
+			continue
+		}
+
+		var info synapseMediaInfo
+		err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "media", serverName, mediaID), nil, &info)
+		if err != nil {
+			// Older Synapse versions cannot query single media, keep the
+			// media as is.
+			if isUnrecognized(err) {
+				tflog.Debug(ctx, "media query admin API is not available, skipping quarantine check")
+				quarantined = media
+				break
+			}
+
+			// Deleted media no longer needs to be released.
+			if isNotFound(err) {
+				continue
+			}
+
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read media %s, got error: %s", uri, err))
+			return
+		}
+
+		if info.MediaInfo.QuarantinedBy == nil || *info.MediaInfo.QuarantinedBy == "" {
+			if imported {
+				continue
+			}
+
+			tflog.Warn(ctx, "room media was released from quarantine outside of Terraform, removing from state", map[string]any{"id": data.Id.ValueString(), "uri": uri})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		quarantined = append(quarantined, uri)
+	}
+
+	mediaValue, diags := types.ListValueFrom(ctx, types.StringType, quarantined)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Media = mediaValue
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseRoomMediaQuarantineResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseRoomMediaQuarantineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseRoomMediaQuarantineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var media []string
+	resp.Diagnostics.Append(data.Media.ElementsAs(ctx, &media, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, uri := range media {
+		serverName, mediaID, ok := splitMXCURI(uri)
+		if !ok {
+			tflog.Warn(ctx, "skipping invalid mxc URI", map[string]any{"uri": uri})
+			continue
+		}
+
+		url := synapseAdminURL(r.client, "v1", "media", "unquarantine", serverName, mediaID)
+		err := r.client.MakeRequest("POST", url, struct{}{}, nil)
+		if err != nil {
+			if isUnrecognized(err) {
+				resp.Diagnostics.AddWarning(
+					"Media Left In Quarantine",
+					"This Synapse version does not support releasing media from quarantine. "+
+						"The media of "+data.RoomID.ValueString()+" was removed from Terraform state but is still quarantined.",
+				)
+				return
+			}
+
+			if isNotFound(err) {
+				continue
+			}
+
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to release %s from quarantine, got error: %s", uri, err))
+			return
+		}
+	}
+}
+
+func (r *SynapseRoomMediaQuarantineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The homeserver does not report when the media was quarantined, so
+	// quarantined_at stays null.
+	importCompositeID(ctx, req, resp, "room_id")
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRoomMediaQuarantineResource(t *testing.T) {
+	var contentURI string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			client := testAccClient(t)
+			upload, err := client.UploadToContentRepo(strings.NewReader("quarantine me"), "text/plain", 13)
+			if err != nil {
+				t.Fatalf("unable to upload test media: %s", err)
+			}
+			contentURI = upload.ContentURI
+
+			roomID := testAccCreateRoom(t)
+			_, err = client.SendMessageEvent(roomID, "m.room.message", map[string]any{
+				"msgtype": "m.file",
+				"body":    "quarantine.txt",
+				"url":     contentURI,
+			})
+			if err != nil {
+				t.Fatalf("unable to send test media: %s", err)
+			}
+
+			t.Setenv("TF_VAR_room_id", roomID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseRoomMediaQuarantineResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_synapse_room_media_quarantine.test", "id", "matrix_synapse_room_media_quarantine.test", "room_id"),
+					resource.TestCheckResourceAttr("matrix_synapse_room_media_quarantine.test", "media.#", "1"),
+					resource.TestCheckResourceAttrPtr("matrix_synapse_room_media_quarantine.test", "media.0", &contentURI),
+					resource.TestCheckResourceAttrSet("matrix_synapse_room_media_quarantine.test", "quarantined_at"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_synapse_room_media_quarantine.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"quarantined_at"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccSynapseRoomMediaQuarantineResourceConfig = `
+variable "room_id" {}
+
+resource "matrix_synapse_room_media_quarantine" "test" {
+  room_id = var.room_id
+}
+`
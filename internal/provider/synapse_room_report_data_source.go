@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseRoomReportDataSource{}
+
+func NewSynapseRoomReportDataSource() datasource.DataSource {
+	return &SynapseRoomReportDataSource{}
+}
+
+// SynapseRoomReportDataSource defines the data source implementation.
+type SynapseRoomReportDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomReportDataSourceModel describes the data source data model.
+type SynapseRoomReportDataSourceModel struct {
+	RoomID       types.String              `tfsdk:"room_id"`
+	UserID       types.String              `tfsdk:"user_id"`
+	Limit        types.Int64               `tfsdk:"limit"`
+	From         types.Int64               `tfsdk:"from"`
+	Dir          types.String              `tfsdk:"dir"`
+	EventReports []SynapseEventReportModel `tfsdk:"event_reports"`
+	NextToken    types.Int64               `tfsdk:"next_token"`
+	Total        types.Int64               `tfsdk:"total"`
+	Id           types.String              `tfsdk:"id"`
+}
+
+// SynapseEventReportModel describes a single event report.
+type SynapseEventReportModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	ReceivedTs     types.Int64  `tfsdk:"received_ts"`
+	RoomID         types.String `tfsdk:"room_id"`
+	EventID        types.String `tfsdk:"event_id"`
+	UserID         types.String `tfsdk:"user_id"`
+	Score          types.Int64  `tfsdk:"score"`
+	Reason         types.String `tfsdk:"reason"`
+	Sender         types.String `tfsdk:"sender"`
+	CanonicalAlias types.String `tfsdk:"canonical_alias"`
+	RoomName       types.String `tfsdk:"room_name"`
+}
+
+// synapseEventReport is an event report as returned by the Synapse admin API.
+// The reporter may leave out the score and reason, and the room may have
+// neither an alias nor a name.
+type synapseEventReport struct {
+	Id             int64   `json:"id"`
+	ReceivedTs     int64   `json:"received_ts"`
+	RoomID         string  `json:"room_id"`
+	EventID        string  `json:"event_id"`
+	UserID         string  `json:"user_id"`
+	Score          *int64  `json:"score"`
+	Reason         *string `json:"reason"`
+	Sender         string  `json:"sender"`
+	CanonicalAlias *string `json:"canonical_alias"`
+	Name           *string `json:"name"`
+}
+
+func (d *SynapseRoomReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_report"
+}
+
+func (d *SynapseRoomReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists event reports submitted by users using the Synapse admin API. " +
+			"Use `matrix_synapse_delete_event_report` to delete reports once they are handled.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "Only list reports about events in this room.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Only list reports submitted by this user.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of reports to return. Synapse defaults to 100.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"from": schema.Int64Attribute{
+				MarkdownDescription: "The offset to start listing from, usually the `next_token` of a previous read.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"dir": schema.StringAttribute{
+				MarkdownDescription: "The direction to list reports in, `b` for newest first or `f` for oldest first. " +
+					"Synapse defaults to `b`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("f", "b"),
+				},
+			},
+			"event_reports": schema.ListNestedAttribute{
+				MarkdownDescription: "The event reports.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the report.",
+							Computed:            true,
+						},
+						"received_ts": schema.Int64Attribute{
+							MarkdownDescription: "When the report was received, in milliseconds since the epoch.",
+							Computed:            true,
+						},
+						"room_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the room the reported event is in.",
+							Computed:            true,
+						},
+						"event_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the reported event.",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who submitted the report.",
+							Computed:            true,
+						},
+						"score": schema.Int64Attribute{
+							MarkdownDescription: "The score given by the reporter, from -100 (most offensive) to 0 (inoffensive).",
+							Computed:            true,
+						},
+						"reason": schema.StringAttribute{
+							MarkdownDescription: "The reason given by the reporter.",
+							Computed:            true,
+						},
+						"sender": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who sent the reported event.",
+							Computed:            true,
+						},
+						"canonical_alias": schema.StringAttribute{
+							MarkdownDescription: "The canonical alias of the room.",
+							Computed:            true,
+						},
+						"room_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the room.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"next_token": schema.Int64Attribute{
+				MarkdownDescription: "The `from` value to read the next page of reports with. Null if there are no more reports.",
+				Computed:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "The total number of reports matching the filters.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseRoomReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseRoomReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseRoomReportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.RoomID.IsNull() {
+		query.Set("room_id", data.RoomID.ValueString())
+	}
+	if !data.UserID.IsNull() {
+		query.Set("user_id", data.UserID.ValueString())
+	}
+	if !data.Limit.IsNull() {
+		query.Set("limit", strconv.FormatInt(data.Limit.ValueInt64(), 10))
+	}
+	if !data.From.IsNull() {
+		query.Set("from", strconv.FormatInt(data.From.ValueInt64(), 10))
+	}
+	if !data.Dir.IsNull() {
+		query.Set("dir", data.Dir.ValueString())
+	}
+
+	reportsURL := synapseAdminURL(d.client, "v1", "event_reports")
+	if len(query) > 0 {
+		reportsURL += "?" + query.Encode()
+	}
+
+	var reportsResp struct {
+		EventReports []synapseEventReport `json:"event_reports"`
+		NextToken    *int64               `json:"next_token"`
+		Total        int64                `json:"total"`
+	}
+	err := d.client.MakeRequest("GET", reportsURL, nil, &reportsResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event reports, got error: %s", err))
+		return
+	}
+
+	data.EventReports = make([]SynapseEventReportModel, 0, len(reportsResp.EventReports))
+	for _, report := range reportsResp.EventReports {
+		data.EventReports = append(data.EventReports, SynapseEventReportModel{
+			Id:             types.Int64Value(report.Id),
+			ReceivedTs:     types.Int64Value(report.ReceivedTs),
+			RoomID:         types.StringValue(report.RoomID),
+			EventID:        types.StringValue(report.EventID),
+			UserID:         types.StringValue(report.UserID),
+			Score:          types.Int64PointerValue(report.Score),
+			Reason:         types.StringPointerValue(report.Reason),
+			Sender:         types.StringValue(report.Sender),
+			CanonicalAlias: types.StringPointerValue(report.CanonicalAlias),
+			RoomName:       types.StringPointerValue(report.Name),
+		})
+	}
+	data.NextToken = types.Int64PointerValue(reportsResp.NextToken)
+	data.Total = types.Int64Value(reportsResp.Total)
+	data.Id = types.StringValue("event_reports")
+
+	tflog.Trace(ctx, "read event reports", map[string]any{"count": len(data.EventReports), "total": reportsResp.Total})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
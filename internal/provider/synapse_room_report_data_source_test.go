@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRoomReportDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			eventID := testAccSendMessage(t, roomID, "reported by terraform acceptance tests")
+			reportID := testAccReportEvent(t, roomID, eventID)
+
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", eventID)
+			t.Setenv("TF_VAR_report_id", strconv.FormatInt(reportID, 10))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseRoomReportDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_report.test", "total", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_report.test", "event_reports.#", "1"),
+					resource.TestCheckResourceAttrPair("data.matrix_synapse_room_report.test", "event_reports.0.room_id", "data.matrix_synapse_room_report.test", "room_id"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_report.test", "event_reports.0.score", "-100"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_room_report.test", "event_reports.0.reason", "tf-acc-report"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_room_report.test", "event_reports.0.received_ts"),
+					resource.TestCheckNoResourceAttr("data.matrix_synapse_room_report.test", "next_token"),
+					resource.TestCheckOutput("matches", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseRoomReportDataSourceConfig = `
+variable "room_id" {}
+variable "event_id" {}
+variable "report_id" {}
+
+data "matrix_synapse_room_report" "test" {
+  room_id = var.room_id
+  dir     = "f"
+  limit   = 10
+}
+
+output "matches" {
+  value = (
+    data.matrix_synapse_room_report.test.event_reports[0].id == tonumber(var.report_id) &&
+    data.matrix_synapse_room_report.test.event_reports[0].event_id == var.event_id
+  )
+}
+`
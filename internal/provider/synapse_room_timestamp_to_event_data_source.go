@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseRoomTimestampToEventDataSource{}
+
+func NewSynapseRoomTimestampToEventDataSource() datasource.DataSource {
+	return &SynapseRoomTimestampToEventDataSource{}
+}
+
+// SynapseRoomTimestampToEventDataSource defines the data source implementation.
+type SynapseRoomTimestampToEventDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseRoomTimestampToEventDataSourceModel describes the data source data model.
+type SynapseRoomTimestampToEventDataSourceModel struct {
+	RoomID         types.String `tfsdk:"room_id"`
+	Ts             types.Int64  `tfsdk:"ts"`
+	Dir            types.String `tfsdk:"dir"`
+	EventID        types.String `tfsdk:"event_id"`
+	OriginServerTs types.Int64  `tfsdk:"origin_server_ts"`
+	Id             types.String `tfsdk:"id"`
+}
+
+func (d *SynapseRoomTimestampToEventDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_room_timestamp_to_event"
+}
+
+func (d *SynapseRoomTimestampToEventDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Finds the event of a room closest to a point in time, e.g. to feed the " +
+			"`purge_up_to_event_id` of a `matrix_synapse_purge_history` resource.\n\n" +
+			"The provider user must be able to see the events of the room.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room to search.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"ts": schema.Int64Attribute{
+				MarkdownDescription: "The point in time to search from, in milliseconds since the epoch.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"dir": schema.StringAttribute{
+				MarkdownDescription: "The direction to search in, `b` for the closest event at or before `ts` " +
+					"or `f` for the closest event at or after it.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("f", "b"),
+				},
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the closest event.",
+				Computed:            true,
+			},
+			"origin_server_ts": schema.Int64Attribute{
+				MarkdownDescription: "When the closest event was sent, in milliseconds since the epoch.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the closest event",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseRoomTimestampToEventDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseRoomTimestampToEventDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseRoomTimestampToEventDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("ts", strconv.FormatInt(data.Ts.ValueInt64(), 10))
+	query.Set("dir", data.Dir.ValueString())
+
+	var event struct {
+		EventID        string `json:"event_id"`
+		OriginServerTs int64  `json:"origin_server_ts"`
+	}
+	eventURL := clientV1URL(d.client, "rooms", data.RoomID.ValueString(), "timestamp_to_event") + "?" + query.Encode()
+	err := d.client.MakeRequest("GET", eventURL, nil, &event)
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Event Not Found",
+				fmt.Sprintf("The room %s has no event in direction %q of %d.", data.RoomID.ValueString(), data.Dir.ValueString(), data.Ts.ValueInt64()),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find event by timestamp, got error: %s", err))
+		return
+	}
+
+	data.EventID = types.StringValue(event.EventID)
+	data.OriginServerTs = types.Int64Value(event.OriginServerTs)
+	data.Id = data.EventID
+
+	tflog.Trace(ctx, "found event by timestamp", map[string]any{"room_id": data.RoomID.ValueString(), "event_id": event.EventID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
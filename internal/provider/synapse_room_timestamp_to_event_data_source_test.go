@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseRoomTimestampToEventDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			roomID := testAccCreateRoom(t)
+			t.Setenv("TF_VAR_room_id", roomID)
+			t.Setenv("TF_VAR_event_id", testAccSendMessage(t, roomID, "latest message"))
+			t.Setenv("TF_VAR_ts", fmt.Sprint(time.Now().Add(time.Minute).UnixMilli()))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseRoomTimestampToEventDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_room_timestamp_to_event.test", "origin_server_ts"),
+					resource.TestCheckOutput("matches", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseRoomTimestampToEventDataSourceConfig = `
+variable "room_id" {}
+variable "event_id" {}
+variable "ts" {}
+
+data "matrix_synapse_room_timestamp_to_event" "test" {
+  room_id = var.room_id
+  ts      = var.ts
+  dir     = "b"
+}
+
+output "matches" {
+  value = data.matrix_synapse_room_timestamp_to_event.test.event_id == var.event_id
+}
+`
@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseServerNoticeResource{}
+var _ resource.ResourceWithImportState = &SynapseServerNoticeResource{}
+
+func NewSynapseServerNoticeResource() resource.Resource {
+	return &SynapseServerNoticeResource{}
+}
+
+// SynapseServerNoticeResource defines the resource implementation.
+type SynapseServerNoticeResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseServerNoticeResourceModel describes the resource data model.
+type SynapseServerNoticeResourceModel struct {
+	UserID         types.String `tfsdk:"user_id"`
+	ContentMsgtype types.String `tfsdk:"content_msgtype"`
+	ContentBody    types.String `tfsdk:"content_body"`
+	EventID        types.String `tfsdk:"event_id"`
+	RoomID         types.String `tfsdk:"room_id"`
+	Id             types.String `tfsdk:"id"`
+}
+
+func (r *SynapseServerNoticeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_server_notice"
+}
+
+func (r *SynapseServerNoticeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a server notice to a local user using the Synapse admin API. " +
+			"Server notices must be enabled in the homeserver configuration. Changing the notice sends an `m.replace` edit, " +
+			"so it stays in place in the notices room.\n\n" +
+			"Server notices cannot be unsent, so destroying this resource only removes it from the Terraform state. " +
+			"To detect notices that were deleted from the notice room, `default_user_id` must be the server notices user " +
+			"(`server_notices.system_mxid_localpart`) and a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user to notify.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"content_msgtype": schema.StringAttribute{
+				MarkdownDescription: "The `msgtype` of the notice. Defaults to `m.text`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("m.text"),
+			},
+			"content_body": schema.StringAttribute{
+				MarkdownDescription: "The plain text body of the notice.",
+				Required:            true,
+			},
+			"event_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the notice event.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the server notices room the event was sent to. " +
+					"Empty if the provider user is not a member of that room.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the notice event",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseServerNoticeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// content builds the notice content from the model.
+func (m SynapseServerNoticeResourceModel) content() roomMessageContent {
+	return roomMessageContent{
+		MsgType: m.ContentMsgtype.ValueString(),
+		Body:    m.ContentBody.ValueString(),
+	}
+}
+
+// sendNotice sends content to the server notices room of userID.
+func (r *SynapseServerNoticeResource) sendNotice(userID string, content any) (string, error) {
+	reqBody := map[string]any{
+		"user_id": userID,
+		"content": content,
+	}
+
+	var sendResp gomatrix.RespSendEvent
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "send_server_notice"), reqBody, &sendResp)
+	if err != nil {
+		return "", err
+	}
+
+	return sendResp.EventID, nil
+}
+
+func (r *SynapseServerNoticeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseServerNoticeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	eventID, err := r.sendNotice(data.UserID.ValueString(), data.content())
+	if err != nil {
+		if matrixErrCode(err) == "M_FORBIDDEN" {
+			resp.Diagnostics.AddError(
+				"Server Notice Forbidden",
+				fmt.Sprintf("The homeserver refused to send the server notice as %s. "+
+					"The provider's default_user_id must be a server admin and should be the designated server notices user "+
+					"(server_notices.system_mxid_localpart in homeserver.yaml).\n\n"+
+					"Matrix Client Error: %s", r.client.UserID, err),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to send server notice, got error: %s", err))
+		return
+	}
+
+	data.EventID = types.StringValue(eventID)
+	data.Id = data.EventID
+	data.RoomID = types.StringValue(r.findNoticeRoom(ctx, data.UserID.ValueString(), eventID))
+
+	tflog.Trace(ctx, "sent server notice", map[string]any{"event_id": eventID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findNoticeRoom looks for the room containing eventID among the rooms shared
+// by the provider user and userID. The send_server_notice API does not return
+// the room, so this only succeeds if the provider user is the notices user.
+func (r *SynapseServerNoticeResource) findNoticeRoom(ctx context.Context, userID string, eventID string) string {
+	ownRooms, err := r.client.JoinedRooms()
+	if err != nil {
+		tflog.Debug(ctx, "unable to list joined rooms of provider user", map[string]any{"error": err.Error()})
+		return ""
+	}
+
+	var userRooms synapseJoinedRooms
+	err = r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "users", userID, "joined_rooms"), nil, &userRooms)
+	if err != nil {
+		tflog.Debug(ctx, "unable to list joined rooms of notified user", map[string]any{"error": err.Error()})
+		return ""
+	}
+
+	shared := make(map[string]bool, len(ownRooms.JoinedRooms))
+	for _, roomID := range ownRooms.JoinedRooms {
+		shared[roomID] = true
+	}
+
+	for _, roomID := range userRooms.JoinedRooms {
+		if !shared[roomID] {
+			continue
+		}
+
+		err = r.client.MakeRequest("GET", r.client.BuildURL("rooms", roomID, "event", eventID), nil, nil)
+		if err == nil {
+			return roomID
+		}
+	}
+
+	return ""
+}
+
+func (r *SynapseServerNoticeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseServerNoticeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.RoomID.ValueString() == "" {
+		tflog.Debug(ctx, "server notice room is unknown, skipping existence check", map[string]any{"event_id": data.EventID.ValueString()})
+		return
+	}
+
+	var event gomatrix.Event
+	err := r.client.MakeRequest("GET", r.client.BuildURL("rooms", data.RoomID.ValueString(), "event", data.EventID.ValueString()), nil, &event)
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "server notice is gone and needs to be sent again", map[string]any{"event_id": data.EventID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server notice, got error: %s", err))
+		return
+	}
+
+	// Redacted notices have no content left, keep what was sent in that case.
+	if msgtype, ok := event.Content["msgtype"].(string); ok {
+		data.ContentMsgtype = types.StringValue(msgtype)
+	}
+	if body, ok := event.Content["body"].(string); ok {
+		data.ContentBody = types.StringValue(body)
+	}
+
+	edit, err := latestMessageEdit(r.client, data.RoomID.ValueString(), data.EventID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server notice edits, got error: %s", err))
+		return
+	}
+	if edit != nil {
+		data.ContentMsgtype = types.StringValue(edit.MsgType)
+		data.ContentBody = types.StringValue(edit.Body)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseServerNoticeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SynapseServerNoticeResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.content() != state.content() {
+		// The edit goes through the same API, which sends it to the same
+		// notices room as the original notice.
+		_, err := r.sendNotice(data.UserID.ValueString(), newRoomMessageEdit(data.EventID.ValueString(), data.content()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to edit server notice, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseServerNoticeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseServerNoticeResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Server Notice Not Unsent",
+		"Server notices cannot be unsent. The notice "+data.EventID.ValueString()+" was removed from Terraform state, "+
+			"but "+data.UserID.ValueString()+" can still read it.",
+	)
+}
+
+func (r *SynapseServerNoticeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := importCompositeID(ctx, req, resp, "user_id", "room_id", "event_id")
+	if parts == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseServerNoticeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-server-notice"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseServerNoticeResourceConfig("Scheduled maintenance tonight"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_server_notice.test", "content_msgtype", "m.text"),
+					resource.TestCheckResourceAttrSet("matrix_synapse_server_notice.test", "event_id"),
+					resource.TestCheckResourceAttrPair("matrix_synapse_server_notice.test", "id", "matrix_synapse_server_notice.test", "event_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseServerNoticeResourceConfig("Scheduled maintenance moved to tomorrow"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_server_notice.test", "content_body", "Scheduled maintenance moved to tomorrow"),
+					resource.TestCheckResourceAttrPair("matrix_synapse_server_notice.test", "id", "matrix_synapse_server_notice.test", "event_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapseServerNoticeResourceConfig(body string) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_server_notice" "test" {
+  user_id      = var.user_id
+  content_body = %[1]q
+}
+`, body)
+}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseStatsRoomDataSource{}
+
+func NewSynapseStatsRoomDataSource() datasource.DataSource {
+	return &SynapseStatsRoomDataSource{}
+}
+
+// SynapseStatsRoomDataSource defines the data source implementation.
+type SynapseStatsRoomDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseStatsRoomDataSourceModel describes the data source data model.
+type SynapseStatsRoomDataSourceModel struct {
+	OrderBy    types.String            `tfsdk:"order_by"`
+	Dir        types.String            `tfsdk:"dir"`
+	Limit      types.Int64             `tfsdk:"limit"`
+	From       types.Int64             `tfsdk:"from"`
+	SearchTerm types.String            `tfsdk:"search_term"`
+	Rooms      []SynapseRoomStatsModel `tfsdk:"rooms"`
+	NextBatch  types.Int64             `tfsdk:"next_batch"`
+	TotalRooms types.Int64             `tfsdk:"total_rooms"`
+	Id         types.String            `tfsdk:"id"`
+}
+
+// SynapseRoomStatsModel describes the statistics of a single room.
+type SynapseRoomStatsModel struct {
+	RoomID             types.String `tfsdk:"room_id"`
+	Name               types.String `tfsdk:"name"`
+	CanonicalAlias     types.String `tfsdk:"canonical_alias"`
+	JoinedMembers      types.Int64  `tfsdk:"joined_members"`
+	JoinedLocalMembers types.Int64  `tfsdk:"joined_local_members"`
+	Version            types.String `tfsdk:"version"`
+	Creator            types.String `tfsdk:"creator"`
+	Encryption         types.String `tfsdk:"encryption"`
+	Federatable        types.Bool   `tfsdk:"federatable"`
+	Public             types.Bool   `tfsdk:"public"`
+	JoinRules          types.String `tfsdk:"join_rules"`
+	GuestAccess        types.String `tfsdk:"guest_access"`
+	HistoryVisibility  types.String `tfsdk:"history_visibility"`
+	StateEvents        types.Int64  `tfsdk:"state_events"`
+}
+
+// synapseRoomStats is a room as listed by the Synapse admin API. Everything
+// taken from state events is null if the room lacks the event.
+type synapseRoomStats struct {
+	RoomID             string  `json:"room_id"`
+	Name               *string `json:"name"`
+	CanonicalAlias     *string `json:"canonical_alias"`
+	JoinedMembers      int64   `json:"joined_members"`
+	JoinedLocalMembers int64   `json:"joined_local_members"`
+	Version            *string `json:"version"`
+	Creator            *string `json:"creator"`
+	Encryption         *string `json:"encryption"`
+	Federatable        bool    `json:"federatable"`
+	Public             bool    `json:"public"`
+	JoinRules          *string `json:"join_rules"`
+	GuestAccess        *string `json:"guest_access"`
+	HistoryVisibility  *string `json:"history_visibility"`
+	StateEvents        int64   `json:"state_events"`
+}
+
+// roomStatsOrderBy are the fields the Synapse room list can be sorted by.
+var roomStatsOrderBy = []string{
+	"name", "canonical_alias", "joined_members", "joined_local_members", "version", "creator",
+	"encryption", "federatable", "public", "join_rules", "guest_access", "history_visibility", "state_events",
+}
+
+func (d *SynapseStatsRoomDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_stats_room"
+}
+
+func (d *SynapseStatsRoomDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the rooms known to the homeserver with their statistics using the Synapse admin API.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"order_by": schema.StringAttribute{
+				MarkdownDescription: "The field to sort the rooms by, one of `name`, `canonical_alias`, `joined_members`, " +
+					"`joined_local_members`, `version`, `creator`, `encryption`, `federatable`, `public`, `join_rules`, " +
+					"`guest_access`, `history_visibility` or `state_events`. Synapse defaults to `name`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(roomStatsOrderBy...),
+				},
+			},
+			"dir": schema.StringAttribute{
+				MarkdownDescription: "The direction to sort in, `f` for ascending or `b` for descending. Synapse defaults to `f`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("f", "b"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of rooms to return. Synapse defaults to 100.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"from": schema.Int64Attribute{
+				MarkdownDescription: "The offset to start listing from, usually the `next_batch` of a previous read.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"search_term": schema.StringAttribute{
+				MarkdownDescription: "Only list rooms whose name, canonical alias or ID contains this term.",
+				Optional:            true,
+			},
+			"rooms": schema.ListNestedAttribute{
+				MarkdownDescription: "The rooms.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"room_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the room.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the room.",
+							Computed:            true,
+						},
+						"canonical_alias": schema.StringAttribute{
+							MarkdownDescription: "The canonical alias of the room.",
+							Computed:            true,
+						},
+						"joined_members": schema.Int64Attribute{
+							MarkdownDescription: "The number of joined members.",
+							Computed:            true,
+						},
+						"joined_local_members": schema.Int64Attribute{
+							MarkdownDescription: "The number of joined members of this homeserver.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The version of the room.",
+							Computed:            true,
+						},
+						"creator": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who created the room.",
+							Computed:            true,
+						},
+						"encryption": schema.StringAttribute{
+							MarkdownDescription: "The encryption algorithm of the room, null if the room is not encrypted.",
+							Computed:            true,
+						},
+						"federatable": schema.BoolAttribute{
+							MarkdownDescription: "Whether other homeservers can join the room.",
+							Computed:            true,
+						},
+						"public": schema.BoolAttribute{
+							MarkdownDescription: "Whether the room is listed in the public room directory.",
+							Computed:            true,
+						},
+						"join_rules": schema.StringAttribute{
+							MarkdownDescription: "The join rule of the room.",
+							Computed:            true,
+						},
+						"guest_access": schema.StringAttribute{
+							MarkdownDescription: "The guest access of the room.",
+							Computed:            true,
+						},
+						"history_visibility": schema.StringAttribute{
+							MarkdownDescription: "The history visibility of the room.",
+							Computed:            true,
+						},
+						"state_events": schema.Int64Attribute{
+							MarkdownDescription: "The number of state events in the room.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"next_batch": schema.Int64Attribute{
+				MarkdownDescription: "The `from` value to read the next page of rooms with. Null if there are no more rooms.",
+				Computed:            true,
+			},
+			"total_rooms": schema.Int64Attribute{
+				MarkdownDescription: "The total number of rooms matching `search_term`.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseStatsRoomDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseStatsRoomDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseStatsRoomDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.OrderBy.IsNull() {
+		query.Set("order_by", data.OrderBy.ValueString())
+	}
+	if !data.Dir.IsNull() {
+		query.Set("dir", data.Dir.ValueString())
+	}
+	if !data.Limit.IsNull() {
+		query.Set("limit", strconv.FormatInt(data.Limit.ValueInt64(), 10))
+	}
+	if !data.From.IsNull() {
+		query.Set("from", strconv.FormatInt(data.From.ValueInt64(), 10))
+	}
+	if !data.SearchTerm.IsNull() {
+		query.Set("search_term", data.SearchTerm.ValueString())
+	}
+
+	roomsURL := synapseAdminURL(d.client, "v1", "rooms")
+	if len(query) > 0 {
+		roomsURL += "?" + query.Encode()
+	}
+
+	var roomsResp struct {
+		Rooms      []synapseRoomStats `json:"rooms"`
+		NextBatch  *int64             `json:"next_batch"`
+		TotalRooms int64              `json:"total_rooms"`
+	}
+	err := d.client.MakeRequest("GET", roomsURL, nil, &roomsResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read room statistics, got error: %s", err))
+		return
+	}
+
+	data.Rooms = make([]SynapseRoomStatsModel, 0, len(roomsResp.Rooms))
+	for _, room := range roomsResp.Rooms {
+		data.Rooms = append(data.Rooms, SynapseRoomStatsModel{
+			RoomID:             types.StringValue(room.RoomID),
+			Name:               types.StringPointerValue(room.Name),
+			CanonicalAlias:     types.StringPointerValue(room.CanonicalAlias),
+			JoinedMembers:      types.Int64Value(room.JoinedMembers),
+			JoinedLocalMembers: types.Int64Value(room.JoinedLocalMembers),
+			Version:            types.StringPointerValue(room.Version),
+			Creator:            types.StringPointerValue(room.Creator),
+			Encryption:         types.StringPointerValue(room.Encryption),
+			Federatable:        types.BoolValue(room.Federatable),
+			Public:             types.BoolValue(room.Public),
+			JoinRules:          types.StringPointerValue(room.JoinRules),
+			GuestAccess:        types.StringPointerValue(room.GuestAccess),
+			HistoryVisibility:  types.StringPointerValue(room.HistoryVisibility),
+			StateEvents:        types.Int64Value(room.StateEvents),
+		})
+	}
+	data.NextBatch = types.Int64PointerValue(roomsResp.NextBatch)
+	data.TotalRooms = types.Int64Value(roomsResp.TotalRooms)
+	data.Id = types.StringValue("rooms")
+
+	tflog.Trace(ctx, "read room statistics", map[string]any{"count": len(data.Rooms), "total": roomsResp.TotalRooms})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
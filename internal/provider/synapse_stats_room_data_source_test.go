@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestAccSynapseStatsRoomDataSource(t *testing.T) {
+	name := "tf-acc-stats-" + acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			_, err := testAccClient(t).CreateRoom(&gomatrix.ReqCreateRoom{Name: name, Preset: "private_chat"})
+			if err != nil {
+				t.Fatalf("unable to create test room: %s", err)
+			}
+
+			t.Setenv("TF_VAR_name", name)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseStatsRoomDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_room.test", "total_rooms", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_room.test", "rooms.#", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_room.test", "rooms.0.name", name),
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_room.test", "rooms.0.joined_members", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_room.test", "rooms.0.join_rules", "invite"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_room.test", "rooms.0.public", "false"),
+					resource.TestCheckNoResourceAttr("data.matrix_synapse_stats_room.test", "next_batch"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseStatsRoomDataSourceConfig = `
+variable "name" {}
+
+data "matrix_synapse_stats_room" "test" {
+  search_term = var.name
+  order_by    = "joined_members"
+  dir         = "b"
+  limit       = 10
+}
+`
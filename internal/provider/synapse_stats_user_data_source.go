@@ -0,0 +1,350 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseStatsUserDataSource{}
+
+func NewSynapseStatsUserDataSource() datasource.DataSource {
+	return &SynapseStatsUserDataSource{}
+}
+
+// SynapseStatsUserDataSource defines the data source implementation.
+type SynapseStatsUserDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseStatsUserDataSourceModel describes the data source data model.
+type SynapseStatsUserDataSourceModel struct {
+	Guests      types.Bool              `tfsdk:"guests"`
+	Deactivated types.Bool              `tfsdk:"deactivated"`
+	Admin       types.Bool              `tfsdk:"admin"`
+	Limit       types.Int64             `tfsdk:"limit"`
+	From        types.String            `tfsdk:"from"`
+	OrderBy     types.String            `tfsdk:"order_by"`
+	Dir         types.String            `tfsdk:"dir"`
+	MaxUsers    types.Int64             `tfsdk:"max_users"`
+	Users       []SynapseUserStatsModel `tfsdk:"users"`
+	NextToken   types.String            `tfsdk:"next_token"`
+	Total       types.Int64             `tfsdk:"total"`
+	Id          types.String            `tfsdk:"id"`
+}
+
+// SynapseUserStatsModel describes a single user.
+type SynapseUserStatsModel struct {
+	Name         types.String `tfsdk:"name"`
+	IsGuest      types.Bool   `tfsdk:"is_guest"`
+	Admin        types.Bool   `tfsdk:"admin"`
+	UserType     types.String `tfsdk:"user_type"`
+	Deactivated  types.Bool   `tfsdk:"deactivated"`
+	ShadowBanned types.Bool   `tfsdk:"shadow_banned"`
+	AvatarURL    types.String `tfsdk:"avatar_url"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	CreationTs   types.Int64  `tfsdk:"creation_ts"`
+	MediaCount   types.Int64  `tfsdk:"media_count"`
+}
+
+// synapseListedUser is a user as listed by the Synapse admin API.
+type synapseListedUser struct {
+	Name         string  `json:"name"`
+	IsGuest      bool    `json:"is_guest"`
+	Admin        bool    `json:"admin"`
+	UserType     *string `json:"user_type"`
+	Deactivated  bool    `json:"deactivated"`
+	ShadowBanned bool    `json:"shadow_banned"`
+	AvatarURL    *string `json:"avatar_url"`
+	DisplayName  *string `json:"displayname"`
+	CreationTs   int64   `json:"creation_ts"`
+}
+
+// userStatsOrderBy are the fields the Synapse user list can be sorted by.
+var userStatsOrderBy = []string{
+	"name", "is_guest", "admin", "user_type", "deactivated", "shadow_banned", "displayname", "avatar_url", "creation_ts",
+}
+
+// defaultMaxSynapseUsers caps how many users are fetched if max_users is unset.
+const defaultMaxSynapseUsers = 1000
+
+func (d *SynapseStatsUserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_stats_user"
+}
+
+func (d *SynapseStatsUserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the local users of the homeserver with the number of media they uploaded, " +
+			"using the Synapse admin API. Pages are fetched until `max_users` users are returned.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"guests": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include guest users. Synapse defaults to `true`.",
+				Optional:            true,
+			},
+			"deactivated": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include deactivated users. Synapse defaults to `false`.",
+				Optional:            true,
+			},
+			"admin": schema.BoolAttribute{
+				MarkdownDescription: "Only list server admins if `true`, only other users if `false`. Lists both if unset.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The number of users to request per page. Defaults to the homeserver default.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "A pagination token to start from, e.g. the `next_token` of another lookup.",
+				Optional:            true,
+			},
+			"order_by": schema.StringAttribute{
+				MarkdownDescription: "The field to sort the users by, one of `name`, `is_guest`, `admin`, `user_type`, " +
+					"`deactivated`, `shadow_banned`, `displayname`, `avatar_url` or `creation_ts`. Synapse defaults to `name`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(userStatsOrderBy...),
+				},
+			},
+			"dir": schema.StringAttribute{
+				MarkdownDescription: "The direction to sort in, `f` for ascending or `b` for descending. Synapse defaults to `f`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("f", "b"),
+				},
+			},
+			"max_users": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of users to return. Defaults to `%d`.", defaultMaxSynapseUsers),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "The users, in the order returned by the homeserver.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The fully qualified ID of the user.",
+							Computed:            true,
+						},
+						"is_guest": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is a guest.",
+							Computed:            true,
+						},
+						"admin": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is a server admin.",
+							Computed:            true,
+						},
+						"user_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the user, e.g. `support` or `bot`. Null for regular users.",
+							Computed:            true,
+						},
+						"deactivated": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is deactivated.",
+							Computed:            true,
+						},
+						"shadow_banned": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is shadow-banned.",
+							Computed:            true,
+						},
+						"avatar_url": schema.StringAttribute{
+							MarkdownDescription: "The `mxc://` URL of the avatar of the user.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the user.",
+							Computed:            true,
+						},
+						"creation_ts": schema.Int64Attribute{
+							MarkdownDescription: "When the user was created, in milliseconds since the epoch.",
+							Computed:            true,
+						},
+						"media_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of media the user uploaded.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"next_token": schema.StringAttribute{
+				MarkdownDescription: "The `from` value to read the next page of users with. Null if there are no more users.",
+				Computed:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "The total number of users matching the filters.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseStatsUserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// getUserMediaCounts returns the number of uploaded media per user. Users
+// who never uploaded anything are not part of the statistics.
+func getUserMediaCounts(client *gomatrix.Client) (map[string]int64, error) {
+	counts := map[string]int64{}
+
+	query := url.Values{}
+	for {
+		var page struct {
+			Users []struct {
+				UserID     string `json:"user_id"`
+				MediaCount int64  `json:"media_count"`
+			} `json:"users"`
+			NextToken *int64 `json:"next_token"`
+		}
+		err := client.MakeRequest("GET", synapseAdminURL(client, "v1", "statistics", "users", "media")+"?"+query.Encode(), nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range page.Users {
+			counts[user.UserID] = user.MediaCount
+		}
+
+		if page.NextToken == nil || len(page.Users) == 0 {
+			return counts, nil
+		}
+
+		query.Set("from", strconv.FormatInt(*page.NextToken, 10))
+	}
+}
+
+func (d *SynapseStatsUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseStatsUserDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxUsers := int64(defaultMaxSynapseUsers)
+	if !data.MaxUsers.IsNull() {
+		maxUsers = data.MaxUsers.ValueInt64()
+	}
+
+	query := url.Values{}
+	if !data.Guests.IsNull() {
+		query.Set("guests", strconv.FormatBool(data.Guests.ValueBool()))
+	}
+	if !data.Deactivated.IsNull() {
+		query.Set("deactivated", strconv.FormatBool(data.Deactivated.ValueBool()))
+	}
+	if !data.Admin.IsNull() {
+		query.Set("admins", strconv.FormatBool(data.Admin.ValueBool()))
+	}
+	if !data.OrderBy.IsNull() {
+		query.Set("order_by", data.OrderBy.ValueString())
+	}
+	if !data.Dir.IsNull() {
+		query.Set("dir", data.Dir.ValueString())
+	}
+	if !data.From.IsNull() {
+		query.Set("from", data.From.ValueString())
+	}
+
+	mediaCounts, err := getUserMediaCounts(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user media statistics, got error: %s", err))
+		return
+	}
+
+	data.Users = []SynapseUserStatsModel{}
+
+	for {
+		// Never request more users than are still missing, so next_token
+		// always points right behind the last returned user.
+		limit := maxUsers - int64(len(data.Users))
+		if !data.Limit.IsNull() && data.Limit.ValueInt64() < limit {
+			limit = data.Limit.ValueInt64()
+		}
+		query.Set("limit", strconv.FormatInt(limit, 10))
+
+		var page struct {
+			Users     []synapseListedUser `json:"users"`
+			NextToken *string             `json:"next_token"`
+			Total     int64               `json:"total"`
+		}
+		err := d.client.MakeRequest("GET", synapseAdminURL(d.client, "v2", "users")+"?"+query.Encode(), nil, &page)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read users, got error: %s", err))
+			return
+		}
+
+		for _, user := range page.Users {
+			data.Users = append(data.Users, SynapseUserStatsModel{
+				Name:         types.StringValue(user.Name),
+				IsGuest:      types.BoolValue(user.IsGuest),
+				Admin:        types.BoolValue(user.Admin),
+				UserType:     types.StringPointerValue(user.UserType),
+				Deactivated:  types.BoolValue(user.Deactivated),
+				ShadowBanned: types.BoolValue(user.ShadowBanned),
+				AvatarURL:    types.StringPointerValue(user.AvatarURL),
+				DisplayName:  types.StringPointerValue(user.DisplayName),
+				CreationTs:   types.Int64Value(user.CreationTs),
+				MediaCount:   types.Int64Value(mediaCounts[user.Name]),
+			})
+		}
+
+		data.Total = types.Int64Value(page.Total)
+		data.NextToken = types.StringPointerValue(page.NextToken)
+
+		if page.NextToken == nil || len(page.Users) == 0 || int64(len(data.Users)) >= maxUsers {
+			break
+		}
+
+		query.Set("from", *page.NextToken)
+	}
+
+	data.Id = types.StringValue("users")
+
+	tflog.Trace(ctx, "read users", map[string]any{"users": len(data.Users)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseStatsUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCreateUser(t, "tf-acc-stats-user-a")
+			testAccCreateUser(t, "tf-acc-stats-user-b")
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseStatsUserDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_user.admins", "users.0.admin", "true"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_stats_user.admins", "users.0.media_count"),
+					// Two pages of one user each.
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_user.paged", "users.#", "2"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_stats_user.paged", "users.0.admin", "false"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_stats_user.paged", "next_token"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseStatsUserDataSourceConfig = `
+data "matrix_synapse_stats_user" "admins" {
+  admin = true
+}
+
+data "matrix_synapse_stats_user" "paged" {
+  admin     = false
+  order_by  = "creation_ts"
+  limit     = 1
+  max_users = 2
+}
+`
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseUserAdminResource{}
+var _ resource.ResourceWithImportState = &SynapseUserAdminResource{}
+var _ resource.ResourceWithModifyPlan = &SynapseUserAdminResource{}
+
+func NewSynapseUserAdminResource() resource.Resource {
+	return &SynapseUserAdminResource{}
+}
+
+// SynapseUserAdminResource defines the resource implementation.
+type SynapseUserAdminResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserAdminResourceModel describes the resource data model.
+type SynapseUserAdminResourceModel struct {
+	UserID types.String `tfsdk:"user_id"`
+	Id     types.String `tfsdk:"id"`
+}
+
+// synapseUserAdminContent is the body of the Synapse admin API for the
+// server admin flag of a user.
+type synapseUserAdminContent struct {
+	Admin bool `json:"admin"`
+}
+
+// setAdmin grants or revokes the server admin flag.
+func (r *SynapseUserAdminResource) setAdmin(userID string, admin bool) error {
+	return r.client.MakeRequest("PUT", synapseAdminURL(r.client, "v1", "users", userID, "admin"), synapseUserAdminContent{Admin: admin}, nil)
+}
+
+func (r *SynapseUserAdminResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_admin"
+}
+
+func (r *SynapseUserAdminResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Makes an existing local user a server admin using the Synapse admin API, so the list of " +
+			"server admins is kept in version control. Destroying the resource revokes the admin flag. The provider " +
+			"user cannot revoke its own admin flag, as it would lose access to the admin API.\n\n" +
+			"Do not use this resource for users managed by `matrix_synapse_user`, set its `admin` attribute instead.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user, e.g. `@alice:example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseUserAdminResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// ModifyPlan refuses to plan revoking the admin flag of the provider user,
+// which would lock the provider out of the admin API.
+func (r *SynapseUserAdminResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only destroy plans are checked, and only once the provider is
+	// configured.
+	if !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var state SynapseUserAdminResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.UserID.ValueString() == r.client.UserID {
+		resp.Diagnostics.AddError(
+			"Refusing to Revoke Provider User Admin",
+			fmt.Sprintf("The user %s is the provider user, revoking its admin flag would lock the provider out of the admin API. "+
+				"Remove it from the state with terraform state rm to stop managing it.", state.UserID.ValueString()),
+		)
+	}
+}
+
+func (r *SynapseUserAdminResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseUserAdminResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Synapse sets the flag for users that do not exist, which Read would
+	// then never find.
+	_, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"User Not Found",
+				fmt.Sprintf("The user %s does not exist. Create it first, e.g. with matrix_synapse_user.", data.UserID.ValueString()),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	err = r.setAdmin(data.UserID.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to make user a server admin, got error: %s", err))
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "made user a server admin", map[string]any{"user_id": data.UserID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserAdminResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseUserAdminResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content synapseUserAdminContent
+	err := r.client.MakeRequest("GET", synapseAdminURL(r.client, "v1", "users", data.UserID.ValueString(), "admin"), nil, &content)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server admin flag, got error: %s", err))
+		return
+	}
+
+	if !content.Admin {
+		tflog.Warn(ctx, "admin flag was revoked outside of Terraform", map[string]any{"user_id": data.UserID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserAdminResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseUserAdminResourceModel
+
+	// user_id requires replacement, so there is nothing to send to the
+	// homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserAdminResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseUserAdminResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setAdmin(data.UserID.ValueString(), false)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke server admin flag, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseUserAdminResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id")
+}
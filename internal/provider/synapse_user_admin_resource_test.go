@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSynapseUserAdminResource(t *testing.T) {
+	var userID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			userID = testAccCreateUser(t, "tf-acc-admin")
+			t.Setenv("TF_VAR_user_id", userID)
+			t.Setenv("TF_VAR_missing_user_id", "@tf-acc-missing-admin:"+testAccServerName())
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			user, err := getSynapseUser(testAccClient(t), userID)
+			if err != nil {
+				return err
+			}
+			if user.Admin {
+				return fmt.Errorf("expected %s to no longer be a server admin", userID)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Missing user testing
+			{
+				Config: `
+variable "missing_user_id" {}
+
+resource "matrix_synapse_user_admin" "test" {
+  user_id = var.missing_user_id
+}
+`,
+				ExpectError: regexp.MustCompile(`User Not Found`),
+			},
+			// Create and Read testing
+			{
+				Config: `
+variable "user_id" {}
+
+resource "matrix_synapse_user_admin" "test" {
+  user_id = var.user_id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_synapse_user_admin.test", "id", "matrix_synapse_user_admin.test", "user_id"),
+					func(s *terraform.State) error {
+						user, err := getSynapseUser(testAccClient(t), userID)
+						if err != nil {
+							return err
+						}
+						if !user.Admin {
+							return fmt.Errorf("expected %s to be a server admin", userID)
+						}
+
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_user_admin.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
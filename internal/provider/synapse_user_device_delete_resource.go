@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseUserDeviceDeleteResource{}
+var _ resource.ResourceWithImportState = &SynapseUserDeviceDeleteResource{}
+
+func NewSynapseUserDeviceDeleteResource() resource.Resource {
+	return &SynapseUserDeviceDeleteResource{}
+}
+
+// SynapseUserDeviceDeleteResource defines the resource implementation.
+type SynapseUserDeviceDeleteResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserDeviceDeleteResourceModel describes the resource data model.
+type SynapseUserDeviceDeleteResourceModel struct {
+	UserID   types.String `tfsdk:"user_id"`
+	DeviceID types.String `tfsdk:"device_id"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (r *SynapseUserDeviceDeleteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_device_delete"
+}
+
+func (r *SynapseUserDeviceDeleteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes a device of a local user using the Synapse admin API, which also invalidates its access token. " +
+			"Creating the resource deletes the device. If a device with the same ID shows up again, " +
+			"the resource is planned for creation again so the device gets deleted once more. " +
+			"Destroying the resource does nothing on the homeserver.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user owning the device.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the device to delete.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/device_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseUserDeviceDeleteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseUserDeviceDeleteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseUserDeviceDeleteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := synapseAdminURL(r.client, "v2", "users", data.UserID.ValueString(), "devices", data.DeviceID.ValueString())
+	err := r.client.MakeRequest("DELETE", url, nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete device, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + data.DeviceID.ValueString())
+
+	tflog.Trace(ctx, "deleted device", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserDeviceDeleteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseUserDeviceDeleteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := synapseAdminURL(r.client, "v2", "users", data.UserID.ValueString(), "devices", data.DeviceID.ValueString())
+	err := r.client.MakeRequest("GET", url, nil, nil)
+	if err != nil {
+		// The device is still gone, which is the desired state.
+		if isNotFound(err) {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device, got error: %s", err))
+		return
+	}
+
+	tflog.Warn(ctx, "deleted device exists again, it will be deleted on the next apply", map[string]any{"id": data.Id.ValueString()})
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *SynapseUserDeviceDeleteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseUserDeviceDeleteResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserDeviceDeleteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A deleted device and its access token cannot be restored, only forget
+	// the resource.
+}
+
+func (r *SynapseUserDeviceDeleteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id", "device_id")
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestAccSynapseUserDeviceDeleteResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			userID := testAccCreateUser(t, "tf-acc-device-delete")
+			_, err := testAccClient(t).Login(&gomatrix.ReqLogin{
+				Type:       "m.login.password",
+				Identifier: gomatrix.NewUserIdentifier(userID),
+				Password:   "tf-acc-device-delete-password",
+				DeviceID:   "TFACCDEVICE",
+			})
+			if err != nil {
+				t.Fatalf("unable to log in test user: %s", err)
+			}
+
+			t.Setenv("TF_VAR_user_id", userID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The device exists before the resource is applied
+			{
+				Config: testAccSynapseUserDeviceDeleteDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_synapse_user_devices.test", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_user_devices.test", "devices.0.device_id", "TFACCDEVICE"),
+				),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSynapseUserDeviceDeleteResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_user_device_delete.test", "device_id", "TFACCDEVICE"),
+					resource.TestCheckResourceAttrSet("matrix_synapse_user_device_delete.test", "id"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_user_devices.test", "devices.#", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_user_device_delete.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccSynapseUserDeviceDeleteDataSourceConfig = `
+variable "user_id" {}
+
+data "matrix_synapse_user_devices" "test" {
+  user_id = var.user_id
+}
+`
+
+const testAccSynapseUserDeviceDeleteResourceConfig = `
+variable "user_id" {}
+
+resource "matrix_synapse_user_device_delete" "test" {
+  user_id   = var.user_id
+  device_id = "TFACCDEVICE"
+}
+
+data "matrix_synapse_user_devices" "test" {
+  user_id = var.user_id
+
+  depends_on = [matrix_synapse_user_device_delete.test]
+}
+`
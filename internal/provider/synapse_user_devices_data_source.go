@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseUserDevicesDataSource{}
+
+func NewSynapseUserDevicesDataSource() datasource.DataSource {
+	return &SynapseUserDevicesDataSource{}
+}
+
+// SynapseUserDevicesDataSource defines the data source implementation.
+type SynapseUserDevicesDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserDevicesDataSourceModel describes the data source data model.
+type SynapseUserDevicesDataSourceModel struct {
+	UserID  types.String             `tfsdk:"user_id"`
+	Devices []SynapseUserDeviceModel `tfsdk:"devices"`
+	Id      types.String             `tfsdk:"id"`
+}
+
+// SynapseUserDeviceModel describes a single device of a user.
+type SynapseUserDeviceModel struct {
+	DeviceID          types.String `tfsdk:"device_id"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	LastSeenIP        types.String `tfsdk:"last_seen_ip"`
+	LastSeenUserAgent types.String `tfsdk:"last_seen_user_agent"`
+	LastSeenTs        types.Int64  `tfsdk:"last_seen_ts"`
+}
+
+// synapseDevice is a device as returned by the Synapse admin API. All fields
+// but the device ID may be null.
+type synapseDevice struct {
+	DeviceID          string  `json:"device_id"`
+	DisplayName       *string `json:"display_name"`
+	LastSeenIP        *string `json:"last_seen_ip"`
+	LastSeenUserAgent *string `json:"last_seen_user_agent"`
+	LastSeenTs        *int64  `json:"last_seen_ts"`
+}
+
+func (d *SynapseUserDevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_devices"
+}
+
+func (d *SynapseUserDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all devices of a local user using the Synapse admin API. " +
+			"If the user does not exist the list is empty and a warning is emitted.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"devices": schema.ListNestedAttribute{
+				MarkdownDescription: "The devices of the user.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the device.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the device.",
+							Computed:            true,
+						},
+						"last_seen_ip": schema.StringAttribute{
+							MarkdownDescription: "The IP address the device was last seen from.",
+							Computed:            true,
+						},
+						"last_seen_user_agent": schema.StringAttribute{
+							MarkdownDescription: "The user agent the device was last seen with.",
+							Computed:            true,
+						},
+						"last_seen_ts": schema.Int64Attribute{
+							MarkdownDescription: "When the device was last seen, in milliseconds since the epoch.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseUserDevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseUserDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseUserDevicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var devicesResp struct {
+		Devices []synapseDevice `json:"devices"`
+	}
+	err := d.client.MakeRequest("GET", synapseAdminURL(d.client, "v2", "users", data.UserID.ValueString(), "devices"), nil, &devicesResp)
+	if err != nil {
+		if !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user devices, got error: %s", err))
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"User Not Found",
+			fmt.Sprintf("The user %s does not exist, returning an empty device list.", data.UserID.ValueString()),
+		)
+	}
+
+	data.Devices = make([]SynapseUserDeviceModel, 0, len(devicesResp.Devices))
+	for _, device := range devicesResp.Devices {
+		data.Devices = append(data.Devices, SynapseUserDeviceModel{
+			DeviceID:          types.StringValue(device.DeviceID),
+			DisplayName:       types.StringPointerValue(device.DisplayName),
+			LastSeenIP:        types.StringPointerValue(device.LastSeenIP),
+			LastSeenUserAgent: types.StringPointerValue(device.LastSeenUserAgent),
+			LastSeenTs:        types.Int64PointerValue(device.LastSeenTs),
+		})
+	}
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "read user devices", map[string]any{"user_id": data.UserID.ValueString(), "count": len(data.Devices)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
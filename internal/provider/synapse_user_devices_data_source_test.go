@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseUserDevicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_self_user_id", os.Getenv("MATRIX_DEFAULT_USERID"))
+			t.Setenv("TF_VAR_missing_user_id", "@tf-acc-does-not-exist:"+testAccServerName())
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseUserDevicesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The provider user has at least the device of its access token.
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_user_devices.self", "devices.0.device_id"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_user_devices.missing", "devices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseUserDevicesDataSourceConfig = `
+variable "self_user_id" {}
+variable "missing_user_id" {}
+
+data "matrix_synapse_user_devices" "self" {
+  user_id = var.self_user_id
+}
+
+data "matrix_synapse_user_devices" "missing" {
+  user_id = var.missing_user_id
+}
+`
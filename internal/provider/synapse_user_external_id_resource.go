@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseUserExternalIDResource{}
+var _ resource.ResourceWithImportState = &SynapseUserExternalIDResource{}
+
+func NewSynapseUserExternalIDResource() resource.Resource {
+	return &SynapseUserExternalIDResource{}
+}
+
+// SynapseUserExternalIDResource defines the resource implementation.
+type SynapseUserExternalIDResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserExternalIDResourceModel describes the resource data model.
+type SynapseUserExternalIDResourceModel struct {
+	UserID       types.String `tfsdk:"user_id"`
+	AuthProvider types.String `tfsdk:"auth_provider"`
+	ExternalID   types.String `tfsdk:"external_id"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// binding returns the external ID of the model as sent to the admin API.
+func (m SynapseUserExternalIDResourceModel) binding() synapseExternalID {
+	return synapseExternalID{
+		AuthProvider: m.AuthProvider.ValueString(),
+		ExternalID:   m.ExternalID.ValueString(),
+	}
+}
+
+// hasExternalID reports whether the binding is in the list.
+func hasExternalID(externalIDs []synapseExternalID, binding synapseExternalID) bool {
+	for _, externalID := range externalIDs {
+		if externalID == binding {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *SynapseUserExternalIDResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_external_id"
+}
+
+func (r *SynapseUserExternalIDResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Binds a local user account to the subject of a single sign-on provider using the Synapse " +
+			"admin API, so the user is logged into the existing account on their first SSO login instead of getting " +
+			"a new one. The admin API only allows replacing the whole list of external IDs of a user, " +
+			"so avoid managing the same user's external IDs from outside Terraform at the same time.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"auth_provider": schema.StringAttribute{
+				MarkdownDescription: "The ID of the SSO provider as configured on the homeserver, " +
+					"e.g. `oidc-keycloak` for an OIDC provider with `idp_id: keycloak`.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user at the SSO provider, e.g. the `sub` claim of an OIDC provider.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/auth_provider/external_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseUserExternalIDResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// setExternalIDs replaces all external IDs of the user.
+func (r *SynapseUserExternalIDResource) setExternalIDs(userID string, externalIDs []synapseExternalID) error {
+	reqBody := map[string]any{"external_ids": externalIDs}
+	return r.client.MakeRequest("PUT", synapseAdminURL(r.client, "v2", "users", userID), reqBody, nil)
+}
+
+func (r *SynapseUserExternalIDResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseUserExternalIDResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if !hasExternalID(user.ExternalIDs, data.binding()) {
+		// Synapse refuses external IDs that are bound to another user.
+		err = r.setExternalIDs(data.UserID.ValueString(), append(user.ExternalIDs, data.binding()))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add external ID, got error: %s", err))
+			return
+		}
+	}
+
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + data.AuthProvider.ValueString() + "/" + data.ExternalID.ValueString())
+
+	tflog.Trace(ctx, "added external ID", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserExternalIDResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseUserExternalIDResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if !hasExternalID(user.ExternalIDs, data.binding()) {
+		tflog.Warn(ctx, "external ID was removed outside of Terraform", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserExternalIDResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseUserExternalIDResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserExternalIDResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseUserExternalIDResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if !hasExternalID(user.ExternalIDs, data.binding()) {
+		return
+	}
+
+	externalIDs := make([]synapseExternalID, 0, len(user.ExternalIDs))
+	for _, externalID := range user.ExternalIDs {
+		if externalID != data.binding() {
+			externalIDs = append(externalIDs, externalID)
+		}
+	}
+
+	err = r.setExternalIDs(data.UserID.ValueString(), externalIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove external ID, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseUserExternalIDResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id", "auth_provider", "external_id")
+}
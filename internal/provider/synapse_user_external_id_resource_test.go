@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSynapseUserExternalIDResource(t *testing.T) {
+	externalID := "tf-acc-" + acctest.RandString(8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-external-id"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSynapseUserExternalID(t, false),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseUserExternalIDResourceConfig(externalID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_user_external_id.test", "auth_provider", "oidc-test"),
+					resource.TestCheckResourceAttr("matrix_synapse_user_external_id.test", "external_id", externalID),
+					testAccCheckSynapseUserExternalID(t, true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_user_external_id.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSynapseUserExternalID asserts whether the external ID is bound
+// to the test user as reported by the admin API.
+func testAccCheckSynapseUserExternalID(t *testing.T, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "matrix_synapse_user_external_id" {
+				continue
+			}
+
+			user, err := getSynapseUser(client, rs.Primary.Attributes["user_id"])
+			if err != nil {
+				return err
+			}
+
+			binding := synapseExternalID{
+				AuthProvider: rs.Primary.Attributes["auth_provider"],
+				ExternalID:   rs.Primary.Attributes["external_id"],
+			}
+			if hasExternalID(user.ExternalIDs, binding) != expected {
+				return fmt.Errorf("expected %s bound to %s: %t", rs.Primary.ID, user.Name, expected)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccSynapseUserExternalIDResourceConfig(externalID string) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_user_external_id" "test" {
+  user_id       = var.user_id
+  auth_provider = "oidc-test"
+  external_id   = %[1]q
+}
+`, externalID)
+}
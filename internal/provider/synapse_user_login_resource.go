@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseUserLoginResource{}
+
+func NewSynapseUserLoginResource() resource.Resource {
+	return &SynapseUserLoginResource{}
+}
+
+// SynapseUserLoginResource defines the resource implementation.
+type SynapseUserLoginResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserLoginResourceModel describes the resource data model.
+type SynapseUserLoginResourceModel struct {
+	UserID       types.String `tfsdk:"user_id"`
+	ValidUntilMs types.Int64  `tfsdk:"valid_until_ms"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	DeviceID     types.String `tfsdk:"device_id"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// matrixWhoami is the response of the whoami endpoint.
+type matrixWhoami struct {
+	UserID   string `json:"user_id"`
+	DeviceID string `json:"device_id"`
+}
+
+// getWhoami returns the user and device of the access token of the client.
+func getWhoami(client *gomatrix.Client) (*matrixWhoami, error) {
+	var whoami matrixWhoami
+	err := client.MakeRequest("GET", client.BuildURL("account", "whoami"), nil, &whoami)
+	if err != nil {
+		return nil, err
+	}
+
+	return &whoami, nil
+}
+
+func (r *SynapseUserLoginResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_login"
+}
+
+func (r *SynapseUserLoginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates an access token for a local user without knowing their password using the Synapse admin API, " +
+			"e.g. to act as that user in an aliased provider block. A new token is created once the token expired or was " +
+			"logged out. Destroying the resource logs the token out. The token is stored in the Terraform state.\n\n" +
+			"The provider user must be a server admin and cannot create a token for itself.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user to log in as.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"valid_until_ms": schema.Int64Attribute{
+				MarkdownDescription: "When the access token expires, in milliseconds since the epoch. " +
+					"The token does not expire if unset.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "The access token of the user.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the device the access token belongs to.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `user_id/device_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseUserLoginResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseUserLoginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseUserLoginResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := map[string]any{}
+	if !data.ValidUntilMs.IsNull() {
+		reqBody["valid_until_ms"] = data.ValidUntilMs.ValueInt64()
+	}
+
+	var loginResp struct {
+		AccessToken string `json:"access_token"`
+	}
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "users", data.UserID.ValueString(), "login"), reqBody, &loginResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to log in as user, got error: %s", err))
+		return
+	}
+
+	// The admin API only returns the token, the device is needed to tell
+	// tokens of the same user apart.
+	whoami, err := getWhoami(clientAsUser(r.client, data.UserID.ValueString(), loginResp.AccessToken))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device of the new access token, got error: %s", err))
+		return
+	}
+
+	data.AccessToken = types.StringValue(loginResp.AccessToken)
+	data.DeviceID = types.StringValue(whoami.DeviceID)
+	data.Id = types.StringValue(data.UserID.ValueString() + "/" + whoami.DeviceID)
+
+	tflog.Trace(ctx, "logged in as user", map[string]any{"user_id": data.UserID.ValueString(), "device_id": whoami.DeviceID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserLoginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseUserLoginResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ValidUntilMs.IsNull() && time.Now().After(time.UnixMilli(data.ValidUntilMs.ValueInt64())) {
+		tflog.Warn(ctx, "access token expired, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	_, err := getWhoami(clientAsUser(r.client, data.UserID.ValueString(), data.AccessToken.ValueString()))
+	if err != nil {
+		if matrixErrCode(err) == "M_UNKNOWN_TOKEN" {
+			tflog.Warn(ctx, "access token is no longer valid, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check access token, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserLoginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SynapseUserLoginResourceModel
+
+	// All configurable attributes require replacement, so there is nothing
+	// to send to the homeserver here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserLoginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseUserLoginResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := clientAsUser(r.client, data.UserID.ValueString(), data.AccessToken.ValueString()).Logout()
+	if err != nil && matrixErrCode(err) != "M_UNKNOWN_TOKEN" {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to log out access token, got error: %s", err))
+		return
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSynapseUserLoginResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-login"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseUserLoginResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("matrix_synapse_user_login.test", "access_token"),
+					resource.TestCheckResourceAttrSet("matrix_synapse_user_login.test", "device_id"),
+					testAccCheckSynapseUserLoginToken(t, "matrix_synapse_user_login.test"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSynapseUserLoginToken checks that the access token of the
+// resource authenticates as its user.
+func testAccCheckSynapseUserLoginToken(t *testing.T, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		userID := rs.Primary.Attributes["user_id"]
+		whoami, err := getWhoami(clientAsUser(testAccClient(t), userID, rs.Primary.Attributes["access_token"]))
+		if err != nil {
+			return err
+		}
+
+		if whoami.UserID != userID {
+			return fmt.Errorf("expected access token of %s, got one of %s", userID, whoami.UserID)
+		}
+
+		return nil
+	}
+}
+
+const testAccSynapseUserLoginResourceConfig = `
+variable "user_id" {}
+
+resource "matrix_synapse_user_login" "test" {
+  user_id = var.user_id
+}
+`
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseUserPasswordResource{}
+var _ resource.ResourceWithImportState = &SynapseUserPasswordResource{}
+
+func NewSynapseUserPasswordResource() resource.Resource {
+	return &SynapseUserPasswordResource{}
+}
+
+// SynapseUserPasswordResource defines the resource implementation.
+type SynapseUserPasswordResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserPasswordResourceModel describes the resource data model.
+type SynapseUserPasswordResourceModel struct {
+	UserID            types.String `tfsdk:"user_id"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWoVersion types.Int64  `tfsdk:"password_wo_version"`
+	LogoutDevices     types.Bool   `tfsdk:"logout_devices"`
+	Id                types.String `tfsdk:"id"`
+}
+
+// resetPassword sets the password of the user using the Synapse admin API.
+// The password is write-only, so it is taken from the configuration rather
+// than the plan.
+func (r *SynapseUserPasswordResource) resetPassword(ctx context.Context, config tfsdk.Config, data SynapseUserPasswordResourceModel) diag.Diagnostics {
+	var password types.String
+	diags := config.GetAttribute(ctx, path.Root("password"), &password)
+	if diags.HasError() {
+		return diags
+	}
+
+	reqBody := map[string]interface{}{
+		"new_password":   password.ValueString(),
+		"logout_devices": data.LogoutDevices.ValueBool(),
+	}
+
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "reset_password", data.UserID.ValueString()), reqBody, nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to reset password, got error: %s", err))
+	}
+
+	return diags
+}
+
+func (r *SynapseUserPasswordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_password"
+}
+
+func (r *SynapseUserPasswordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resets the password of an existing local user using the Synapse admin API. The password " +
+			"is write-only and never stored in the Terraform state, so Terraform cannot tell when it changes. It is set on " +
+			"create and whenever `password_wo_version` changes. Changes made by the user are not detected. " +
+			"Destroying the resource only stops managing the password.\n\n" +
+			"Requires Terraform 1.11 or later.\n\n" +
+			"Do not use this resource for users managed by `matrix_synapse_user`, set its `password` attribute instead.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user, e.g. `@alice:example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The new password of the user. Write-only, it is not stored in the Terraform state.",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to reset the password to the current value of `password` again.",
+				Optional:            true,
+			},
+			"logout_devices": schema.BoolAttribute{
+				MarkdownDescription: "Whether to log out all devices of the user when the password is reset. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseUserPasswordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (r *SynapseUserPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseUserPasswordResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.resetPassword(ctx, req.Config, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "reset user password", map[string]any{"user_id": data.UserID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseUserPasswordResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The password cannot be read back, so only check the user still exists.
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	if user.Deactivated {
+		tflog.Warn(ctx, "user was deactivated outside of Terraform, removing from state", map[string]any{"user_id": data.UserID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SynapseUserPasswordResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The password itself is not in the state, so only a new version can
+	// tell that it changed. logout_devices only applies to the next reset,
+	// so changing it alone does not log anyone out.
+	if !data.PasswordWoVersion.Equal(state.PasswordWoVersion) {
+		resp.Diagnostics.Append(r.resetPassword(ctx, req.Config, data)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A password cannot be unset, so deleting the resource only removes it
+	// from the Terraform state.
+}
+
+func (r *SynapseUserPasswordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id")
+
+	// logout_devices only exists in Terraform, assume the default. The
+	// password cannot be read back, setting password_wo_version in the
+	// configuration resets it on the next apply.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("logout_devices"), true)...)
+}
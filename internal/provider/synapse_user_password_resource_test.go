@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestAccSynapseUserPasswordResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-password"))
+		},
+		// Write-only attributes need Terraform 1.11.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseUserPasswordResourceConfig("correct horse battery staple", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_synapse_user_password.test", "id", "matrix_synapse_user_password.test", "user_id"),
+					resource.TestCheckResourceAttr("matrix_synapse_user_password.test", "logout_devices", "true"),
+					resource.TestCheckNoResourceAttr("matrix_synapse_user_password.test", "password"),
+					testAccCheckSynapseUserPassword(t, "matrix_synapse_user_password.test", "correct horse battery staple"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_synapse_user_password.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password_wo_version"},
+			},
+			// A new password without a new version is not sent
+			{
+				Config: testAccSynapseUserPasswordResourceConfig("battery staple correct horse", 1),
+				Check:  testAccCheckSynapseUserPassword(t, "matrix_synapse_user_password.test", "correct horse battery staple"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSynapseUserPasswordResourceConfig("battery staple correct horse", 2),
+				Check:  testAccCheckSynapseUserPassword(t, "matrix_synapse_user_password.test", "battery staple correct horse"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSynapseUserPassword checks that the user of the resource can
+// log in with the given password.
+func testAccCheckSynapseUserPassword(t *testing.T, resourceName string, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		client := clientAsUser(testAccClient(t), "", "")
+		_, err := client.Login(&gomatrix.ReqLogin{
+			Type:     "m.login.password",
+			User:     rs.Primary.Attributes["user_id"],
+			Password: password,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to log in with the expected password: %s", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccSynapseUserPasswordResourceConfig(password string, version int) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_user_password" "test" {
+  user_id             = var.user_id
+  password            = %[1]q
+  password_wo_version = %[2]d
+}
+`, password, version)
+}
@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SynapseUserResource{}
+var _ resource.ResourceWithImportState = &SynapseUserResource{}
+var _ resource.ResourceWithModifyPlan = &SynapseUserResource{}
+
+// synapseUserDestroyModes are the values of on_destroy of
+// matrix_synapse_user.
+var synapseUserDestroyModes = []string{"deactivate", "erase", "keep"}
+
+func NewSynapseUserResource() resource.Resource {
+	return &SynapseUserResource{}
+}
+
+// SynapseUserResource defines the resource implementation.
+type SynapseUserResource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserResourceModel describes the resource data model.
+type SynapseUserResourceModel struct {
+	UserID      types.String `tfsdk:"user_id"`
+	Password    types.String `tfsdk:"password"`
+	Displayname types.String `tfsdk:"displayname"`
+	Admin       types.Bool   `tfsdk:"admin"`
+	UserType    types.String `tfsdk:"user_type"`
+	OnDestroy   types.String `tfsdk:"on_destroy"`
+	Id          types.String `tfsdk:"id"`
+}
+
+func (r *SynapseUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user"
+}
+
+func (r *SynapseUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a local user account using the Synapse admin API, e.g. for bots, bridges and " +
+			"employees. Destroying the resource deactivates the account by default, which cannot be undone, " +
+			"see `on_destroy`. The account of the provider user is never deactivated.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user, e.g. `@alice:example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password of the user. It is only sent when it changes, so changes made by " +
+					"the user are not detected. Leave it unset for users logging in with SSO.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"displayname": schema.StringAttribute{
+				MarkdownDescription: "The display name of the user. Defaults to the localpart of the user ID.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is a server admin. Defaults to `false`. " +
+					"Do not combine it with `matrix_synapse_user_admin` for the same user.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"user_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the user, `bot` or `support`. Unset for regular users.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("bot", "support"),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What destroying the resource does to the account. `deactivate` (the default) " +
+					"deactivates it, the user cannot log in anymore and leaves all rooms. `erase` also asks other " +
+					"homeservers to forget the messages of the user, as for a GDPR erasure request. `keep` leaves " +
+					"the account as it is and only removes it from the state. Deactivation cannot be undone and the " +
+					"user ID cannot be registered again.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("deactivate"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(synapseUserDestroyModes...),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SynapseUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// ModifyPlan refuses to plan deactivating the provider user, which would
+// lock the provider out of the homeserver for good.
+func (r *SynapseUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only destroy plans are checked, and only once the provider is
+	// configured.
+	if !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var state SynapseUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.UserID.ValueString() == r.client.UserID && state.OnDestroy.ValueString() != "keep" {
+		resp.Diagnostics.AddError("Refusing to Deactivate Provider User", providerUserDeactivationMessage(state.UserID.ValueString()))
+	}
+}
+
+// providerUserDeactivationMessage explains why the provider user is not
+// deactivated.
+func providerUserDeactivationMessage(userID string) string {
+	return fmt.Sprintf("The user %s is the provider user, deactivating it would lock the provider out of the homeserver. "+
+		"Remove it from the state with terraform state rm to stop managing it, or set on_destroy to \"keep\" where the resource supports it.", userID)
+}
+
+// put creates or modifies the user. The password is only sent if it changed
+// from prior, which is nil on create.
+func (r *SynapseUserResource) put(data *SynapseUserResourceModel, prior *SynapseUserResourceModel) error {
+	// A null user_type must be sent explicitly to make the user a regular
+	// user again.
+	reqBody := map[string]interface{}{
+		"admin":     data.Admin.ValueBool(),
+		"user_type": data.UserType.ValueStringPointer(),
+	}
+	if !data.Displayname.IsUnknown() {
+		reqBody["displayname"] = data.Displayname.ValueString()
+	}
+	if !data.Password.IsNull() && (prior == nil || !data.Password.Equal(prior.Password)) {
+		reqBody["password"] = data.Password.ValueString()
+	}
+
+	var user synapseUser
+	err := r.client.MakeRequest("PUT", synapseAdminURL(r.client, "v2", "users", data.UserID.ValueString()), reqBody, &user)
+	if err != nil {
+		return err
+	}
+
+	data.Displayname = types.StringValue(user.Displayname)
+	return nil
+}
+
+func (r *SynapseUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SynapseUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The admin API modifies existing users, which must be imported instead
+	// of silently taken over.
+	_, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err == nil {
+		resp.Diagnostics.AddError(
+			"User Already Exists",
+			fmt.Sprintf("The user %s already exists. Import it with terraform import to manage it.", data.UserID.ValueString()),
+		)
+		return
+	}
+	if !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	err = r.put(&data, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
+		return
+	}
+
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "created user", map[string]any{"user_id": data.UserID.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SynapseUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getSynapseUser(r.client, data.UserID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			tflog.Warn(ctx, "user no longer exists, removing from state", map[string]any{"user_id": data.UserID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	// Deactivated users cannot be brought back, plan a new one instead.
+	if user.Deactivated {
+		tflog.Warn(ctx, "user was deactivated, removing from state", map[string]any{"user_id": data.UserID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Displayname = types.StringValue(user.Displayname)
+	data.Admin = types.BoolValue(user.Admin)
+	data.UserType = types.StringPointerValue(user.UserType)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SynapseUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.put(&data, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SynapseUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SynapseUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.OnDestroy.ValueString() == "keep" {
+		return
+	}
+
+	// Checked at plan time already, but a changed provider user could slip
+	// through with a stale plan.
+	if data.UserID.ValueString() == r.client.UserID {
+		resp.Diagnostics.AddError("Refusing to Deactivate Provider User", providerUserDeactivationMessage(data.UserID.ValueString()))
+		return
+	}
+
+	erase := data.OnDestroy.ValueString() == "erase"
+	err := r.client.MakeRequest("POST", synapseAdminURL(r.client, "v1", "deactivate", data.UserID.ValueString()), map[string]bool{"erase": erase}, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deactivate user, got error: %s", err))
+		return
+	}
+}
+
+func (r *SynapseUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "user_id")
+
+	// on_destroy only exists in Terraform, assume the default.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), "deactivate")...)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/matrix-org/gomatrix"
+)
+
+func TestAccSynapseUserResource(t *testing.T) {
+	var userID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+
+			// Deactivated users cannot be registered again.
+			userID = "@tf-acc-user-" + acctest.RandString(8) + ":" + testAccServerName()
+			t.Setenv("TF_VAR_user_id", userID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			user, err := getSynapseUser(testAccClient(t), userID)
+			if err != nil {
+				return err
+			}
+			if !user.Deactivated {
+				return fmt.Errorf("expected %s to be deactivated", userID)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseUserResourceConfig("Deploy Bot", `"bot"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_user.test", "displayname", "Deploy Bot"),
+					resource.TestCheckResourceAttr("matrix_synapse_user.test", "admin", "false"),
+					resource.TestCheckResourceAttr("matrix_synapse_user.test", "user_type", "bot"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "matrix_synapse_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			// Update and Read testing makes the bot a regular user
+			{
+				Config: testAccSynapseUserResourceConfig("Deploy", "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_user.test", "displayname", "Deploy"),
+					resource.TestCheckNoResourceAttr("matrix_synapse_user.test", "user_type"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSynapseUserResourceConfig(displayname string, userType string) string {
+	return fmt.Sprintf(`
+variable "user_id" {}
+
+resource "matrix_synapse_user" "test" {
+  user_id     = var.user_id
+  password    = "correct horse battery staple"
+  displayname = %q
+  user_type   = %s
+}
+`, displayname, userType)
+}
+
+func TestAccSynapseUserResource_keep(t *testing.T) {
+	var userID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			userID = "@tf-acc-user-" + acctest.RandString(8) + ":" + testAccServerName()
+			t.Setenv("TF_VAR_user_id", userID)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			user, err := getSynapseUser(testAccClient(t), userID)
+			if err != nil {
+				return err
+			}
+			if user.Deactivated {
+				return fmt.Errorf("expected %s to be kept active", userID)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+variable "user_id" {}
+
+resource "matrix_synapse_user" "test" {
+  user_id    = var.user_id
+  on_destroy = "keep"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_synapse_user.test", "on_destroy", "keep"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSynapseUserResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		userID      string
+		onDestroy   string
+		expectError bool
+	}{
+		"other user": {
+			userID:    "@alice:example.com",
+			onDestroy: "deactivate",
+		},
+		"provider user": {
+			userID:      "@provider:example.com",
+			onDestroy:   "deactivate",
+			expectError: true,
+		},
+		"provider user erased": {
+			userID:      "@provider:example.com",
+			onDestroy:   "erase",
+			expectError: true,
+		},
+		"provider user kept": {
+			userID:    "@provider:example.com",
+			onDestroy: "keep",
+		},
+	}
+
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewSynapseUserResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	stateType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected the schema to be an object type")
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			values := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+			for attribute, attributeType := range stateType.AttributeTypes {
+				values[attribute] = tftypes.NewValue(attributeType, nil)
+			}
+			values["user_id"] = tftypes.NewValue(tftypes.String, testCase.userID)
+			values["on_destroy"] = tftypes.NewValue(tftypes.String, testCase.onDestroy)
+
+			// A null plan is a destroy plan.
+			req := fwresource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, values)},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, nil)},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			r := &SynapseUserResource{client: &gomatrix.Client{UserID: "@provider:example.com"}}
+			r.ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
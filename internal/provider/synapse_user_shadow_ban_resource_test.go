@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSynapseUserShadowBanResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_user_id", testAccCreateUser(t, "tf-acc-shadow-ban"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSynapseUserShadowBanned(t, false),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSynapseUserShadowBanResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("matrix_synapse_user_shadow_ban.test", "id", "matrix_synapse_user_shadow_ban.test", "user_id"),
+					testAccCheckSynapseUserShadowBanned(t, true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_synapse_user_shadow_ban.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckSynapseUserShadowBanned asserts the shadow_banned flag of the
+// test user as reported by the admin API.
+func testAccCheckSynapseUserShadowBanned(t *testing.T, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "matrix_synapse_user_shadow_ban" {
+				continue
+			}
+
+			user, err := getSynapseUser(client, rs.Primary.Attributes["user_id"])
+			if err != nil {
+				return err
+			}
+
+			if user.ShadowBanned != expected {
+				return fmt.Errorf("expected shadow_banned of %s to be %t, got %t", user.Name, expected, user.ShadowBanned)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccSynapseUserShadowBanResourceConfig = `
+variable "user_id" {}
+
+resource "matrix_synapse_user_shadow_ban" "test" {
+  user_id = var.user_id
+}
+`
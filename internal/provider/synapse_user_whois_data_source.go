@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SynapseUserWhoisDataSource{}
+
+func NewSynapseUserWhoisDataSource() datasource.DataSource {
+	return &SynapseUserWhoisDataSource{}
+}
+
+// SynapseUserWhoisDataSource defines the data source implementation.
+type SynapseUserWhoisDataSource struct {
+	client *gomatrix.Client
+}
+
+// SynapseUserWhoisDataSourceModel describes the data source data model.
+type SynapseUserWhoisDataSourceModel struct {
+	UserID  types.String              `tfsdk:"user_id"`
+	Devices []SynapseWhoisDeviceModel `tfsdk:"devices"`
+	Id      types.String              `tfsdk:"id"`
+}
+
+// SynapseWhoisDeviceModel describes the sessions of a single device.
+type SynapseWhoisDeviceModel struct {
+	DeviceID types.String               `tfsdk:"device_id"`
+	Sessions []SynapseWhoisSessionModel `tfsdk:"sessions"`
+}
+
+// SynapseWhoisSessionModel describes a single session of a device.
+type SynapseWhoisSessionModel struct {
+	Connections []SynapseWhoisConnectionModel `tfsdk:"connections"`
+}
+
+// SynapseWhoisConnectionModel describes a single connection of a session.
+type SynapseWhoisConnectionModel struct {
+	IP        types.String `tfsdk:"ip"`
+	LastSeen  types.Int64  `tfsdk:"last_seen"`
+	UserAgent types.String `tfsdk:"user_agent"`
+}
+
+// synapseWhois is the response of the whois admin API. Devices are keyed by
+// their device ID.
+type synapseWhois struct {
+	Devices map[string]struct {
+		Sessions []struct {
+			Connections []struct {
+				IP        *string `json:"ip"`
+				LastSeen  *int64  `json:"last_seen"`
+				UserAgent *string `json:"user_agent"`
+			} `json:"connections"`
+		} `json:"sessions"`
+	} `json:"devices"`
+}
+
+func (d *SynapseUserWhoisDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_synapse_user_whois"
+}
+
+func (d *SynapseUserWhoisDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the IP addresses and user agents a local user connected from, per device, " +
+			"using the Synapse admin API. Users that never connected, e.g. service accounts, have no devices.\n\n" +
+			"The provider user must be a server admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The fully qualified ID of the local user.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixUserID(),
+				},
+			},
+			"devices": schema.ListNestedAttribute{
+				MarkdownDescription: "The devices of the user, sorted by device ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the device.",
+							Computed:            true,
+						},
+						"sessions": schema.ListNestedAttribute{
+							MarkdownDescription: "The sessions of the device.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"connections": schema.ListNestedAttribute{
+										MarkdownDescription: "The connections of the session.",
+										Computed:            true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"ip": schema.StringAttribute{
+													MarkdownDescription: "The IP address the device connected from.",
+													Computed:            true,
+												},
+												"last_seen": schema.Int64Attribute{
+													MarkdownDescription: "When the connection was last seen, in milliseconds since the epoch.",
+													Computed:            true,
+												},
+												"user_agent": schema.StringAttribute{
+													MarkdownDescription: "The user agent the device connected with.",
+													Computed:            true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SynapseUserWhoisDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *SynapseUserWhoisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SynapseUserWhoisDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var whois synapseWhois
+	err := d.client.MakeRequest("GET", synapseAdminURL(d.client, "v1", "whois", data.UserID.ValueString()), nil, &whois)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user whois, got error: %s", err))
+		return
+	}
+
+	deviceIDs := make([]string, 0, len(whois.Devices))
+	for deviceID := range whois.Devices {
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+	sort.Strings(deviceIDs)
+
+	data.Devices = make([]SynapseWhoisDeviceModel, 0, len(deviceIDs))
+	for _, deviceID := range deviceIDs {
+		device := SynapseWhoisDeviceModel{
+			DeviceID: types.StringValue(deviceID),
+			Sessions: []SynapseWhoisSessionModel{},
+		}
+
+		for _, session := range whois.Devices[deviceID].Sessions {
+			connections := make([]SynapseWhoisConnectionModel, 0, len(session.Connections))
+			for _, connection := range session.Connections {
+				connections = append(connections, SynapseWhoisConnectionModel{
+					IP:        types.StringPointerValue(connection.IP),
+					LastSeen:  types.Int64PointerValue(connection.LastSeen),
+					UserAgent: types.StringPointerValue(connection.UserAgent),
+				})
+			}
+
+			device.Sessions = append(device.Sessions, SynapseWhoisSessionModel{Connections: connections})
+		}
+
+		data.Devices = append(data.Devices, device)
+	}
+	data.Id = data.UserID
+
+	tflog.Trace(ctx, "read user whois", map[string]any{"user_id": data.UserID.ValueString(), "devices": len(data.Devices)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSynapseUserWhoisDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			t.Setenv("TF_VAR_self_user_id", os.Getenv("MATRIX_DEFAULT_USERID"))
+			t.Setenv("TF_VAR_idle_user_id", testAccCreateUser(t, "tf-acc-whois-idle"))
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSynapseUserWhoisDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The provider user connected at least through its access token.
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_user_whois.self", "devices.0.device_id"),
+					resource.TestCheckResourceAttrSet("data.matrix_synapse_user_whois.self", "devices.0.sessions.0.connections.0.ip"),
+					resource.TestCheckResourceAttr("data.matrix_synapse_user_whois.idle", "devices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSynapseUserWhoisDataSourceConfig = `
+variable "self_user_id" {}
+variable "idle_user_id" {}
+
+data "matrix_synapse_user_whois" "self" {
+  user_id = var.self_user_id
+}
+
+data "matrix_synapse_user_whois" "idle" {
+  user_id = var.idle_user_id
+}
+`
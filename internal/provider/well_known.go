@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// wellKnownClient is the result of the client well-known discovery.
+type wellKnownClient struct {
+	HomeserverURL     string
+	IdentityServerURL string
+}
+
+// wellKnownClientResponse is the body of /.well-known/matrix/client.
+type wellKnownClientResponse struct {
+	Homeserver *struct {
+		BaseURL string `json:"base_url"`
+	} `json:"m.homeserver"`
+	IdentityServer *struct {
+		BaseURL string `json:"base_url"`
+	} `json:"m.identity_server"`
+}
+
+// discoverHomeserver resolves the client-server API URL of a server name
+// following the well-known URI discovery of the client-server API
+// specification. A server without a well-known file is assumed to serve the
+// API at https://<server_name>.
+// See https://spec.matrix.org/latest/client-server-api/#well-known-uri
+func discoverHomeserver(ctx context.Context, httpClient *http.Client, serverName string) (*wellKnownClient, error) {
+	var body wellKnownClientResponse
+	found, err := getJSON(ctx, httpClient, "https://"+serverName+"/.well-known/matrix/client", &body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch well-known file of %s: %w", serverName, err)
+	}
+
+	if !found {
+		return &wellKnownClient{HomeserverURL: "https://" + serverName}, nil
+	}
+
+	if body.Homeserver == nil || body.Homeserver.BaseURL == "" {
+		return nil, fmt.Errorf("well-known file of %s has no m.homeserver base_url", serverName)
+	}
+
+	homeserverURL, err := validateDiscoveredURL(ctx, httpClient, body.Homeserver.BaseURL, "/_matrix/client/versions")
+	if err != nil {
+		return nil, fmt.Errorf("invalid homeserver in well-known file of %s: %w", serverName, err)
+	}
+
+	result := &wellKnownClient{HomeserverURL: homeserverURL}
+
+	if body.IdentityServer != nil {
+		result.IdentityServerURL, err = validateDiscoveredURL(ctx, httpClient, body.IdentityServer.BaseURL, "/_matrix/identity/v2")
+		if err != nil {
+			return nil, fmt.Errorf("invalid identity server in well-known file of %s: %w", serverName, err)
+		}
+	}
+
+	return result, nil
+}
+
+// validateDiscoveredURL checks that a discovered base URL is a valid URL
+// serving the given API endpoint and returns it without trailing slash.
+func validateDiscoveredURL(ctx context.Context, httpClient *http.Client, baseURL string, endpoint string) (string, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return "", fmt.Errorf("%q is not a valid URL", baseURL)
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	var versions map[string]any
+	found, err := getJSON(ctx, httpClient, baseURL+endpoint, &versions)
+	if err != nil {
+		return "", fmt.Errorf("%s is not reachable: %w", baseURL, err)
+	}
+	if !found {
+		return "", fmt.Errorf("%s does not serve %s", baseURL, endpoint)
+	}
+
+	return baseURL, nil
+}
+
+// getJSON decodes the JSON body of a GET request. It returns false if the
+// server answered with a 404.
+func getJSON(ctx context.Context, httpClient *http.Client, url string, out any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(out)
+	if err != nil {
+		return false, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WellKnownDiscoveryDataSource{}
+
+func NewWellKnownDiscoveryDataSource() datasource.DataSource {
+	return &WellKnownDiscoveryDataSource{}
+}
+
+// WellKnownDiscoveryDataSource defines the data source implementation.
+type WellKnownDiscoveryDataSource struct {
+	client *gomatrix.Client
+}
+
+// WellKnownDiscoveryDataSourceModel describes the data source data model.
+type WellKnownDiscoveryDataSourceModel struct {
+	ServerName        types.String `tfsdk:"server_name"`
+	HomeserverURL     types.String `tfsdk:"homeserver_url"`
+	IdentityServerURL types.String `tfsdk:"identity_server_url"`
+	Id                types.String `tfsdk:"id"`
+}
+
+func (d *WellKnownDiscoveryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_well_known_discovery"
+}
+
+func (d *WellKnownDiscoveryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves the client-server API URL of a Matrix server name through its " +
+			"`/.well-known/matrix/client` file, as specified for client discovery. " +
+			"Servers without a well-known file are assumed to serve the API at `https://<server_name>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"server_name": schema.StringAttribute{
+				MarkdownDescription: "The server name to look up, e.g. `example.com`.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.MatrixServerName(),
+				},
+			},
+			"homeserver_url": schema.StringAttribute{
+				MarkdownDescription: "The base URL of the client-server API, without trailing slash.",
+				Computed:            true,
+			},
+			"identity_server_url": schema.StringAttribute{
+				MarkdownDescription: "The base URL of the identity server, empty if the server does not advertise one.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The server name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WellKnownDiscoveryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = contextAwareClient(ctx, providerData.Client)
+}
+
+func (d *WellKnownDiscoveryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WellKnownDiscoveryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	discovered, err := discoverHomeserver(ctx, d.client.Client, data.ServerName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Discovery Error", fmt.Sprintf("Unable to discover homeserver, got error: %s", err))
+		return
+	}
+
+	data.HomeserverURL = types.StringValue(discovered.HomeserverURL)
+	data.IdentityServerURL = types.StringValue(discovered.IdentityServerURL)
+	data.Id = data.ServerName
+
+	tflog.Trace(ctx, "discovered homeserver", map[string]any{"server_name": data.ServerName.ValueString(), "homeserver_url": discovered.HomeserverURL})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The test homeserver is usually not served over HTTPS, so this looks up
+// matrix.org, which delegates its client-server API to another host.
+func TestAccWellKnownDiscoveryDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccWellKnownDiscoveryDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.matrix_well_known_discovery.test", "homeserver_url", "https://matrix-client.matrix.org"),
+					resource.TestCheckResourceAttr("data.matrix_well_known_discovery.test", "id", "matrix.org"),
+				),
+			},
+		},
+	})
+}
+
+const testAccWellKnownDiscoveryDataSourceConfig = `
+data "matrix_well_known_discovery" "test" {
+  server_name = "matrix.org"
+}
+`
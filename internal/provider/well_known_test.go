@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverHomeserver(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		// wellKnown is the body of the well-known file, BASE is replaced by
+		// the URL of the test server. No file is served if empty.
+		wellKnown           string
+		serveIdentity       bool
+		expectError         bool
+		expectHomeserver    string
+		expectIdentity      string
+		expectServerNameURL bool
+	}{
+		"no-well-known": {
+			expectServerNameURL: true,
+		},
+		"delegated": {
+			wellKnown:        `{"m.homeserver": {"base_url": "BASE/"}}`,
+			expectHomeserver: "BASE",
+		},
+		"identity-server": {
+			wellKnown:        `{"m.homeserver": {"base_url": "BASE"}, "m.identity_server": {"base_url": "BASE"}}`,
+			serveIdentity:    true,
+			expectHomeserver: "BASE",
+			expectIdentity:   "BASE",
+		},
+		"identity-server-unreachable": {
+			wellKnown:   `{"m.homeserver": {"base_url": "BASE"}, "m.identity_server": {"base_url": "BASE"}}`,
+			expectError: true,
+		},
+		"invalid-json": {
+			wellKnown:   `{"m.homeserver": `,
+			expectError: true,
+		},
+		"missing-base-url": {
+			wellKnown:   `{"m.identity_server": {"base_url": "BASE"}}`,
+			expectError: true,
+		},
+		"invalid-base-url": {
+			wellKnown:   `{"m.homeserver": {"base_url": "matrix.example.com"}}`,
+			expectError: true,
+		},
+		"not-a-homeserver": {
+			wellKnown:   `{"m.homeserver": {"base_url": "BASE/elsewhere"}}`,
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var server *httptest.Server
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/.well-known/matrix/client" && testCase.wellKnown != "":
+					_, _ = w.Write([]byte(strings.ReplaceAll(testCase.wellKnown, "BASE", server.URL)))
+				case r.URL.Path == "/_matrix/client/versions":
+					_, _ = w.Write([]byte(`{"versions": ["v1.8"]}`))
+				case r.URL.Path == "/_matrix/identity/v2" && testCase.serveIdentity:
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			serverName := strings.TrimPrefix(server.URL, "https://")
+			result, err := discoverHomeserver(context.Background(), server.Client(), serverName)
+
+			if (err != nil) != testCase.expectError {
+				t.Fatalf("expected error: %t, got: %v", testCase.expectError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			expectHomeserver := strings.ReplaceAll(testCase.expectHomeserver, "BASE", server.URL)
+			if testCase.expectServerNameURL {
+				expectHomeserver = "https://" + serverName
+			}
+			if result.HomeserverURL != expectHomeserver {
+				t.Errorf("expected homeserver %q, got %q", expectHomeserver, result.HomeserverURL)
+			}
+
+			expectIdentity := strings.ReplaceAll(testCase.expectIdentity, "BASE", server.URL)
+			if result.IdentityServerURL != expectIdentity {
+				t.Errorf("expected identity server %q, got %q", expectIdentity, result.IdentityServerURL)
+			}
+		})
+	}
+}
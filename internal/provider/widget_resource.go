@@ -0,0 +1,396 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MTRNord/terraform-provider-matrix/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/matrix-org/gomatrix"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WidgetResource{}
+var _ resource.ResourceWithImportState = &WidgetResource{}
+
+// widgetEventTypes are the state event types of widgets. Element still uses
+// the legacy type, m.widget is the one of the proposed specification.
+var widgetEventTypes = []string{"im.vector.modular.widgets", "m.widget"}
+
+// widgetLayoutEventType is the state event Element uses to pin widgets to a
+// container of the room view.
+const widgetLayoutEventType = "io.element.widgets.layout"
+
+func NewWidgetResource() resource.Resource {
+	return &WidgetResource{}
+}
+
+// WidgetResource defines the resource implementation.
+type WidgetResource struct {
+	client *gomatrix.Client
+}
+
+// WidgetResourceModel describes the resource data model.
+type WidgetResourceModel struct {
+	RoomID    types.String         `tfsdk:"room_id"`
+	WidgetID  types.String         `tfsdk:"widget_id"`
+	EventType types.String         `tfsdk:"event_type"`
+	Type      types.String         `tfsdk:"type"`
+	URL       types.String         `tfsdk:"url"`
+	Name      types.String         `tfsdk:"name"`
+	DataJSON  jsontypes.Normalized `tfsdk:"data_json"`
+	Container types.String         `tfsdk:"container"`
+	Id        types.String         `tfsdk:"id"`
+}
+
+// widgetContent is the content of a widget state event.
+type widgetContent struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	URL           string          `json:"url"`
+	Name          string          `json:"name,omitempty"`
+	Data          json.RawMessage `json:"data,omitempty"`
+	CreatorUserID string          `json:"creatorUserId,omitempty"`
+}
+
+// getWidgetContainer returns the container a widget is pinned to in the
+// Element layout of a room, or an empty string.
+func getWidgetContainer(client *gomatrix.Client, roomID string, widgetID string) (string, error) {
+	var layout struct {
+		Widgets map[string]struct {
+			Container string `json:"container"`
+		} `json:"widgets"`
+	}
+	err := client.StateEvent(roomID, widgetLayoutEventType, "", &layout)
+	if err != nil && !isNotFound(err) {
+		return "", err
+	}
+
+	return layout.Widgets[widgetID].Container, nil
+}
+
+// setWidgetContainer pins a widget to a container in the Element layout of a
+// room, or unpins it if container is empty. The layout of other widgets and
+// unknown fields are kept.
+func setWidgetContainer(client *gomatrix.Client, roomID string, widgetID string, container string) error {
+	layout := map[string]interface{}{}
+	err := client.StateEvent(roomID, widgetLayoutEventType, "", &layout)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("unable to read %s state event: %w", widgetLayoutEventType, err)
+	}
+
+	widgets, _ := layout["widgets"].(map[string]interface{})
+	if widgets == nil {
+		widgets = map[string]interface{}{}
+	}
+
+	if container == "" {
+		delete(widgets, widgetID)
+	} else {
+		widgets[widgetID] = map[string]interface{}{"container": container}
+	}
+	layout["widgets"] = widgets
+
+	_, err = client.SendStateEvent(roomID, widgetLayoutEventType, "", layout)
+	if err != nil {
+		return fmt.Errorf("unable to send %s state event: %w", widgetLayoutEventType, err)
+	}
+
+	return nil
+}
+
+func (r *WidgetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_widget"
+}
+
+func (r *WidgetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a widget to a room, e.g. a Grafana dashboard or a Jitsi or Element Call conference, " +
+			"which clients like Element embed next to the timeline. Destroying the resource removes the widget.\n\n" +
+			"The provider user must be allowed to send the state events, widgets usually need moderator power.",
+
+		Attributes: map[string]schema.Attribute{
+			"room_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the room.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.MatrixRoomID(),
+				},
+			},
+			"widget_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the widget, unique within the room, e.g. `grafana`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"event_type": schema.StringAttribute{
+				MarkdownDescription: "The state event type of the widget, `im.vector.modular.widgets` (the default), " +
+					"which Element understands, or `m.widget`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(widgetEventTypes[0]),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(widgetEventTypes...),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the widget, e.g. `m.custom`, `m.grafana`, `m.jitsi` or `m.etherpad`.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the widget. Clients replace template variables like `$matrix_user_id` " +
+					"or `$matrix_room_id`, and ones from `data_json` like `$theme`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpURLRegexp, "value must be an absolute http or https URL"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the widget, shown by clients.",
+				Optional:            true,
+			},
+			"data_json": schema.StringAttribute{
+				MarkdownDescription: "Widget specific data as JSON object, e.g. from `jsonencode`, which also provides " +
+					"template variables for `url`.",
+				CustomType: jsontypes.NormalizedType{},
+				Optional:   true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"container": schema.StringAttribute{
+				MarkdownDescription: "Pin the widget to a part of the room view in Element, `top` above the timeline or " +
+					"`right` in the side panel, using the `io.element.widgets.layout` state event.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("top", "right"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier in the form `room_id/event_type/widget_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *WidgetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*MatrixProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MatrixProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = contextAwareClient(ctx, providerData.Client)
+}
+
+// send sends the widget state event.
+func (r *WidgetResource) send(data WidgetResourceModel) error {
+	content := widgetContent{
+		ID:            data.WidgetID.ValueString(),
+		Type:          data.Type.ValueString(),
+		URL:           data.URL.ValueString(),
+		Name:          data.Name.ValueString(),
+		CreatorUserID: r.client.UserID,
+	}
+	if !data.DataJSON.IsNull() {
+		content.Data = json.RawMessage(data.DataJSON.ValueString())
+	}
+
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.EventType.ValueString(), data.WidgetID.ValueString(), content)
+	if err != nil {
+		return fmt.Errorf("unable to send %s state event: %w", data.EventType.ValueString(), err)
+	}
+
+	return nil
+}
+
+func (r *WidgetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WidgetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add widget, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.RoomID.ValueString() + "/" + data.EventType.ValueString() + "/" + data.WidgetID.ValueString())
+
+	if !data.Container.IsNull() {
+		err = setWidgetContainer(r.client, data.RoomID.ValueString(), data.WidgetID.ValueString(), data.Container.ValueString())
+		if err != nil {
+			// Keep the widget in the state, the next plan shows the missing
+			// layout as drift.
+			data.Container = types.StringNull()
+			resp.Diagnostics.AddWarning(
+				"Widget Not Pinned",
+				fmt.Sprintf("The widget was added, but pinning it failed with error: %s", err),
+			)
+		}
+	}
+
+	tflog.Trace(ctx, "added widget", map[string]any{"id": data.Id.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WidgetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WidgetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content widgetContent
+	err := r.client.StateEvent(data.RoomID.ValueString(), data.EventType.ValueString(), data.WidgetID.ValueString(), &content)
+	if err != nil {
+		if isNotFound(err) || matrixErrCode(err) == "M_FORBIDDEN" {
+			tflog.Warn(ctx, "widget no longer exists, removing from state", map[string]any{"id": data.Id.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", data.EventType.ValueString(), err))
+		return
+	}
+
+	// Widgets are removed by sending empty content.
+	if content.URL == "" {
+		tflog.Warn(ctx, "widget was removed, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Type = types.StringValue(content.Type)
+	data.URL = types.StringValue(content.URL)
+
+	if content.Name != "" || !data.Name.IsNull() {
+		data.Name = types.StringValue(content.Name)
+	}
+
+	// Semantic equality keeps the configured formatting unless the data
+	// really changed.
+	if len(content.Data) > 0 && !(data.DataJSON.IsNull() && jsonEqual(content.Data, []byte("{}"))) {
+		data.DataJSON = jsontypes.NewNormalizedValue(string(content.Data))
+	} else {
+		data.DataJSON = jsontypes.NewNormalizedNull()
+	}
+
+	container, err := getWidgetContainer(r.client, data.RoomID.ValueString(), data.WidgetID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s state event, got error: %s", widgetLayoutEventType, err))
+		return
+	}
+
+	if container != "" {
+		data.Container = types.StringValue(container)
+	} else {
+		data.Container = types.StringNull()
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WidgetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state WidgetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.send(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update widget, got error: %s", err))
+		return
+	}
+
+	if !data.Container.Equal(state.Container) {
+		err = setWidgetContainer(r.client, data.RoomID.ValueString(), data.WidgetID.ValueString(), data.Container.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to pin widget, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WidgetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WidgetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Container.IsNull() {
+		err := setWidgetContainer(r.client, data.RoomID.ValueString(), data.WidgetID.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unpin widget, got error: %s", err))
+			return
+		}
+	}
+
+	// State events cannot be deleted, empty content removes the widget.
+	_, err := r.client.SendStateEvent(data.RoomID.ValueString(), data.EventType.ValueString(), data.WidgetID.ValueString(), struct{}{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove widget, got error: %s", err))
+		return
+	}
+}
+
+func (r *WidgetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importCompositeID(ctx, req, resp, "room_id", "event_type", "widget_id")
+}
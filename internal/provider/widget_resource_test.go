@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWidgetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWidgetResourceConfig("Dashboard", `"top"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_widget.test", "event_type", "im.vector.modular.widgets"),
+					resource.TestCheckResourceAttr("matrix_widget.test", "name", "Dashboard"),
+					resource.TestCheckResourceAttr("matrix_widget.test", "container", "top"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "matrix_widget.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing unpins the widget
+			{
+				Config: testAccWidgetResourceConfig("Grafana", "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("matrix_widget.test", "name", "Grafana"),
+					resource.TestCheckNoResourceAttr("matrix_widget.test", "container"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWidgetResourceConfig(name string, container string) string {
+	return fmt.Sprintf(`
+resource "matrix_room" "test" {
+  name = "Operations"
+}
+
+resource "matrix_widget" "test" {
+  room_id   = matrix_room.test.room_id
+  widget_id = "grafana"
+  type      = "m.grafana"
+  url       = "https://grafana.example.com/d/overview?theme=$theme"
+  name      = %q
+  data_json = jsonencode({ theme = "dark" })
+  container = %s
+}
+`, name, container)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// emailRegexp is deliberately loose: a local part, an @ and a domain with at
+// least one dot. The homeserver does the authoritative validation.
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s.]+$`)
+
+// EmailAddress returns a validator which ensures that any configured string
+// value looks like an email address.
+func EmailAddress() validator.String {
+	return stringvalidator.RegexMatches(emailRegexp, "value must be a valid email address")
+}
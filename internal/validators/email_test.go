@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailAddress(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":         {value: types.StringNull()},
+		"unknown":      {value: types.StringUnknown()},
+		"simple":       {value: types.StringValue("alice@example.com")},
+		"plus":         {value: types.StringValue("alice+matrix@example.com")},
+		"subdomain":    {value: types.StringValue("alice@mail.example.co.uk")},
+		"empty":        {value: types.StringValue(""), expectError: true},
+		"no-at":        {value: types.StringValue("alice.example.com"), expectError: true},
+		"two-ats":      {value: types.StringValue("alice@bob@example.com"), expectError: true},
+		"no-local":     {value: types.StringValue("@example.com"), expectError: true},
+		"no-tld":       {value: types.StringValue("alice@localhost"), expectError: true},
+		"whitespace":   {value: types.StringValue("alice @example.com"), expectError: true},
+		"trailing-dot": {value: types.StringValue("alice@example."), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			EmailAddress().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
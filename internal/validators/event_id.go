@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// eventIDRegexp accepts the event IDs of all room versions: $localpart:server
+// in versions 1 and 2, standard base64 of the reference hash in version 3 and
+// URL-safe base64 from version 4 on.
+var eventIDRegexp = regexp.MustCompile(`^\$([A-Za-z0-9+/_\-]+|[\x21-\x39\x3B-\x7E]+:` + serverNamePattern + `)$`)
+
+// MatrixEventID returns a validator which ensures that any configured string
+// value is a valid Matrix event ID in the form $opaque_id or
+// $localpart:server.
+func MatrixEventID() validator.String {
+	return matrixIDValidator{
+		regexp:  eventIDRegexp,
+		message: "value must be a valid Matrix event ID ($opaque_id)",
+	}
+}
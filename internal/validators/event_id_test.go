@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatrixEventID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":            {value: types.StringNull()},
+		"unknown":         {value: types.StringUnknown()},
+		"v1":              {value: types.StringValue("$h29iv0s8:example.com")},
+		"v1-port":         {value: types.StringValue("$h29iv0s8:example.com:8448")},
+		"v3":              {value: types.StringValue("$acR1l0raoZnm60CBwAVgqbZqoO/mYU81xysh1u7XcJk")},
+		"v4":              {value: types.StringValue("$Rqnc-F-dvnEYJTyHq_iKxU2bZ1CI92-kuZq3a5lr5Zg")},
+		"max-length":      {value: types.StringValue("$" + strings.Repeat("a", 254))},
+		"empty":           {value: types.StringValue(""), expectError: true},
+		"sigil-only":      {value: types.StringValue("$"), expectError: true},
+		"no-sigil":        {value: types.StringValue("Rqnc-F-dvnEYJTyHq_iKxU2bZ1CI92-kuZq3a5lr5Zg"), expectError: true},
+		"room":            {value: types.StringValue("!abc:example.com"), expectError: true},
+		"empty-localpart": {value: types.StringValue("$:example.com"), expectError: true},
+		"space":           {value: types.StringValue("$abc def"), expectError: true},
+		"invalid-server":  {value: types.StringValue("$abc:example_com"), expectError: true},
+		"too-long":        {value: types.StringValue("$" + strings.Repeat("a", 255)), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			MatrixEventID().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONObject(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":    {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+		"empty":   {value: types.StringValue("{}")},
+		"object":  {value: types.StringValue(`{"algorithm": "m.megolm.v1.aes-sha2"}`)},
+		"nested":  {value: types.StringValue(`{"users": {"@alice:example.com": 100}}`)},
+		"string":  {value: types.StringValue(""), expectError: true},
+		"null-js": {value: types.StringValue("null"), expectError: true},
+		"array":   {value: types.StringValue("[]"), expectError: true},
+		"number":  {value: types.StringValue("1"), expectError: true},
+		"invalid": {value: types.StringValue(`{"name": }`), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			JSONObject().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// maxMatrixIDLength is the maximum length of user, room and event IDs in
+// bytes, including the sigil and server name.
+const maxMatrixIDLength = 255
+
+var _ validator.String = matrixIDValidator{}
+
+// matrixIDValidator validates the grammar and length of a Matrix identifier.
+type matrixIDValidator struct {
+	regexp  *regexp.Regexp
+	message string
+}
+
+func (v matrixIDValidator) Description(_ context.Context) string {
+	return v.message
+}
+
+func (v matrixIDValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v matrixIDValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if len(value) > maxMatrixIDLength || !v.regexp.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Matrix Identifier",
+			fmt.Sprintf("Attribute %s %s and at most %d bytes long, got: %s", req.Path, v.Description(ctx), maxMatrixIDLength, value),
+		)
+	}
+}
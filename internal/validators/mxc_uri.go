@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// mxcURIRegexp matches mxc://<server-name>/<media-id>, media IDs only use
+// the characters allowed by the Matrix specification.
+var mxcURIRegexp = regexp.MustCompile(`^mxc://` + serverNamePattern + `/[A-Za-z0-9_\-]+$`)
+
+// MxcURI returns a validator which ensures that any configured string value
+// is a valid mxc:// content URI.
+func MxcURI() validator.String {
+	return stringvalidator.RegexMatches(mxcURIRegexp, "value must be an mxc:// URI")
+}
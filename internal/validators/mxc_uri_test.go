@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMxcURI(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":         {value: types.StringNull()},
+		"unknown":      {value: types.StringUnknown()},
+		"simple":       {value: types.StringValue("mxc://example.com/SEsfnsuifSDFSSEF")},
+		"port":         {value: types.StringValue("mxc://example.com:8448/abc_DEF-123")},
+		"empty":        {value: types.StringValue(""), expectError: true},
+		"http":         {value: types.StringValue("https://example.com/abc"), expectError: true},
+		"no-media-id":  {value: types.StringValue("mxc://example.com/"), expectError: true},
+		"no-server":    {value: types.StringValue("mxc:///abc"), expectError: true},
+		"extra-path":   {value: types.StringValue("mxc://example.com/abc/def"), expectError: true},
+		"invalid-char": {value: types.StringValue("mxc://example.com/abc.def"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			MxcURI().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
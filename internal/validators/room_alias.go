@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// roomAliasRegexp accepts any printable ASCII character except the colon in
+// the localpart, like user IDs.
+var roomAliasRegexp = regexp.MustCompile(`^#[\x21-\x39\x3B-\x7E]+:` + serverNamePattern + `$`)
+
+// MatrixRoomAlias returns a validator which ensures that any configured
+// string value is a valid Matrix room alias in the form #localpart:server.
+func MatrixRoomAlias() validator.String {
+	return matrixIDValidator{
+		regexp:  roomAliasRegexp,
+		message: "value must be a valid Matrix room alias (#localpart:server)",
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatrixRoomAlias(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":             {value: types.StringNull()},
+		"unknown":          {value: types.StringUnknown()},
+		"simple":           {value: types.StringValue("#lobby:example.com")},
+		"port":             {value: types.StringValue("#lobby:example.com:8448")},
+		"special":          {value: types.StringValue("#team.ops_on-call:example.com")},
+		"max-length":       {value: types.StringValue("#" + strings.Repeat("a", 242) + ":example.com")},
+		"empty":            {value: types.StringValue(""), expectError: true},
+		"no-sigil":         {value: types.StringValue("lobby:example.com"), expectError: true},
+		"room-sigil":       {value: types.StringValue("!lobby:example.com"), expectError: true},
+		"no-server":        {value: types.StringValue("#lobby"), expectError: true},
+		"empty-localpart":  {value: types.StringValue("#:example.com"), expectError: true},
+		"space":            {value: types.StringValue("#lob by:example.com"), expectError: true},
+		"too-long":         {value: types.StringValue("#" + strings.Repeat("a", 243) + ":example.com"), expectError: true},
+		"trailing-newline": {value: types.StringValue("#lobby:example.com\n"), expectError: true},
+	}
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			MatrixRoomAlias().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// roomIDRegexp accepts room IDs with an opaque localpart and server name, as
+// well as the server-less room IDs of room version 12 and later, which are
+// the unpadded URL-safe base64 encoding of the create event reference hash.
+var roomIDRegexp = regexp.MustCompile(`^!([A-Za-z0-9_\-]{43}|[\x21-\x39\x3B-\x7E]+:` + serverNamePattern + `)$`)
+
+// MatrixRoomID returns a validator which ensures that any configured string
+// value is a valid Matrix room ID in the form !localpart:server. Room
+// aliases are rejected.
+func MatrixRoomID() validator.String {
+	return matrixIDValidator{
+		regexp:  roomIDRegexp,
+		message: "value must be a valid Matrix room ID (!localpart:server)",
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatrixRoomID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":            {value: types.StringNull()},
+		"unknown":         {value: types.StringUnknown()},
+		"spec-example":    {value: types.StringValue("!jEsUZKDJdhlrceRyVU:example.org")},
+		"port":            {value: types.StringValue("!abc:example.org:8448")},
+		"ipv4":            {value: types.StringValue("!abc:1.2.3.4")},
+		"v12":             {value: types.StringValue("!31hneApxJ_1o-63DmFrpeqnkFfWppnzWso1JvH3ogLM")},
+		"max-length":      {value: types.StringValue("!" + strings.Repeat("a", 242) + ":example.com")},
+		"empty":           {value: types.StringValue(""), expectError: true},
+		"alias":           {value: types.StringValue("#room:example.org"), expectError: true},
+		"user":            {value: types.StringValue("@alice:example.org"), expectError: true},
+		"no-server":       {value: types.StringValue("!abc"), expectError: true},
+		"empty-localpart": {value: types.StringValue("!:example.org"), expectError: true},
+		"space":           {value: types.StringValue("!a b:example.org"), expectError: true},
+		"invalid-server":  {value: types.StringValue("!abc:example_org"), expectError: true},
+		"v12-too-short":   {value: types.StringValue("!31hneApxJ_1o-63DmFrpeqnkFfWppnzWso1JvH3ogL"), expectError: true},
+		"too-long":        {value: types.StringValue("!" + strings.Repeat("a", 243) + ":example.com"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			MatrixRoomID().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// serverNamePattern follows the grammar from the Matrix specification
+// appendix: a DNS name, IPv4 literal or bracketed IPv6 literal, optionally
+// followed by a port. It is shared with the identifiers containing a server
+// name.
+const serverNamePattern = `(\[[0-9A-Fa-f:.]{2,45}\]|[0-9]{1,3}(\.[0-9]{1,3}){3}|[A-Za-z0-9\-.]{1,255})(:[0-9]{1,5})?`
+
+var serverNameRegexp = regexp.MustCompile(`^` + serverNamePattern + `$`)
+
+// MatrixServerName returns a validator which ensures that any configured
+// string value is a valid Matrix server name in the form host[:port].
+func MatrixServerName() validator.String {
+	return stringvalidator.RegexMatches(serverNameRegexp, "value must be a valid Matrix server name (host[:port])")
+}
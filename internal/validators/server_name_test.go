@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatrixServerName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":          {value: types.StringNull()},
+		"unknown":       {value: types.StringUnknown()},
+		"dns":           {value: types.StringValue("matrix.org")},
+		"dns-port":      {value: types.StringValue("matrix.org:8448")},
+		"localhost":     {value: types.StringValue("localhost")},
+		"ipv4":          {value: types.StringValue("1.2.3.4")},
+		"ipv4-port":     {value: types.StringValue("1.2.3.4:1234")},
+		"ipv6":          {value: types.StringValue("[1234:5678::abcd]")},
+		"ipv6-port":     {value: types.StringValue("[1234:5678::abcd]:5678")},
+		"empty":         {value: types.StringValue(""), expectError: true},
+		"scheme":        {value: types.StringValue("https://matrix.org"), expectError: true},
+		"path":          {value: types.StringValue("matrix.org/media"), expectError: true},
+		"empty-port":    {value: types.StringValue("matrix.org:"), expectError: true},
+		"port-too-long": {value: types.StringValue("matrix.org:123456"), expectError: true},
+		"ipv6-bare":     {value: types.StringValue("1234:5678::abcd"), expectError: true},
+		"underscore":    {value: types.StringValue("matrix_org"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			MatrixServerName().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// userIDRegexp accepts the historical user ID grammar, which allows any
+// printable ASCII character except the colon in the localpart. Homeservers
+// still have to accept such users over federation.
+var userIDRegexp = regexp.MustCompile(`^@[\x21-\x39\x3B-\x7E]+:` + serverNamePattern + `$`)
+
+// MatrixUserID returns a validator which ensures that any configured string
+// value is a valid Matrix user ID in the form @localpart:server.
+func MatrixUserID() validator.String {
+	return matrixIDValidator{
+		regexp:  userIDRegexp,
+		message: "value must be a valid Matrix user ID (@localpart:server)",
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatrixUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"null":             {value: types.StringNull()},
+		"unknown":          {value: types.StringUnknown()},
+		"simple":           {value: types.StringValue("@alice:example.com")},
+		"port":             {value: types.StringValue("@alice:example.com:8448")},
+		"ipv6":             {value: types.StringValue("@alice:[1234:5678::abcd]")},
+		"special":          {value: types.StringValue("@a.b_c-d=e/f+g:example.com")},
+		"historical":       {value: types.StringValue("@Alice!:example.com")},
+		"max-length":       {value: types.StringValue("@" + strings.Repeat("a", 242) + ":example.com")},
+		"empty":            {value: types.StringValue(""), expectError: true},
+		"no-sigil":         {value: types.StringValue("alice:example.com"), expectError: true},
+		"room-sigil":       {value: types.StringValue("!alice:example.com"), expectError: true},
+		"no-server":        {value: types.StringValue("@alice"), expectError: true},
+		"empty-localpart":  {value: types.StringValue("@:example.com"), expectError: true},
+		"space":            {value: types.StringValue("@al ice:example.com"), expectError: true},
+		"invalid-server":   {value: types.StringValue("@alice:example_com"), expectError: true},
+		"too-long":         {value: types.StringValue("@" + strings.Repeat("a", 243) + ":example.com"), expectError: true},
+		"trailing-newline": {value: types.StringValue("@alice:example.com\n"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			MatrixUserID().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}